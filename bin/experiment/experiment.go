@@ -25,6 +25,7 @@ import (
 	gcpVMDiskLoss "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-vm-disk-loss/experiment"
 	gcpVMInstanceStopByLabel "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-vm-instance-stop-by-label/experiment"
 	gcpVMInstanceStop "github.com/litmuschaos/litmus-go/experiments/gcp/gcp-vm-instance-stop/experiment"
+	cleanup "github.com/litmuschaos/litmus-go/experiments/generic/cleanup/experiment"
 	containerKill "github.com/litmuschaos/litmus-go/experiments/generic/container-kill/experiment"
 	diskFill "github.com/litmuschaos/litmus-go/experiments/generic/disk-fill/experiment"
 	dockerServiceKill "github.com/litmuschaos/litmus-go/experiments/generic/docker-service-kill/experiment"
@@ -55,6 +56,8 @@ import (
 	podNetworkLatency "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-latency/experiment"
 	podNetworkLoss "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-loss/experiment"
 	podNetworkPartition "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-partition/experiment"
+	podNetworkRateLimit "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-rate-limit/experiment"
+	scenario "github.com/litmuschaos/litmus-go/experiments/generic/scenario/experiment"
 	kafkaBrokerPodFailure "github.com/litmuschaos/litmus-go/experiments/kafka/kafka-broker-pod-failure/experiment"
 	ebsLossByID "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss-by-id/experiment"
 	ebsLossByTag "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss-by-tag/experiment"
@@ -65,18 +68,15 @@ import (
 	vmpoweroff "github.com/litmuschaos/litmus-go/experiments/vmware/vm-poweroff/experiment"
 	cli "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/notify"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
-	"github.com/sirupsen/logrus"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"go.opentelemetry.io/otel"
 )
 
 func init() {
-	// Log as JSON instead of the default ASCII formatter.
-	logrus.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:          true,
-		DisableSorting:         true,
-		DisableLevelTruncation: true,
-	})
+	// Configure the log format, level and the standard fields from the envs
+	log.Configure()
 }
 
 func main() {
@@ -95,10 +95,19 @@ func main() {
 		initCtx = telemetry.GetTraceParentContext()
 	}
 
+	// the lifecycle notifications are delivered in the background, the queued ones are flushed before the exit
+	defer notify.Flush(notify.FlushTimeout)
+
+	// the root context is cancelled upon abort signal or experiment deadline
+	rootCtx, cancel := common.InitRootContext(initCtx)
+	defer cancel()
+
 	clients := cli.ClientSets{}
 
-	ctx, span := otel.Tracer(telemetry.TracerName).Start(initCtx, "ExecuteExperiment")
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(rootCtx, "ExecuteExperiment")
 	defer span.End()
+	// the log lines logged without a context are correlated with the root span
+	log.SetDefaultContext(ctx)
 
 	// parse the experiment name
 	experimentName := flag.String("name", "pod-delete", "name of the chaos experiment")
@@ -113,6 +122,8 @@ func main() {
 
 	// invoke the corresponding experiment based on the (-name) flag
 	switch *experimentName {
+	case "cleanup":
+		cleanup.Cleanup(ctx, clients)
 	case "container-kill":
 		containerKill.ContainerKill(ctx, clients)
 	case "disk-fill":
@@ -153,6 +164,8 @@ func main() {
 		podNetworkLoss.PodNetworkLoss(ctx, clients)
 	case "pod-network-partition":
 		podNetworkPartition.PodNetworkPartition(ctx, clients)
+	case "pod-network-rate-limit":
+		podNetworkRateLimit.PodNetworkRateLimit(ctx, clients)
 	case "pod-memory-hog":
 		podMemoryHog.PodMemoryHog(ctx, clients)
 	case "pod-cpu-hog":
@@ -207,10 +220,17 @@ func main() {
 		gcpVMDiskLossByLabel.GCPVMDiskLossByLabel(ctx, clients)
 	case "spring-boot-cpu-stress", "spring-boot-memory-stress", "spring-boot-exceptions", "spring-boot-app-kill", "spring-boot-faults", "spring-boot-latency":
 		springBootFaults.Experiment(ctx, clients, *experimentName)
+	case "scenario":
+		scenario.Scenario(ctx, clients)
 	case "k6-loadgen":
 		k6Loadgen.Experiment(ctx, clients)
 	default:
 		log.Errorf("Unsupported -name %v, please provide the correct value of -name args", *experimentName)
 		return
 	}
+
+	// the chaos unwinds once the run is aborted, the process exits only after the revert phase is completed
+	if common.Aborted() {
+		common.Exit(1)
+	}
 }
@@ -15,6 +15,7 @@ import (
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/openstack"
 
+	cleanup "github.com/litmuschaos/litmus-go/chaoslib/litmus/cleanup/helper"
 	containerKill "github.com/litmuschaos/litmus-go/chaoslib/litmus/container-kill/helper"
 	diskFill "github.com/litmuschaos/litmus-go/chaoslib/litmus/disk-fill/helper"
 	httpChaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/helper"
@@ -23,39 +24,45 @@ import (
 	stressChaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/stress-chaos/helper"
 	cli "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/notify"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
-	"github.com/sirupsen/logrus"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"go.opentelemetry.io/otel"
 )
 
 func init() {
-	// Log as JSON instead of the default ASCII formatter
-	logrus.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:          true,
-		DisableSorting:         true,
-		DisableLevelTruncation: true,
-	})
+	// Configure the log format, level and the standard fields from the envs
+	log.Configure()
 }
 
 func main() {
-	ctx := context.Background()
+	initCtx := context.Background()
 	// Set up Observability.
 	if otelExporterEndpoint := os.Getenv(telemetry.OTELExporterOTLPEndpoint); otelExporterEndpoint != "" {
-		shutdown, err := telemetry.InitOTelSDK(ctx, true, otelExporterEndpoint)
+		shutdown, err := telemetry.InitOTelSDK(initCtx, true, otelExporterEndpoint)
 		if err != nil {
 			log.Errorf("Failed to initialize OTel SDK: %v", err)
 			return
 		}
 		defer func() {
-			err = errors.Join(err, shutdown(ctx))
+			err = errors.Join(err, shutdown(initCtx))
 		}()
-		ctx = telemetry.GetTraceParentContext()
+		initCtx = telemetry.GetTraceParentContext()
 	}
 
+	// the lifecycle notifications are delivered in the background, the queued ones are flushed before the exit
+	defer notify.Flush(notify.FlushTimeout)
+
+	// the root context is cancelled upon abort signal or experiment deadline
+	rootCtx, cancel := common.InitRootContext(initCtx)
+	defer cancel()
+
 	clients := cli.ClientSets{}
 
-	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "ExecuteExperimentHelper")
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(rootCtx, "ExecuteExperimentHelper")
 	defer span.End()
+	// the log lines logged without a context are correlated with the root span
+	log.SetDefaultContext(ctx)
 
 	// parse the helper name
 	helperName := flag.String("name", "", "name of the helper pod")
@@ -82,9 +89,16 @@ func main() {
 		networkChaos.Helper(ctx, clients)
 	case "http-chaos":
 		httpChaos.Helper(ctx, clients)
+	case "cleanup":
+		cleanup.Helper(ctx, clients)
 
 	default:
 		log.Errorf("Unsupported -name %v, please provide the correct value of -name args", *helperName)
 		return
 	}
+
+	// the chaos unwinds once the run is aborted, the process exits only after the revert phase is completed
+	if common.Aborted() {
+		common.Exit(1)
+	}
 }
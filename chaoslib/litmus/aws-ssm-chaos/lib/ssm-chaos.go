@@ -27,7 +27,7 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -49,7 +49,7 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				//Sending AWS SSM command
 				log.Info("[Chaos]: Starting the ssm command")
 				ec2IDList := strings.Fields(ec2ID)
-				commandId, err := ssm.SendSSMCommand(experimentsDetails, ec2IDList)
+				commandId, err := ssm.SendSSMCommand(ctx, experimentsDetails, ec2IDList)
 				if err != nil {
 					return stacktrace.Propagate(err, "failed to send ssm command")
 				}
@@ -79,7 +79,9 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Wait for chaos interval
 				log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-				time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+				if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+					return err
+				}
 
 			}
 			duration = int(time.Since(ChaosStartTimeStamp).Seconds())
@@ -97,7 +99,7 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -113,26 +115,33 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 				events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 			}
 
-			//Sending AWS SSM command
-			log.Info("[Chaos]: Starting the ssm command")
-			commandId, err := ssm.SendSSMCommand(experimentsDetails, instanceIDList)
-			if err != nil {
-				return stacktrace.Propagate(err, "failed to send ssm command")
+			//Sending AWS SSM command to every instance, as per its start offset in the staggered sequence
+			commandIDs := make(map[string]string, len(instanceIDList))
+			for _, ec2ID := range instanceIDList {
+				if err := common.WaitForStaggeredStart(ctx); err != nil {
+					return err
+				}
+				log.Info("[Chaos]: Starting the ssm command")
+				commandId, err := ssm.SendSSMCommand(ctx, experimentsDetails, []string{ec2ID})
+				if err != nil {
+					return stacktrace.Propagate(err, "failed to send ssm command")
+				}
+				//prepare commands for abort recovery
+				experimentsDetails.CommandIDs = append(experimentsDetails.CommandIDs, commandId)
+				commandIDs[ec2ID] = commandId
 			}
-			//prepare commands for abort recovery
-			experimentsDetails.CommandIDs = append(experimentsDetails.CommandIDs, commandId)
 
 			for _, ec2ID := range instanceIDList {
 				//wait for the ssm command to get in running state
 				log.Info("[Wait]: Waiting for the ssm command to get in InProgress state")
-				if err := ssm.WaitForCommandStatus("InProgress", commandId, ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+				if err := ssm.WaitForCommandStatus("InProgress", commandIDs[ec2ID], ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "failed to start ssm command")
 				}
 			}
 
 			// run the probes during chaos
 			if len(resultDetails.ProbeDetails) != 0 {
-				if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+				if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return stacktrace.Propagate(err, "failed to run probes")
 				}
 			}
@@ -140,14 +149,16 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			for _, ec2ID := range instanceIDList {
 				//wait for the ssm command to get succeeded in the given chaos duration
 				log.Info("[Wait]: Waiting for the ssm command to get completed")
-				if err := ssm.WaitForCommandStatus("Success", commandId, ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
+				if err := ssm.WaitForCommandStatus("Success", commandIDs[ec2ID], ec2ID, experimentsDetails.Region, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.Delay); err != nil {
 					return stacktrace.Propagate(err, "failed to send ssm command")
 				}
 			}
 
 			//Wait for chaos interval
 			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-			time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			duration = int(time.Since(ChaosStartTimeStamp).Seconds())
 		}
@@ -157,7 +168,9 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // AbortWatcher will be watching for the abort signal and revert the chaos
-func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, abort chan os.Signal) {
+func AbortWatcher(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, abort chan os.Signal) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -165,7 +178,7 @@ func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, abort c
 	switch {
 	case len(experimentsDetails.CommandIDs) != 0:
 		for _, commandId := range experimentsDetails.CommandIDs {
-			if err := ssm.CancelCommand(commandId, experimentsDetails.Region); err != nil {
+			if err := ssm.CancelCommand(ctx, commandId, experimentsDetails.Region); err != nil {
 				log.Errorf("[Abort]: Failed to cancel command, recovery failed: %v", err)
 			}
 		}
@@ -176,5 +189,6 @@ func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, abort c
 		log.Errorf("Failed to delete ssm document: %v", err)
 	}
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
@@ -2,11 +2,8 @@ package ssm
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 
 	"github.com/litmuschaos/litmus-go/chaoslib/litmus/aws-ssm-chaos/lib"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/types"
@@ -33,18 +30,20 @@ func PrepareAWSSSMChaosByID(ctx context.Context, experimentsDetails *experimentT
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//create and upload the ssm document on the given aws service monitoring docs
@@ -55,7 +54,7 @@ func PrepareAWSSSMChaosByID(ctx context.Context, experimentsDetails *experimentT
 	log.Info("[Info]: SSM docs uploaded successfully")
 
 	// watching for the abort signal and revert the chaos
-	go lib.AbortWatcher(experimentsDetails, abort)
+	go lib.AbortWatcher(ctx, experimentsDetails, abort)
 
 	//get the instance id or list of instance ids
 	instanceIDList := strings.Split(experimentsDetails.EC2InstanceID, ",")
@@ -63,17 +62,12 @@ func PrepareAWSSSMChaosByID(ctx context.Context, experimentsDetails *experimentT
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no instance id found for chaos injection"}
 	}
 
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = lib.InjectChaosInSerialMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails, inject); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = lib.InjectChaosInParallelMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails, inject); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, instanceIDList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+		return lib.InjectChaosInSerialMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails, inject)
+	}, func(ctx context.Context, targets []string) error {
+		return lib.InjectChaosInParallelMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails, inject)
+	}); err != nil {
+		return err
 	}
 
 	//Delete the ssm document on the given aws service monitoring docs
@@ -85,7 +79,9 @@ func PrepareAWSSSMChaosByID(ctx context.Context, experimentsDetails *experimentT
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -2,11 +2,7 @@ package ssm
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
-	"strings"
-	"syscall"
 
 	"github.com/litmuschaos/litmus-go/chaoslib/litmus/aws-ssm-chaos/lib"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/types"
@@ -28,18 +24,20 @@ func PrepareAWSSSMChaosByTag(ctx context.Context, experimentsDetails *experiment
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//create and upload the ssm document on the given aws service monitoring docs
@@ -50,25 +48,20 @@ func PrepareAWSSSMChaosByTag(ctx context.Context, experimentsDetails *experiment
 	log.Info("[Info]: SSM docs uploaded successfully")
 
 	// watching for the abort signal and revert the chaos
-	go lib.AbortWatcher(experimentsDetails, abort)
-	instanceIDList := common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, experimentsDetails.TargetInstanceIDList)
+	go lib.AbortWatcher(ctx, experimentsDetails, abort)
+	instanceIDList := common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, experimentsDetails.TargetInstanceIDList, chaosDetails)
 	log.Infof("[Chaos]:Number of Instance targeted: %v", len(instanceIDList))
 
 	if len(instanceIDList) == 0 {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "no instance id found for chaos injection"}
 	}
 
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = lib.InjectChaosInSerialMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails, inject); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = lib.InjectChaosInParallelMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails, inject); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, instanceIDList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+		return lib.InjectChaosInSerialMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails, inject)
+	}, func(ctx context.Context, targets []string) error {
+		return lib.InjectChaosInParallelMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails, inject)
+	}); err != nil {
+		return err
 	}
 
 	//Delete the ssm document on the given aws service monitoring docs
@@ -80,7 +73,9 @@ func PrepareAWSSSMChaosByTag(ctx context.Context, experimentsDetails *experiment
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
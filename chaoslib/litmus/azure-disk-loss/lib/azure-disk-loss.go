@@ -2,11 +2,9 @@ package lib
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
+	"sort"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
@@ -38,18 +36,20 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//get the disk name  or list of disk names
@@ -76,29 +76,35 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 
 		// watching for the abort signal and revert the chaos
-		go abortWatcher(experimentsDetails, attachedDisksWithInstance, instanceNamesWithDiskNames, chaosDetails)
+		go abortWatcher(ctx, experimentsDetails, attachedDisksWithInstance, instanceNamesWithDiskNames, chaosDetails)
 
-		switch strings.ToLower(experimentsDetails.Sequence) {
-		case "serial":
-			if err = injectChaosInSerialMode(ctx, experimentsDetails, instanceNamesWithDiskNames, attachedDisksWithInstance, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in serial mode")
-			}
-		case "parallel":
-			if err = injectChaosInParallelMode(ctx, experimentsDetails, instanceNamesWithDiskNames, attachedDisksWithInstance, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-			}
-		default:
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+		// the instances are the targets of the sequence, every instance is injected along with all of its target disks
+		instanceNames := make([]string, 0, len(instanceNamesWithDiskNames))
+		for instanceName := range instanceNamesWithDiskNames {
+			instanceNames = append(instanceNames, instanceName)
+		}
+		sort.Strings(instanceNames)
+
+		if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, instanceNames, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+			diskNames, attachedDisks := getTargetDisks(targets, instanceNamesWithDiskNames, attachedDisksWithInstance)
+			return injectChaosInSerialMode(ctx, experimentsDetails, diskNames, attachedDisks, clients, resultDetails, eventsDetails, chaosDetails)
+		}, func(ctx context.Context, targets []string) error {
+			diskNames, attachedDisks := getTargetDisks(targets, instanceNamesWithDiskNames, attachedDisksWithInstance)
+			return injectChaosInParallelMode(ctx, experimentsDetails, diskNames, attachedDisks, clients, resultDetails, eventsDetails, chaosDetails)
+		}); err != nil {
+			return err
 		}
 
 		//Waiting for the ramp time after chaos injection
 		if experimentsDetails.RampTime != 0 {
 			log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-			common.WaitForDuration(experimentsDetails.RampTime)
+			if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -124,7 +130,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 		// Detaching the virtual disks
 		log.Info("[Chaos]: Detaching the virtual disks from the instances")
 		for instanceName, diskNameList := range instanceNamesWithDiskNames {
-			if err = diskStatus.DetachDisks(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameList); err != nil {
+			if err := common.WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
+			if err = diskStatus.DetachDisks(ctx, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameList); err != nil {
 				return stacktrace.Propagate(err, "failed to detach disks")
 			}
 		}
@@ -153,12 +162,14 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 		//Wait for chaos duration
 		log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-		common.WaitForDuration(experimentsDetails.ChaosInterval)
+		if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+			return err
+		}
 
 		//Attaching the virtual disks to the instance
 		log.Info("[Chaos]: Attaching the Virtual disks back to the instances")
 		for instanceName, diskNameList := range attachedDisksWithInstance {
-			if err = diskStatus.AttachDisk(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameList); err != nil {
+			if err = diskStatus.AttachDisk(ctx, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameList); err != nil {
 				return stacktrace.Propagate(err, "virtual disk attachment failed")
 			}
 
@@ -208,7 +219,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				// Detaching the virtual disks
 				log.Infof("[Chaos]: Detaching %v from the instance", diskName)
-				if err = diskStatus.DetachDisks(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameToList); err != nil {
+				if err = diskStatus.DetachDisks(ctx, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskNameToList); err != nil {
 					return stacktrace.Propagate(err, "failed to detach disks")
 				}
 
@@ -230,11 +241,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Wait for chaos duration
 				log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-				common.WaitForDuration(experimentsDetails.ChaosInterval)
+				if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+					return err
+				}
 
 				//Attaching the virtual disks to the instance
 				log.Infof("[Chaos]: Attaching %v back to the instance", diskName)
-				if err = diskStatus.AttachDisk(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, attachedDisksWithInstance[instanceName]); err != nil {
+				if err = diskStatus.AttachDisk(ctx, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, attachedDisksWithInstance[instanceName]); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
@@ -253,7 +266,10 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 }
 
 // abortWatcher will be watching for the abort signal and revert the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, attachedDisksWithInstance map[string]*[]compute.DataDisk, instanceNamesWithDiskNames map[string][]string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, attachedDisksWithInstance map[string]*[]compute.DataDisk, instanceNamesWithDiskNames map[string][]string, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
@@ -285,7 +301,7 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, attache
 				log.Errorf("Failed to get disk status: %v", err)
 			}
 			if diskStatusString != "Attached" {
-				if err := diskStatus.AttachDisk(experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskList); err != nil {
+				if err := diskStatus.AttachDisk(ctx, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, instanceName, experimentsDetails.ScaleSet, diskList); err != nil {
 					log.Errorf("Failed to attach disk, manual revert required: %v", err)
 				} else {
 					common.SetTargets(*disk.Name, "re-attached", "VirtualDisk", chaosDetails)
@@ -295,5 +311,17 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, attache
 	}
 
 	log.Infof("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
+}
+
+// getTargetDisks returns the target disks and the attached disks of the given instances
+func getTargetDisks(instanceNames []string, instanceNamesWithDiskNames map[string][]string, attachedDisksWithInstance map[string]*[]compute.DataDisk) (map[string][]string, map[string]*[]compute.DataDisk) {
+	diskNames := make(map[string][]string, len(instanceNames))
+	attachedDisks := make(map[string]*[]compute.DataDisk, len(instanceNames))
+	for _, instanceName := range instanceNames {
+		diskNames[instanceName] = instanceNamesWithDiskNames[instanceName]
+		attachedDisks[instanceName] = attachedDisksWithInstance[instanceName]
+	}
+	return diskNames, attachedDisks
 }
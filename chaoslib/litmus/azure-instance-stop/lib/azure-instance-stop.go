@@ -2,11 +2,8 @@ package lib
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/instance-stop/types"
@@ -36,17 +33,19 @@ func PrepareAzureStop(ctx context.Context, experimentsDetails *experimentTypes.E
 
 	// inject channel is used to transmit signal notifications
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	common.NotifyAbort(abort)
 
 	// Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//  get the instance name or list of instance names
@@ -56,25 +55,22 @@ func PrepareAzureStop(ctx context.Context, experimentsDetails *experimentTypes.E
 	}
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, instanceNameList)
-
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, instanceNameList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, instanceNameList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	go abortWatcher(ctx, experimentsDetails, instanceNameList)
+
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, instanceNameList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []string) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}); err != nil {
+		return err
 	}
 
 	// Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -87,7 +83,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		// ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -109,11 +105,11 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				// Stopping the Azure instance
 				log.Infof("[Chaos]: Stopping the Azure instance: %v", vmName)
 				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureScaleSetInstanceStop(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to stop the Azure instance")
 					}
 				} else {
-					if err := azureStatus.AzureInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureInstanceStop(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to stop the Azure instance")
 					}
 				}
@@ -134,16 +130,18 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				// Wait for Chaos interval
 				log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-				common.WaitForDuration(experimentsDetails.ChaosInterval)
+				if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+					return err
+				}
 
 				// Starting the Azure instance
 				log.Info("[Chaos]: Starting back the Azure instance")
 				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureScaleSetInstanceStart(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to start the Azure instance")
 					}
 				} else {
-					if err := azureStatus.AzureInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureInstanceStart(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to start the Azure instance")
 					}
 				}
@@ -168,7 +166,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	select {
 	case <-inject:
 		// Stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		// ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -186,14 +184,17 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 			// PowerOff the instances parallelly
 			for _, vmName := range instanceNameList {
+				if err := common.WaitForStaggeredStart(ctx); err != nil {
+					return err
+				}
 				// Stopping the Azure instance
 				log.Infof("[Chaos]: Stopping the Azure instance: %v", vmName)
 				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureScaleSetInstanceStop(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to stop Azure instance")
 					}
 				} else {
-					if err := azureStatus.AzureInstanceStop(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureInstanceStop(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to stop Azure instance")
 					}
 				}
@@ -216,17 +217,19 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 			// Wait for Chaos interval
 			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			// Starting the Azure instance
 			for _, vmName := range instanceNameList {
 				log.Infof("[Chaos]: Starting back the Azure instance: %v", vmName)
 				if experimentsDetails.ScaleSet == "enable" {
-					if err := azureStatus.AzureScaleSetInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureScaleSetInstanceStart(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to start the Azure instance")
 					}
 				} else {
-					if err := azureStatus.AzureInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+					if err := azureStatus.AzureInstanceStart(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 						return stacktrace.Propagate(err, "unable to start the Azure instance")
 					}
 				}
@@ -247,7 +250,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // watching for the abort signal and revert the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanceNameList []string) {
+func abortWatcher(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceNameList []string) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	<-abort
 
 	var instanceState string
@@ -271,11 +277,11 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanc
 
 			log.Info("[Abort]: Starting Azure instance as abort signal received")
 			if experimentsDetails.ScaleSet == "enable" {
-				if err := azureStatus.AzureScaleSetInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+				if err := azureStatus.AzureScaleSetInstanceStart(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 					log.Errorf("[Abort]: Unable to start the Azure instance: %v", err)
 				}
 			} else {
-				if err := azureStatus.AzureInstanceStart(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
+				if err := azureStatus.AzureInstanceStart(ctx, experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.SubscriptionID, experimentsDetails.ResourceGroup, vmName); err != nil {
 					log.Errorf("[Abort]: Unable to start the Azure instance: %v", err)
 				}
 			}
@@ -289,5 +295,6 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanc
 		}
 	}
 	log.Infof("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
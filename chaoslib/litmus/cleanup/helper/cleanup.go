@@ -0,0 +1,457 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/cleanup/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Helper reverts the node scoped chaos artifacts left behind by the interrupted helpers
+func Helper(ctx context.Context, clients clients.ClientSets) {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "CleanupNode")
+	defer span.End()
+
+	experimentsDetails := experimentTypes.ExperimentDetails{}
+	chaosDetails := types.ChaosDetails{}
+	resultDetails := types.ResultDetails{}
+
+	//Fetching all the ENV passed for the helper pod
+	log.Info("[PreReq]: Getting the ENV variables")
+	getENV(&experimentsDetails)
+
+	// Initialise the chaos attributes
+	types.InitialiseChaosVariables(&chaosDetails)
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
+
+	// Initialise Chaos Result Parameters
+	types.SetResultAttributes(&resultDetails, chaosDetails)
+
+	// Set the chaos result uid
+	result.SetResultUID(&resultDetails, clients, &chaosDetails)
+
+	if err := cleanupNode(ctx, &experimentsDetails, clients, &chaosDetails, &resultDetails); err != nil {
+		// update failstep inside chaosresult
+		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
+			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
+		}
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
+}
+
+// cleanupNode reverts the netem, iptables, proxy and disk-fill artifacts from all the pods scheduled on the node
+func cleanupNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+
+	// the chaos helpers run inside the chaos namespace, so the active chaos are looked up in all the namespaces
+	activeChaos, err := getActiveChaosUIDs(ctx, clients, chaosDetails)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the active chaos")
+	}
+
+	entries, err := journal.GetPendingEntries("", clients)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the journal entries")
+	}
+
+	status := "reverted"
+	if experimentsDetails.DryRun {
+		status = "targeted"
+	}
+
+	// the leftover network targets are collected before the replay, as the replay marks the entries as reverted
+	networkTargets := getNetworkChaosTargets(ctx, entries, activeChaos, clients)
+
+	// replaying the unfinished reverts recorded inside the journals for the targets of the node
+	errList := replayJournal(ctx, experimentsDetails, clients, chaosDetails, resultDetails, entries, activeChaos, status)
+
+	// skipping the artifacts without the journal entries, if any other chaos is still running
+	// they can't be told apart from the artifacts of the running chaos, which will be reverted by its owner
+	if len(activeChaos) != 0 {
+		log.Warnf("[Cleanup]: Skipping the leftover artifacts of %v node, as the chaos with uids %v are still running", experimentsDetails.NodeName, getUIDs(activeChaos))
+		if len(errList) != 0 {
+			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+		}
+		return nil
+	}
+
+	podList, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.AppNS).List(ctx, v1.ListOptions{FieldSelector: "spec.nodeName=" + experimentsDetails.NodeName})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: chaosDetails.ChaosPodName, Target: fmt.Sprintf("{nodeName: %s}", experimentsDetails.NodeName), Reason: err.Error()}
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Spec.HostNetwork || pod.Status.Phase != apiv1.PodRunning || pod.Labels["chaosUID"] != "" || len(pod.Status.ContainerStatuses) == 0 {
+			continue
+		}
+
+		found, err := cleanupPod(experimentsDetails, pod, networkTargets.has(pod), clients, chaosDetails.ChaosPodName)
+		if err != nil {
+			errList = append(errList, err.Error())
+			continue
+		}
+		if len(found) == 0 {
+			continue
+		}
+
+		log.InfoWithValues("[Report]: Leftover chaos artifacts", logrus.Fields{
+			"Pod":       pod.Name,
+			"Namespace": pod.Namespace,
+			"Artifacts": strings.Join(found, ","),
+			"Status":    status,
+		})
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, status, "pod", pod.Name, clients); err != nil {
+			errList = append(errList, err.Error())
+		}
+	}
+
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+	}
+	return nil
+}
+
+// replayJournal executes the revert commands of the pending journal entries, whose targets are scheduled on the node
+func replayJournal(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, entries []journal.Entry, activeChaos map[string]bool, status string) []string {
+	var errList []string
+	for _, entry := range entries {
+		if entry.Revert.Type != journal.RevertTypeCommand || entry.ChaosUID == string(chaosDetails.ChaosUID) || activeChaos[entry.ChaosUID] {
+			continue
+		}
+
+		pod, err := clients.KubeClient.CoreV1().Pods(entry.Namespace).Get(ctx, entry.Name, v1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			errList = append(errList, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: chaosDetails.ChaosPodName, Target: fmt.Sprintf("{podName: %s, namespace: %s}", entry.Name, entry.Namespace), Reason: err.Error()}.Error())
+			continue
+		}
+		if err == nil && pod.Spec.NodeName != experimentsDetails.NodeName {
+			continue
+		}
+
+		// the recorded pid is valid only if the target container is still the same
+		// otherwise the chaos has been removed along with the old container
+		if err == nil && isSameContainer(experimentsDetails, entry, clients, chaosDetails.ChaosPodName) {
+			log.InfoWithValues("[Report]: Unfinished revert found inside the journal", logrus.Fields{
+				"Pod":        entry.Name,
+				"Namespace":  entry.Namespace,
+				"Experiment": entry.Experiment,
+				"Journal":    entry.Journal,
+				"Status":     status,
+			})
+			if experimentsDetails.DryRun {
+				continue
+			}
+			if err := runRevertCommands(entry, chaosDetails.ChaosPodName); err != nil {
+				errList = append(errList, err.Error())
+				continue
+			}
+			if err := result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, status, "pod", entry.Name, clients); err != nil {
+				errList = append(errList, err.Error())
+			}
+		} else if experimentsDetails.DryRun {
+			continue
+		}
+
+		if err := journal.MarkEntryReverted(entry.Journal, entry.JournalNamespace, entry.ID, clients); err != nil {
+			errList = append(errList, err.Error())
+		}
+	}
+	return errList
+}
+
+// getActiveChaosUIDs returns the uid of all the chaos which still have a running pod, in any namespace
+func getActiveChaosUIDs(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (map[string]bool, error) {
+	pods, err := clients.KubeClient.CoreV1().Pods("").List(ctx, v1.ListOptions{LabelSelector: "chaosUID"})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: chaosDetails.ChaosPodName, Reason: fmt.Sprintf("failed to list the chaos pods: %s", err.Error())}
+	}
+
+	active := map[string]bool{}
+	for _, pod := range pods.Items {
+		uid := pod.Labels["chaosUID"]
+		if uid == "" || uid == string(chaosDetails.ChaosUID) {
+			continue
+		}
+		if pod.Status.Phase == apiv1.PodRunning || pod.Status.Phase == apiv1.PodPending {
+			active[uid] = true
+		}
+	}
+	return active, nil
+}
+
+// getUIDs returns the sorted uids of the chaos
+func getUIDs(chaos map[string]bool) []string {
+	uids := make([]string, 0, len(chaos))
+	for uid := range chaos {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+// networkTargets contains the pods, whose root qdisc was replaced by the network chaos
+// the pods are keyed by namespace/name, or by name if the namespace isn't known (as in the chaosresult targets)
+type networkTargets map[string]bool
+
+// has checks if the root qdisc of the pod was replaced by the network chaos
+func (targets networkTargets) has(pod apiv1.Pod) bool {
+	return targets[pod.Namespace+"/"+pod.Name] || targets[pod.Name]
+}
+
+// getNetworkChaosTargets returns the pods injected by the network chaos, which is no longer running
+// the pods are discovered from the pending journal entries and the chaosresult targets, which were never reverted
+func getNetworkChaosTargets(ctx context.Context, entries []journal.Entry, activeChaos map[string]bool, clients clients.ClientSets) networkTargets {
+	targets := networkTargets{}
+	for _, entry := range entries {
+		if entry.Kind == "pod" && !activeChaos[entry.ChaosUID] && strings.Contains(strings.Join(entry.Revert.Commands, " "), "tc qdisc") {
+			targets[entry.Namespace+"/"+entry.Name] = true
+		}
+	}
+
+	resultList, err := clients.LitmusClient.ChaosResults("").List(ctx, v1.ListOptions{})
+	if err != nil {
+		log.Warnf("[Cleanup]: Unable to list the chaosresults, err: %v", err)
+		return targets
+	}
+	for _, chaosResult := range resultList.Items {
+		if activeChaos[chaosResult.Labels["chaosUID"]] || chaosResult.Status.History == nil || !isNetworkChaos(chaosResult.Spec.ExperimentName) {
+			continue
+		}
+		for _, target := range chaosResult.Status.History.Targets {
+			switch strings.ToLower(target.ChaosStatus) {
+			case "injected", "targeted":
+				if target.Kind == "pod" {
+					targets[target.Name] = true
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// isNetworkChaos checks if the experiment replaces the root qdisc of its targets
+func isNetworkChaos(experiment string) bool {
+	return strings.HasPrefix(experiment, "pod-network-") && experiment != "pod-network-partition"
+}
+
+// isSameContainer checks if the container recorded inside the journal entry is still running
+func isSameContainer(experimentsDetails *experimentTypes.ExperimentDetails, entry journal.Entry, clients clients.ClientSets, source string) bool {
+	if containerID, err := common.GetContainerID(entry.Namespace, entry.Name, entry.Container, clients, source); err == nil && containerID == entry.ContainerID {
+		return true
+	}
+	containerID, err := common.GetRuntimeBasedContainerID(experimentsDetails.ContainerRuntime, experimentsDetails.SocketPath, entry.Name, entry.Namespace, entry.Container, clients, source)
+	return err == nil && containerID == entry.ContainerID
+}
+
+// runRevertCommands executes the revert commands of the journal entry
+func runRevertCommands(entry journal.Entry, source string) error {
+	for _, command := range entry.Revert.Commands {
+		log.Info(command)
+		if err := common.RunBashCommand(command, fmt.Sprintf("failed to revert the chaos of %s/%s pod", entry.Namespace, entry.Name), source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupPod reverts the chaos artifacts from the given pod and returns the list of artifacts found
+// the root qdisc is deleted only if it was replaced by the network chaos, which is no longer running
+func cleanupPod(experimentsDetails *experimentTypes.ExperimentDetails, pod apiv1.Pod, networkTarget bool, clients clients.ClientSets, source string) ([]string, error) {
+	var found []string
+
+	containerID, err := common.GetRuntimeBasedContainerID(experimentsDetails.ContainerRuntime, experimentsDetails.SocketPath, pod.Name, pod.Namespace, pod.Status.ContainerStatuses[0].Name, clients, source)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "could not get container id")
+	}
+
+	// extract out the pid of the pause container, which holds the network namespace of the pod
+	pid, err := common.GetPauseAndSandboxPID(experimentsDetails.ContainerRuntime, containerID, experimentsDetails.SocketPath, source)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "could not get container pid")
+	}
+
+	if networkTarget {
+		netem, err := removeNetem(experimentsDetails, pid, pod, source)
+		if err != nil {
+			return nil, err
+		}
+		if netem {
+			found = append(found, "netem")
+		}
+	}
+
+	rules, err := removeIPRuleSet(experimentsDetails, pid, pod, source)
+	if err != nil {
+		return nil, err
+	}
+	if rules {
+		found = append(found, "iptables")
+	}
+
+	proxy, err := killProxy(experimentsDetails, pid, pod, source)
+	if err != nil {
+		return nil, err
+	}
+	if proxy {
+		found = append(found, "toxiproxy")
+	}
+
+	for _, container := range pod.Status.ContainerStatuses {
+		if !container.Ready || container.ContainerID == "" {
+			continue
+		}
+		filled, err := removeDiskFill(experimentsDetails, pod, container.Name, clients, source)
+		if err != nil {
+			return nil, err
+		}
+		if filled {
+			found = append(found, "diskfill/"+container.Name)
+		}
+	}
+	return found, nil
+}
+
+// removeNetem deletes the root qdisc of the pod, if it contains the netem or the rate limit (tbf) rules
+// it should be called only for the pods injected by the network chaos, as the user can configure the tbf qdisc as well
+func removeNetem(experimentsDetails *experimentTypes.ExperimentDetails, pid int, pod apiv1.Pod, source string) (bool, error) {
+	out, err := exec.Command("/bin/bash", "-c", fmt.Sprintf("sudo nsenter -t %d -n tc qdisc show dev %s", pid, experimentsDetails.NetworkInterface)).CombinedOutput()
+	if err != nil || (!strings.Contains(string(out), "netem") && !strings.Contains(string(out), "tbf")) {
+		return false, nil
+	}
+	if experimentsDetails.DryRun {
+		return true, nil
+	}
+
+	tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc delete dev %s root", pid, experimentsDetails.NetworkInterface)
+	log.Info(tc)
+	if err := common.RunBashCommand(tc, fmt.Sprintf("failed to revert network faults of %s/%s pod", pod.Namespace, pod.Name), source); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeIPRuleSet deletes the REDIRECT rules to the proxy port from the PREROUTING chain of the pod
+func removeIPRuleSet(experimentsDetails *experimentTypes.ExperimentDetails, pid int, pod apiv1.Pod, source string) (bool, error) {
+	out, err := exec.Command("/bin/bash", "-c", fmt.Sprintf("sudo nsenter -t %d -n iptables -t nat -S PREROUTING", pid)).CombinedOutput()
+	if err != nil {
+		return false, nil
+	}
+
+	found := false
+	for _, rule := range strings.Split(string(out), "\n") {
+		if !strings.HasPrefix(rule, "-A PREROUTING") || !strings.Contains(rule, "-j REDIRECT --to-ports "+strconv.Itoa(experimentsDetails.ProxyPort)) {
+			continue
+		}
+		found = true
+		if experimentsDetails.DryRun {
+			continue
+		}
+		iptables := fmt.Sprintf("sudo nsenter -t %d -n iptables -t nat %s", pid, strings.Replace(rule, "-A", "-D", 1))
+		log.Info(iptables)
+		if err := common.RunBashCommand(iptables, fmt.Sprintf("failed to remove ip rules of %s/%s pod", pod.Namespace, pod.Name), source); err != nil {
+			return false, err
+		}
+	}
+	return found, nil
+}
+
+// killProxy kills the toxiproxy servers running inside the network namespace of the pod
+func killProxy(experimentsDetails *experimentTypes.ExperimentDetails, pid int, pod apiv1.Pod, source string) (bool, error) {
+	netNS, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return false, nil
+	}
+
+	procs, err := filepath.Glob("/proc/[0-9]*/comm")
+	if err != nil {
+		return false, nil
+	}
+
+	found := false
+	for _, comm := range procs {
+		name, err := os.ReadFile(comm)
+		if err != nil || !strings.HasPrefix(strings.TrimSpace(string(name)), "toxiproxy") {
+			continue
+		}
+		procDir := filepath.Dir(comm)
+		if ns, err := os.Readlink(filepath.Join(procDir, "ns", "net")); err != nil || ns != netNS {
+			continue
+		}
+		found = true
+		if experimentsDetails.DryRun {
+			continue
+		}
+		proxyPID, _ := strconv.Atoi(filepath.Base(procDir))
+		log.Infof("[Cleanup]: Killing %v proxy process of %s/%s pod", proxyPID, pod.Namespace, pod.Name)
+		if err := syscall.Kill(proxyPID, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			return false, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: source, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to stop proxy server: %s", err.Error())}
+		}
+	}
+	return found, nil
+}
+
+// removeDiskFill deletes the file created by the disk-fill chaos inside the container
+func removeDiskFill(experimentsDetails *experimentTypes.ExperimentDetails, pod apiv1.Pod, containerName string, clients clients.ClientSets, source string) (bool, error) {
+	containerID, err := common.GetContainerID(pod.Namespace, pod.Name, containerName, clients, source)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "could not get container id")
+	}
+
+	pid, err := common.GetPID(experimentsDetails.ContainerRuntime, containerID, experimentsDetails.SocketPath, source)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "could not get container pid")
+	}
+
+	file := fmt.Sprintf("/proc/%v/root/home/diskfill", pid)
+	if _, err := os.Stat(file); err != nil {
+		return false, nil
+	}
+	if experimentsDetails.DryRun {
+		return true, nil
+	}
+
+	rm := fmt.Sprintf("sudo rm -rf %s", file)
+	log.Info(rm)
+	if err := common.RunBashCommand(rm, fmt.Sprintf("failed to cleanup ephemeral storage of %s/%s pod", pod.Namespace, pod.Name), source); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getENV fetches all the env variables from the runner pod
+func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
+	experimentDetails.ExperimentName = types.Getenv("EXPERIMENT_NAME", "")
+	experimentDetails.InstanceID = types.Getenv("INSTANCE_ID", "")
+	experimentDetails.ChaosNamespace = types.Getenv("CHAOS_NAMESPACE", "litmus")
+	experimentDetails.EngineName = types.Getenv("CHAOSENGINE", "")
+	experimentDetails.ChaosUID = clientTypes.UID(types.Getenv("CHAOS_UID", ""))
+	experimentDetails.ChaosPodName = types.Getenv("POD_NAME", "")
+	experimentDetails.NodeName = types.Getenv("NODE_NAME", "")
+	experimentDetails.AppNS = types.Getenv("APP_NAMESPACE", "")
+	experimentDetails.ContainerRuntime = types.Getenv("CONTAINER_RUNTIME", "")
+	experimentDetails.NetworkInterface = types.Getenv("NETWORK_INTERFACE", "")
+	experimentDetails.SocketPath = types.Getenv("SOCKET_PATH", "")
+	experimentDetails.ProxyPort, _ = strconv.Atoi(types.Getenv("PROXY_PORT", "20000"))
+	experimentDetails.DryRun, _ = strconv.ParseBool(types.Getenv("DRY_RUN", "false"))
+}
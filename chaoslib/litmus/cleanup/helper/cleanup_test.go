@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	litmusFake "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/fake"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetActiveChaosUIDsListsAllTheNamespaces(t *testing.T) {
+	chaosPod := func(name, namespace, uid string, phase apiv1.PodPhase) *apiv1.Pod {
+		return &apiv1.Pod{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"chaosUID": uid}},
+			Status:     apiv1.PodStatus{Phase: phase},
+		}
+	}
+	kubeClient := fake.NewSimpleClientset(
+		chaosPod("pod-network-loss-helper-abcde", "litmus", "uid-1", apiv1.PodRunning),
+		chaosPod("pod-cpu-hog-helper-abcde", "chaos", "uid-2", apiv1.PodPending),
+		chaosPod("pod-delete-abcde", "litmus", "uid-3", apiv1.PodSucceeded),
+		chaosPod("cleanup-helper-abcde", "litmus", "uid-4", apiv1.PodRunning),
+	)
+
+	active, err := getActiveChaosUIDs(context.Background(), clients.ClientSets{KubeClient: kubeClient}, &types.ChaosDetails{ChaosUID: "uid-4"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"uid-1": true, "uid-2": true}, active)
+}
+
+func TestGetNetworkChaosTargets(t *testing.T) {
+	chaosResult := func(name, uid, experiment, target string) *v1alpha1.ChaosResult {
+		return &v1alpha1.ChaosResult{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "litmus", Labels: map[string]string{"chaosUID": uid}},
+			Spec:       v1alpha1.ChaosResultSpec{ExperimentName: experiment},
+			Status: v1alpha1.ChaosResultStatus{History: &v1alpha1.HistoryDetails{
+				Targets: []v1alpha1.TargetDetails{{Name: target, Kind: "pod", ChaosStatus: "injected"}},
+			}},
+		}
+	}
+	clientSets := clients.ClientSets{LitmusClient: litmusFake.NewSimpleClientset(
+		chaosResult("nginx-chaos-pod-network-latency", "uid-1", "pod-network-latency", "nginx-0"),
+		chaosResult("nginx-chaos-pod-network-loss", "uid-2", "pod-network-loss", "nginx-1"),
+		chaosResult("nginx-chaos-pod-network-partition", "uid-1", "pod-network-partition", "nginx-2"),
+		chaosResult("nginx-chaos-pod-cpu-hog", "uid-1", "pod-cpu-hog", "nginx-3"),
+	).LitmuschaosV1alpha1()}
+	entry := func(uid, experiment, name, command string) journal.Entry {
+		entry := journal.NewEntry(experiment, "pod", name, "apps").WithRevertCommands(command)
+		entry.ChaosUID = uid
+		return *entry
+	}
+	entries := []journal.Entry{
+		entry("uid-1", "pod-network-corruption", "nginx-4", "sudo nsenter -t 1 -n tc qdisc delete dev eth0 root"),
+		entry("uid-2", "pod-network-corruption", "nginx-5", "sudo nsenter -t 1 -n tc qdisc delete dev eth0 root"),
+		entry("uid-1", "pod-dns-error", "nginx-6", "sudo kill -9 1"),
+	}
+
+	targets := getNetworkChaosTargets(context.Background(), entries, map[string]bool{"uid-2": true}, clientSets)
+	assert.Equal(t, networkTargets{"nginx-0": true, "apps/nginx-4": true}, targets)
+
+	pod := func(name, namespace string) apiv1.Pod {
+		return apiv1.Pod{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	assert.True(t, targets.has(pod("nginx-0", "default")))
+	assert.True(t, targets.has(pod("nginx-4", "apps")))
+	assert.False(t, targets.has(pod("nginx-4", "default")))
+	assert.False(t, targets.has(pod("nginx-1", "default")))
+}
@@ -0,0 +1,684 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	springBootLib "github.com/litmuschaos/litmus-go/chaoslib/litmus/spring-boot-chaos/lib"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/cleanup/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/records"
+	"github.com/litmuschaos/litmus-go/pkg/status"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/utils/stringutils"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultTaintValue is the value of the taints added by node-taint, if the value isn't provided in the TAINTS env
+const defaultTaintValue = "node-taint"
+
+// PrepareCleanup discovers the chaos artifacts left behind by the interrupted experiments
+// and reverts them. The cluster scoped artifacts are reverted by the experiment pod itself
+// whereas the node scoped artifacts are reverted by the helper pods scheduled on every node
+func PrepareCleanup(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareCleanup")
+	defer span.End()
+
+	var (
+		report  []experimentTypes.Report
+		errList []string
+	)
+
+	activeChaos, err := getActiveChaosUIDs(ctx, clients, chaosDetails)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the active chaos")
+	}
+
+	// replaying the unfinished reverts recorded inside the journals
+	r, errs := replayJournal(ctx, experimentsDetails, clients, activeChaos)
+	report = append(report, r...)
+	errList = append(errList, errs...)
+
+	// reverting the cluster scoped artifacts
+	r, errs = removeNetworkPolicies(ctx, experimentsDetails, clients, activeChaos)
+	report = append(report, r...)
+	errList = append(errList, errs...)
+
+	r, errs = revertChaosResultTargets(ctx, experimentsDetails, clients, activeChaos)
+	report = append(report, r...)
+	errList = append(errList, errs...)
+
+	r, errs = removeUnderChaosAnnotations(ctx, experimentsDetails, clients, activeChaos)
+	report = append(report, r...)
+	errList = append(errList, errs...)
+
+	if err := cleanupNodes(ctx, experimentsDetails, clients, chaosDetails); err != nil {
+		errList = append(errList, err.Error())
+	}
+
+	// removing the journals of the inactive chaos, which doesn't have any pending revert
+	if !experimentsDetails.DryRun {
+		if err := journal.DeleteRevertedJournals("", activeChaos, clients); err != nil {
+			errList = append(errList, err.Error())
+		}
+	}
+
+	for _, r := range report {
+		status := "reverted"
+		if experimentsDetails.DryRun {
+			status = "targeted"
+		}
+		if r.Status == "Failed" {
+			continue
+		}
+		common.SetTargets(r.Name, status, strings.ToLower(r.Kind), chaosDetails)
+	}
+	logReport(report)
+
+	if len(errList) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(errList, ","))}
+	}
+	return nil
+}
+
+// getActiveChaosUIDs returns the uid of all the chaos which still have a running pod
+// the artifacts belongs to these chaos are skipped, as they will be reverted by its owner
+func getActiveChaosUIDs(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (map[string]bool, error) {
+	pods, err := clients.KubeClient.CoreV1().Pods("").List(ctx, v1.ListOptions{LabelSelector: "chaosUID"})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to list the chaos pods: %s", err.Error())}
+	}
+
+	active := map[string]bool{}
+	for _, pod := range pods.Items {
+		uid := pod.Labels["chaosUID"]
+		if uid == string(chaosDetails.ChaosUID) {
+			continue
+		}
+		if pod.Status.Phase == apiv1.PodRunning || pod.Status.Phase == apiv1.PodPending {
+			active[uid] = true
+		}
+	}
+	return active, nil
+}
+
+// replayJournal replays the api based reverts of the journal entries, which are injected but never reverted
+// the command based reverts are replayed by the helper pods, running on the node of the targets
+func replayJournal(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, activeChaos map[string]bool) ([]experimentTypes.Report, []string) {
+	var (
+		report  []experimentTypes.Report
+		errList []string
+	)
+
+	entries, err := journal.GetPendingEntries("", clients)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	for _, entry := range entries {
+		if activeChaos[entry.ChaosUID] || entry.Revert.Type != journal.RevertTypeAPI {
+			continue
+		}
+
+		r := experimentTypes.Report{Kind: entry.Kind, Name: entry.Name, Action: entry.Revert.Action}
+		if !experimentsDetails.DryRun {
+			if err := revertJournalEntry(ctx, entry, clients); err != nil {
+				r.Status = "Failed"
+				errList = append(errList, err.Error())
+				report = append(report, r)
+				continue
+			}
+			if err := journal.MarkEntryReverted(entry.Journal, entry.JournalNamespace, entry.ID, clients); err != nil {
+				errList = append(errList, err.Error())
+			}
+		}
+		r.Status = getStatus(experimentsDetails.DryRun)
+		report = append(report, r)
+	}
+	return report, errList
+}
+
+// revertJournalEntry performs the revert action of the journal entry
+func revertJournalEntry(ctx context.Context, entry journal.Entry, clients clients.ClientSets) error {
+	args := entry.Revert.Args
+	switch entry.Revert.Action {
+	case journal.ActionRemoveTaint:
+		return removeTaints(ctx, args["node"], []apiv1.Taint{{Key: args["key"], Value: args["value"], Effect: apiv1.TaintEffect(args["effect"])}}, clients)
+	case journal.ActionUncordonNode:
+		return uncordonNode(ctx, args["node"], clients)
+	case journal.ActionDeleteNetworkPolicy:
+		if err := clients.KubeClient.NetworkingV1().NetworkPolicies(args["namespace"]).Delete(ctx, args["name"], v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{networkPolicy: %s, namespace: %s}", args["name"], args["namespace"]), Reason: err.Error()}
+		}
+		return nil
+	case journal.ActionDisableChaosMonkey:
+		pod, err := clients.KubeClient.CoreV1().Pods(args["namespace"]).Get(ctx, args["pod"], v1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				log.Infof("[Cleanup]: %v pod doesn't exist anymore", args["pod"])
+				return nil
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{podName: %s, namespace: %s}", args["pod"], args["namespace"]), Reason: err.Error()}
+		}
+		return springBootLib.DisableChaosMonkey(ctx, args["port"], args["path"], *pod)
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{journal: %s, entry: %s}", entry.Journal, entry.ID), Reason: fmt.Sprintf("unsupported revert action: %s", entry.Revert.Action)}
+	}
+}
+
+// removeNetworkPolicies removes the network policies created by the pod-network-partition experiment
+func removeNetworkPolicies(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, activeChaos map[string]bool) ([]experimentTypes.Report, []string) {
+	var (
+		report  []experimentTypes.Report
+		errList []string
+	)
+
+	npList, err := clients.KubeClient.NetworkingV1().NetworkPolicies(experimentsDetails.AppNS).List(ctx, v1.ListOptions{LabelSelector: "app.kubernetes.io/part-of=litmus"})
+	if err != nil {
+		return nil, []string{cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{namespace: %s}", experimentsDetails.AppNS), Reason: fmt.Sprintf("failed to list network policies: %s", err.Error())}.Error()}
+	}
+
+	for _, np := range npList.Items {
+		if !strings.Contains(np.Name, "-np-") || activeChaos[np.Labels["chaosUID"]] {
+			continue
+		}
+		r := experimentTypes.Report{Kind: "NetworkPolicy", Name: np.Namespace + "/" + np.Name, Action: "delete network policy"}
+		if !experimentsDetails.DryRun {
+			if err := clients.KubeClient.NetworkingV1().NetworkPolicies(np.Namespace).Delete(ctx, np.Name, v1.DeleteOptions{}); err != nil {
+				r.Status = "Failed"
+				errList = append(errList, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{networkPolicy: %s, namespace: %s}", np.Name, np.Namespace), Reason: err.Error()}.Error())
+				report = append(report, r)
+				continue
+			}
+		}
+		r.Status = getStatus(experimentsDetails.DryRun)
+		report = append(report, r)
+	}
+	return report, errList
+}
+
+// revertChaosResultTargets reverts the targets which are still marked as injected inside the chaosresults
+// of the inactive node-drain, node-taint and spring-boot chaos
+func revertChaosResultTargets(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, activeChaos map[string]bool) ([]experimentTypes.Report, []string) {
+	var (
+		report  []experimentTypes.Report
+		errList []string
+	)
+
+	resultList, err := clients.LitmusClient.ChaosResults("").List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, []string{cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosResultCRUD, Reason: fmt.Sprintf("failed to list chaosresults: %s", err.Error())}.Error()}
+	}
+
+	for _, chaosResult := range resultList.Items {
+		if activeChaos[chaosResult.Labels["chaosUID"]] || chaosResult.Status.History == nil {
+			continue
+		}
+		// the records of the chaosresult contain the parameters and the namespace of the targets
+		targetRecords, err := records.List(chaosResult.Name, chaosResult.Namespace, clients)
+		if err != nil {
+			log.Warnf("[Cleanup]: Unable to get the records of %v chaosresult, err: %v", chaosResult.Name, err)
+		}
+		for _, target := range getLeftoverTargets(chaosResult) {
+			var (
+				r   experimentTypes.Report
+				err error
+			)
+			switch {
+			case strings.HasPrefix(chaosResult.Spec.ExperimentName, "node-drain") && target.Kind == "node":
+				r = experimentTypes.Report{Kind: "Node", Name: target.Name, Action: "uncordon node"}
+				if !experimentsDetails.DryRun {
+					err = uncordonNode(ctx, target.Name, clients)
+				}
+			case strings.HasPrefix(chaosResult.Spec.ExperimentName, "node-taint") && target.Kind == "node":
+				r = experimentTypes.Report{Kind: "Node", Name: target.Name, Action: "remove taints"}
+				if !experimentsDetails.DryRun {
+					err = removeTaints(ctx, target.Name, getLeftoverTaints(ctx, experimentsDetails, chaosResult, targetRecords, target.Name, clients), clients)
+				}
+			case strings.HasPrefix(chaosResult.Spec.ExperimentName, "spring-boot") && target.Kind == "pod":
+				r = experimentTypes.Report{Kind: "Pod", Name: target.Name, Action: "disable chaos monkey"}
+				if !experimentsDetails.DryRun {
+					err = disableChaosMonkey(ctx, experimentsDetails, getTargetNamespace(experimentsDetails, targetRecords, target.Name), target.Name, clients)
+				}
+			default:
+				continue
+			}
+			if err != nil {
+				r.Status = "Failed"
+				errList = append(errList, err.Error())
+			} else {
+				r.Status = getStatus(experimentsDetails.DryRun)
+			}
+			report = append(report, r)
+		}
+	}
+	return report, errList
+}
+
+// removeUnderChaosAnnotations removes the under-chaos annotations left behind on the targets, owners and nodes by the inactive chaos
+func removeUnderChaosAnnotations(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, activeChaos map[string]bool) ([]experimentTypes.Report, []string) {
+	var (
+		report  []experimentTypes.Report
+		errList []string
+	)
+
+	for kind, gvr := range events.UnderChaosResources() {
+		var (
+			list *unstructured.UnstructuredList
+			err  error
+		)
+		if kind == "Node" {
+			list, err = clients.DynamicClient.Resource(gvr).List(ctx, v1.ListOptions{})
+		} else {
+			list, err = clients.DynamicClient.Resource(gvr).Namespace(experimentsDetails.AppNS).List(ctx, v1.ListOptions{})
+		}
+		if err != nil {
+			errList = append(errList, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{kind: %s, namespace: %s}", kind, experimentsDetails.AppNS), Reason: fmt.Sprintf("failed to list the resources: %s", err.Error())}.Error())
+			continue
+		}
+
+		for _, obj := range list.Items {
+			annotations := obj.GetAnnotations()
+			if _, ok := annotations[events.UnderChaosAnnotation]; !ok || activeChaos[annotations[events.ChaosUIDAnnotation]] {
+				continue
+			}
+			ref := events.TargetReference{Resource: gvr, APIVersion: obj.GetAPIVersion(), Kind: kind, Name: obj.GetName(), Namespace: obj.GetNamespace(), UID: obj.GetUID()}
+			r := experimentTypes.Report{Kind: kind, Name: obj.GetName(), Action: "remove under-chaos annotation"}
+			if obj.GetNamespace() != "" {
+				r.Name = obj.GetNamespace() + "/" + obj.GetName()
+			}
+			if !experimentsDetails.DryRun {
+				if err := events.RemoveUnderChaosAnnotations(ctx, clients, ref); err != nil {
+					r.Status = "Failed"
+					errList = append(errList, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{%s}", ref), Reason: fmt.Sprintf("failed to remove the under-chaos annotation: %s", err.Error())}.Error())
+					report = append(report, r)
+					continue
+				}
+			}
+			r.Status = getStatus(experimentsDetails.DryRun)
+			report = append(report, r)
+		}
+	}
+	return report, errList
+}
+
+// getLeftoverTargets returns the targets of the chaosresult which are injected but never reverted
+func getLeftoverTargets(chaosResult v1alpha1.ChaosResult) []v1alpha1.TargetDetails {
+	var targets []v1alpha1.TargetDetails
+	for _, target := range chaosResult.Status.History.Targets {
+		switch strings.ToLower(target.ChaosStatus) {
+		case "injected", "targeted":
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// uncordonNode marks the node as schedulable
+func uncordonNode(ctx context.Context, nodeName string, clients clients.ClientSets) error {
+	node, err := clients.KubeClient.CoreV1().Nodes().Get(ctx, nodeName, v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Infof("[Cleanup]: %v node doesn't exist anymore", nodeName)
+			return nil
+		}
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: err.Error()}
+	}
+	if !node.Spec.Unschedulable {
+		log.Infof("[Cleanup]: %v node is already schedulable", nodeName)
+		return nil
+	}
+	node.Spec.Unschedulable = false
+	if _, err := clients.KubeClient.CoreV1().Nodes().Update(ctx, node, v1.UpdateOptions{}); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: fmt.Sprintf("failed to uncordon node: %s", err.Error())}
+	}
+	return nil
+}
+
+// getTaints returns the taints, provided in the form of key=value:effect (comma separated)
+// the value and effect default to node-taint and NoExecute, as in node-taint
+func getTaints(taints string) []apiv1.Taint {
+	var result []apiv1.Taint
+	for _, taint := range strings.Split(taints, ",") {
+		parts := strings.Split(strings.TrimSpace(taint), ":")
+		label := strings.Split(parts[0], "=")
+		if label[0] == "" {
+			continue
+		}
+		t := apiv1.Taint{Key: label[0], Value: defaultTaintValue, Effect: apiv1.TaintEffectNoExecute}
+		if len(label) >= 2 {
+			t.Value = label[1]
+		}
+		if len(parts) >= 2 {
+			t.Effect = apiv1.TaintEffect(parts[1])
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// getLeftoverTaints returns the taints added by the node-taint chaos on the given node
+// the taints are discovered from the TAINTS env, the pending journal entries and the records of the chaosresult
+// it falls back to the taints carrying the default node-taint value, if the taints can't be discovered otherwise
+func getLeftoverTaints(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, chaosResult v1alpha1.ChaosResult, targetRecords []records.Record, nodeName string, clients clients.ClientSets) []apiv1.Taint {
+	if experimentsDetails.Taints != "" {
+		return getTaints(experimentsDetails.Taints)
+	}
+
+	var taints []apiv1.Taint
+	entries, err := journal.GetPendingEntries("", clients)
+	if err != nil {
+		log.Warnf("[Cleanup]: Unable to get the journal entries, err: %v", err)
+	}
+	for _, entry := range entries {
+		args := entry.Revert.Args
+		if entry.Revert.Action == journal.ActionRemoveTaint && entry.ChaosUID == chaosResult.Labels["chaosUID"] && args["node"] == nodeName {
+			taints = append(taints, apiv1.Taint{Key: args["key"], Value: args["value"], Effect: apiv1.TaintEffect(args["effect"])})
+		}
+	}
+	for _, record := range targetRecords {
+		if record.Kind == "node" && record.Name == nodeName && record.Params["taint"] != "" {
+			taints = append(taints, getTaints(record.Params["taint"])...)
+		}
+	}
+	if len(taints) != 0 {
+		return taints
+	}
+
+	node, err := clients.KubeClient.CoreV1().Nodes().Get(ctx, nodeName, v1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Value == defaultTaintValue {
+			taints = append(taints, taint)
+		}
+	}
+	return taints
+}
+
+// removeTaints removes the taints matching the key, value and effect of the given taints from the node
+func removeTaints(ctx context.Context, nodeName string, taints []apiv1.Taint, clients clients.ClientSets) error {
+	node, err := clients.KubeClient.CoreV1().Nodes().Get(ctx, nodeName, v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Infof("[Cleanup]: %v node doesn't exist anymore", nodeName)
+			return nil
+		}
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: err.Error()}
+	}
+	if len(taints) == 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: "unable to discover the leftover taints, set the TAINTS env to remove them"}
+	}
+
+	var newTaints []apiv1.Taint
+	for _, taint := range node.Spec.Taints {
+		if !containsTaint(taints, taint) {
+			newTaints = append(newTaints, taint)
+		}
+	}
+	if len(newTaints) == len(node.Spec.Taints) {
+		log.Infof("[Cleanup]: %v node doesn't have the provided taints", nodeName)
+		return nil
+	}
+
+	node.Spec.Taints = newTaints
+	if _, err := clients.KubeClient.CoreV1().Nodes().Update(ctx, node, v1.UpdateOptions{}); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: fmt.Sprintf("failed to remove taints: %s", err.Error())}
+	}
+	return nil
+}
+
+// containsTaint checks if the taint matches the key, value and effect of any of the given taints
+func containsTaint(taints []apiv1.Taint, taint apiv1.Taint) bool {
+	for _, t := range taints {
+		if t.Key == taint.Key && t.Value == taint.Value && t.Effect == taint.Effect {
+			return true
+		}
+	}
+	return false
+}
+
+// getTargetNamespace returns the namespace of the target pod from the records of the chaosresult
+// it falls back to the APP_NAMESPACE env, if the pod isn't recorded
+func getTargetNamespace(experimentsDetails *experimentTypes.ExperimentDetails, targetRecords []records.Record, podName string) string {
+	for _, record := range targetRecords {
+		if record.Kind == "pod" && record.Name == podName && record.Namespace != "" {
+			return record.Namespace
+		}
+	}
+	return experimentsDetails.AppNS
+}
+
+// disableChaosMonkey disables the chaos monkey assaults on the given spring-boot pod
+func disableChaosMonkey(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, namespace, podName string, clients clients.ClientSets) error {
+	if namespace == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{podName: %s}", podName), Reason: "unable to discover the namespace of the pod, set the APP_NAMESPACE env to disable the chaos monkey"}
+	}
+
+	pod, err := clients.KubeClient.CoreV1().Pods(namespace).Get(ctx, podName, v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Infof("[Cleanup]: %v pod doesn't exist anymore", podName)
+			return nil
+		}
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{podName: %s, namespace: %s}", podName, namespace), Reason: err.Error()}
+	}
+	return springBootLib.DisableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, *pod)
+}
+
+// cleanupNodes creates the helper pods on the target nodes to revert the node scoped artifacts
+// like netem rules, iptables rules, proxy servers and disk fill files
+func cleanupNodes(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "CleanupNodes")
+	defer span.End()
+
+	nodes, err := getTargetNodes(ctx, experimentsDetails, clients)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get target nodes")
+	}
+
+	if experimentsDetails.EngineName != "" {
+		if err := common.SetHelperData(chaosDetails, experimentsDetails.SetHelperData, clients); err != nil {
+			return stacktrace.Propagate(err, "could not set helper data")
+		}
+	}
+
+	experimentsDetails.RunID = stringutils.GetRunID()
+
+	// creating the helper pod on every target node
+	for _, node := range nodes {
+		if err := createHelperPod(ctx, experimentsDetails, clients, chaosDetails, node); err != nil {
+			return stacktrace.Propagate(err, "could not create helper pod")
+		}
+	}
+
+	appLabel := fmt.Sprintf("app=%s-helper-%s", experimentsDetails.ExperimentName, experimentsDetails.RunID)
+
+	//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
+	log.Info("[Status]: Checking the status of the helper pods")
+	if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
+		return stacktrace.Propagate(err, "could not check helper status")
+	}
+
+	// Wait till the completion of the helper pod
+	log.Info("[Wait]: Waiting till the completion of the helper pods")
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	if err != nil || podStatus == "Failed" {
+		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
+		return common.HelperFailedError(err, appLabel, experimentsDetails.ChaosNamespace, false)
+	}
+
+	//Deleting all the helper pod
+	log.Info("[Cleanup]: Deleting all the helper pods")
+	if err := common.DeleteAllPod(appLabel, experimentsDetails.ChaosNamespace, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
+		return stacktrace.Propagate(err, "could not delete helper pod(s)")
+	}
+	return nil
+}
+
+// getTargetNodes returns the nodes provided in the TARGET_NODES env, defaults to all the nodes
+func getTargetNodes(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) ([]string, error) {
+	if strings.TrimSpace(experimentsDetails.TargetNodes) != "" {
+		return strings.Split(strings.TrimSpace(experimentsDetails.TargetNodes), ","), nil
+	}
+
+	nodeList, err := clients.KubeClient.CoreV1().Nodes().List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: err.Error()}
+	}
+	var nodes []string
+	for _, node := range nodeList.Items {
+		nodes = append(nodes, node.Name)
+	}
+	return nodes, nil
+}
+
+// createHelperPod derive the attributes for helper pod and create the helper pod
+func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, nodeName string) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "CreateCleanupHelperPod")
+	defer span.End()
+
+	privilegedEnable := true
+	terminationGracePeriodSeconds := int64(experimentsDetails.TerminationGracePeriodSeconds)
+
+	helperPod := &apiv1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			GenerateName: experimentsDetails.ExperimentName + "-helper-",
+			Namespace:    experimentsDetails.ChaosNamespace,
+			Labels:       common.GetHelperLabels(chaosDetails.Labels, experimentsDetails.RunID, experimentsDetails.ExperimentName),
+			Annotations:  chaosDetails.Annotations,
+		},
+		Spec: apiv1.PodSpec{
+			HostPID:                       true,
+			TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+			ImagePullSecrets:              chaosDetails.ImagePullSecrets,
+			ServiceAccountName:            experimentsDetails.ChaosServiceAccount,
+			RestartPolicy:                 apiv1.RestartPolicyNever,
+			NodeName:                      nodeName,
+			Volumes: []apiv1.Volume{
+				{
+					Name: "cri-socket",
+					VolumeSource: apiv1.VolumeSource{
+						HostPath: &apiv1.HostPathVolumeSource{
+							Path: experimentsDetails.SocketPath,
+						},
+					},
+				},
+			},
+			Containers: []apiv1.Container{
+				{
+					Name:            experimentsDetails.ExperimentName,
+					Image:           experimentsDetails.LIBImage,
+					ImagePullPolicy: apiv1.PullPolicy(experimentsDetails.LIBImagePullPolicy),
+					Command: []string{
+						"/bin/bash",
+					},
+					Args: []string{
+						"-c",
+						"./helpers -name cleanup",
+					},
+					Resources: chaosDetails.Resources,
+					Env:       getPodEnv(ctx, experimentsDetails, nodeName),
+					VolumeMounts: []apiv1.VolumeMount{
+						{
+							Name:      "cri-socket",
+							MountPath: experimentsDetails.SocketPath,
+						},
+					},
+					SecurityContext: &apiv1.SecurityContext{
+						Privileged: &privilegedEnable,
+						Capabilities: &apiv1.Capabilities{
+							Add: []apiv1.Capability{
+								"NET_ADMIN",
+								"SYS_ADMIN",
+							},
+						},
+					},
+				},
+			},
+			// tolerating all the taints, as the leftover taints should not stop the cleanup
+			Tolerations: []apiv1.Toleration{
+				{
+					Operator: apiv1.TolerationOpExists,
+				},
+			},
+		},
+	}
+
+	if len(chaosDetails.SideCar) != 0 {
+		helperPod.Spec.Containers = append(helperPod.Spec.Containers, common.BuildSidecar(chaosDetails)...)
+		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
+	}
+
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(ctx, helperPod, v1.CreateOptions{})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
+	}
+	return nil
+}
+
+// getPodEnv derive all the env required for the helper pod
+func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, nodeName string) []apiv1.EnvVar {
+
+	var envDetails common.ENVDetails
+	envDetails.SetEnv("NODE_NAME", nodeName).
+		SetEnv("APP_NAMESPACE", experimentsDetails.AppNS).
+		SetEnv("CHAOS_NAMESPACE", experimentsDetails.ChaosNamespace).
+		SetEnv("CHAOSENGINE", experimentsDetails.EngineName).
+		SetEnv("CHAOS_UID", string(experimentsDetails.ChaosUID)).
+		SetEnv("CONTAINER_RUNTIME", experimentsDetails.ContainerRuntime).
+		SetEnv("NETWORK_INTERFACE", experimentsDetails.NetworkInterface).
+		SetEnv("EXPERIMENT_NAME", experimentsDetails.ExperimentName).
+		SetEnv("SOCKET_PATH", experimentsDetails.SocketPath).
+		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
+		SetEnv("PROXY_PORT", strconv.Itoa(experimentsDetails.ProxyPort)).
+		SetEnv("DRY_RUN", strconv.FormatBool(experimentsDetails.DryRun)).
+		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPEndpoint)).
+		SetEnv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPLogsEndpoint)).
+		SetEnv("TRACE_PARENT", telemetry.GetMarshalledSpanFromContext(ctx)).
+		SetEnvFromDownwardAPI("v1", "metadata.name")
+
+	return envDetails.ENV
+}
+
+// getStatus returns the status of the artifact based on the dry run mode
+func getStatus(dryRun bool) string {
+	if dryRun {
+		return "Found"
+	}
+	return "Reverted"
+}
+
+// logReport logs the artifacts found and reverted by the cleanup
+func logReport(report []experimentTypes.Report) {
+	if len(report) == 0 {
+		log.Info("[Report]: No leftover chaos artifacts found")
+		return
+	}
+	for _, r := range report {
+		log.InfoWithValues("[Report]: Leftover chaos artifact", logrus.Fields{
+			"Kind":   r.Kind,
+			"Name":   r.Name,
+			"Action": r.Action,
+			"Status": r.Status,
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package lib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/cleanup/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
+	"github.com/litmuschaos/litmus-go/pkg/records"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRevertJournalEntryRemovesTheMatchingTaint(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&apiv1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: "node-1"},
+		Spec: apiv1.NodeSpec{Taints: []apiv1.Taint{
+			{Key: "app", Value: "node-taint", Effect: apiv1.TaintEffectNoExecute},
+			{Key: "app", Value: "node-taint", Effect: apiv1.TaintEffectNoSchedule},
+			{Key: "app", Value: "dedicated", Effect: apiv1.TaintEffectNoExecute},
+		}},
+	})
+	entry := journal.NewEntry("node-taint", "node", "node-1", "").
+		WithRevertAction(journal.ActionRemoveTaint, map[string]string{"node": "node-1", "key": "app", "value": "node-taint", "effect": "NoExecute"})
+
+	require.NoError(t, revertJournalEntry(context.Background(), *entry, clients.ClientSets{KubeClient: kubeClient}))
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), "node-1", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []apiv1.Taint{
+		{Key: "app", Value: "node-taint", Effect: apiv1.TaintEffectNoSchedule},
+		{Key: "app", Value: "dedicated", Effect: apiv1.TaintEffectNoExecute},
+	}, node.Spec.Taints)
+}
+
+func TestGetTaints(t *testing.T) {
+	assert.Equal(t, []apiv1.Taint{
+		{Key: "app", Value: "node-taint", Effect: apiv1.TaintEffectNoExecute},
+		{Key: "team", Value: "chaos", Effect: apiv1.TaintEffectNoSchedule},
+	}, getTaints("app, team=chaos:NoSchedule"))
+	assert.Empty(t, getTaints(""))
+}
+
+func TestGetLeftoverTaints(t *testing.T) {
+	node := &apiv1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: "node-1"},
+		Spec: apiv1.NodeSpec{Taints: []apiv1.Taint{
+			{Key: "app", Value: "node-taint", Effect: apiv1.TaintEffectNoExecute},
+			{Key: "dedicated", Value: "infra", Effect: apiv1.TaintEffectNoSchedule},
+		}},
+	}
+	journalEntry := &apiv1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "node-taint-abcde-journal",
+			Namespace: "litmus",
+			Labels:    map[string]string{"app.kubernetes.io/component": "chaos-journal", "chaosUID": "uid-1"},
+		},
+		Data: map[string]string{
+			"node.node-1": `{"id":"node.node-1","kind":"node","name":"node-1","status":"injected","revert":{"type":"api","action":"remove-taint","args":{"node":"node-1","key":"team","value":"chaos","effect":"NoSchedule"}}}`,
+		},
+	}
+	chaosResult := v1alpha1.ChaosResult{ObjectMeta: v1.ObjectMeta{Name: "nginx-chaos-node-taint", Namespace: "litmus", Labels: map[string]string{"chaosUID": "uid-1"}}}
+	targetRecords := []records.Record{
+		{Kind: "node", Name: "node-1", Params: map[string]string{"taint": "zone=chaos:PreferNoSchedule"}},
+		{Kind: "node", Name: "node-2", Params: map[string]string{"taint": "zone=other:NoSchedule"}},
+	}
+
+	testCases := map[string]struct {
+		taints        string
+		objects       []runtime.Object
+		targetRecords []records.Record
+		expected      []apiv1.Taint
+	}{
+		"taints env": {
+			taints:        "app=custom:NoSchedule",
+			objects:       []runtime.Object{node, journalEntry},
+			targetRecords: targetRecords,
+			expected:      []apiv1.Taint{{Key: "app", Value: "custom", Effect: apiv1.TaintEffectNoSchedule}},
+		},
+		"journal and records": {
+			objects:       []runtime.Object{node, journalEntry},
+			targetRecords: targetRecords,
+			expected: []apiv1.Taint{
+				{Key: "team", Value: "chaos", Effect: apiv1.TaintEffectNoSchedule},
+				{Key: "zone", Value: "chaos", Effect: apiv1.TaintEffectPreferNoSchedule},
+			},
+		},
+		"default taint value": {
+			objects:  []runtime.Object{node},
+			expected: []apiv1.Taint{{Key: "app", Value: "node-taint", Effect: apiv1.TaintEffectNoExecute}},
+		},
+		"missing node": {},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			clientSets := clients.ClientSets{KubeClient: fake.NewSimpleClientset(tc.objects...)}
+			experimentsDetails := &experimentTypes.ExperimentDetails{Taints: tc.taints}
+			taints := getLeftoverTaints(context.Background(), experimentsDetails, chaosResult, tc.targetRecords, "node-1", clientSets)
+			assert.Equal(t, tc.expected, taints)
+		})
+	}
+}
+
+func TestDisableChaosMonkeyIsScopedToTheNamespace(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		t.Errorf("the pods shouldn't be listed, got the list in %q namespace", action.GetNamespace())
+		return true, nil, nil
+	})
+	clientSets := clients.ClientSets{KubeClient: kubeClient}
+	targetRecords := []records.Record{{Kind: "pod", Name: "spring-0", Namespace: "apps"}}
+
+	assert.Equal(t, "apps", getTargetNamespace(&experimentTypes.ExperimentDetails{AppNS: "default"}, targetRecords, "spring-0"))
+	assert.Equal(t, "default", getTargetNamespace(&experimentTypes.ExperimentDetails{AppNS: "default"}, targetRecords, "spring-1"))
+
+	// the missing pods are skipped
+	assert.NoError(t, disableChaosMonkey(context.Background(), &experimentTypes.ExperimentDetails{}, "apps", "spring-0", clientSets))
+	assert.Error(t, disableChaosMonkey(context.Background(), &experimentTypes.ExperimentDetails{}, "", "spring-0", clientSets))
+}
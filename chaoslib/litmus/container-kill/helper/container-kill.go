@@ -44,12 +44,12 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// Initialise the chaos attributes
 	types.InitialiseChaosVariables(&chaosDetails)
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	// Initialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
 
-	if err := killContainer(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+	if err := killContainer(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
 		// update failstep inside chaosresult
 		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
 			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
@@ -61,7 +61,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 // killContainer kill the random application container
 // it will kill the container till the chaos duration
 // the execution will stop after timestamp passes the given chaos duration
-func killContainer(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func killContainer(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
 	if err != nil {
 		return stacktrace.Propagate(err, "could not parse targets")
@@ -76,11 +76,14 @@ func killContainer(experimentsDetails *experimentTypes.ExperimentDetails, client
 			TargetContainer: t.TargetContainer,
 			Source:          chaosDetails.ChaosPodName,
 		}
+		if td.References, err = events.GetPodReferences(ctx, clients, td.Name, td.Namespace); err != nil {
+			log.Warnf("[Events]: Unable to get the references of %v pod, err: %v", td.Name, err)
+		}
 		targets = append(targets, td)
 		log.Infof("Injecting chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
 	}
 
-	if err := killIterations(targets, experimentsDetails, clients, eventsDetails, chaosDetails, resultDetails); err != nil {
+	if err := killIterations(ctx, targets, experimentsDetails, clients, eventsDetails, chaosDetails, resultDetails); err != nil {
 		return err
 	}
 
@@ -88,7 +91,17 @@ func killContainer(experimentsDetails *experimentTypes.ExperimentDetails, client
 	return nil
 }
 
-func killIterations(targets []targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func killIterations(ctx context.Context, targets []targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+
+	// the targets are marked as under chaos till the end of the iterations
+	for _, t := range targets {
+		events.MarkUnderChaos(ctx, clients, chaosDetails, t.References)
+	}
+	defer func() {
+		for _, t := range targets {
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+		}
+	}()
 
 	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 	ChaosStartTimeStamp := time.Now()
@@ -118,21 +131,25 @@ func killIterations(targets []targetDetails, experimentsDetails *experimentTypes
 			containerIds = append(containerIds, containerId)
 		}
 
+		injectStart := time.Now()
 		if err := kill(experimentsDetails, containerIds, clients, eventsDetails, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not kill target container")
 		}
+		telemetry.RecordInjectionLatency(ctx, "pod", injectStart)
 
 		//Waiting for the chaos interval after chaos injection
 		if experimentsDetails.ChaosInterval != 0 {
 			log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 		}
 
 		for _, t := range targets {
 			if err := validate(t, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 				return stacktrace.Propagate(err, "could not verify restart count")
 			}
-			if err := result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "targeted", "pod", t.Name); err != nil {
+			if err := result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "targeted", "pod", t.Name, clients); err != nil {
 				return stacktrace.Propagate(err, "could not annotate chaosresult")
 			}
 		}
@@ -275,4 +292,5 @@ type targetDetails struct {
 	TargetContainer    string
 	RestartCountBefore int
 	Source             string
+	References         []events.TargetReference
 }
@@ -37,7 +37,7 @@ func PrepareContainerKill(ctx context.Context, experimentsDetails *experimentTyp
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "provide one of the appLabel or TARGET_PODS"}
 	}
 	//Set up the tunables if provided in range
-	SetChaosTunables(experimentsDetails)
+	SetChaosTunables(experimentsDetails, chaosDetails)
 
 	log.InfoWithValues("[Info]: The tunables are:", logrus.Fields{
 		"PodsAffectedPerc": experimentsDetails.PodsAffectedPerc,
@@ -52,7 +52,9 @@ func PrepareContainerKill(ctx context.Context, experimentsDetails *experimentTyp
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// Getting the serviceAccountName, need permission inside helper pod to create the events
@@ -70,23 +72,20 @@ func PrepareContainerKill(ctx context.Context, experimentsDetails *experimentTyp
 	}
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, resultDetails, eventsDetails)
+	}, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, resultDetails, eventsDetails)
+	}); err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -120,7 +119,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 		log.Info("[Status]: Checking the status of the helper pods")
-		if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return stacktrace.Propagate(err, "could not check helper status")
 		}
@@ -128,7 +127,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		// Wait till the completion of the helper pod
 		// set an upper limit for the waiting time
 		log.Info("[Wait]: waiting till the completion of the helper pod")
-		podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+		podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
 		if err != nil || podStatus == "Failed" {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return common.HelperFailedError(err, appLabel, experimentsDetails.ChaosNamespace, true)
@@ -158,6 +157,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	targets := common.FilterPodsForNodes(targetPodList, experimentsDetails.TargetContainer)
 
 	for node, tar := range targets {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
+		}
 		var targetsPerNode []string
 		for _, k := range tar.Target {
 			targetsPerNode = append(targetsPerNode, fmt.Sprintf("%s:%s:%s", k.Name, k.Namespace, k.TargetContainer))
@@ -172,7 +174,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 	log.Info("[Status]: Checking the status of the helper pods")
-	if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+	if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return stacktrace.Propagate(err, "could not check helper status")
 	}
@@ -180,7 +182,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	// Wait till the completion of the helper pod
 	// set an upper limit for the waiting time
 	log.Info("[Wait]: waiting till the completion of the helper pod")
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
 	if err != nil || podStatus == "Failed" {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return common.HelperFailedError(err, appLabel, experimentsDetails.ChaosNamespace, true)
@@ -262,7 +264,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(context.Background(), helperPod, v1.CreateOptions{})
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(ctx, helperPod, v1.CreateOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
@@ -287,6 +289,7 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 		SetEnv("EXPERIMENT_NAME", experimentsDetails.ExperimentName).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
 		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPEndpoint)).
+		SetEnv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPLogsEndpoint)).
 		SetEnv("TRACE_PARENT", telemetry.GetMarshalledSpanFromContext(ctx)).
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
@@ -295,7 +298,7 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 
 // SetChaosTunables will setup a random value within a given range of values
 // If the value is not provided in range it'll setup the initial provided value.
-func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
-	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc)
-	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
+func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc, chaosDetails)
+	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence, chaosDetails)
 }
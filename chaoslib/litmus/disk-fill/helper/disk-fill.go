@@ -9,16 +9,17 @@ import (
 	"go.opentelemetry.io/otel"
 	"os"
 	"os/exec"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/disk-fill/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -42,13 +43,13 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Fetching all the ENV passed in the helper pod
 	log.Info("[PreReq]: Getting the ENV variables")
@@ -56,7 +57,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// Intialise the chaos attributes
 	types.InitialiseChaosVariables(&chaosDetails)
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	// Intialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -64,7 +65,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	if err := diskFill(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+	if err := diskFill(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
 		// update failstep inside chaosresult
 		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
 			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
@@ -74,7 +75,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 }
 
 // diskFill contains steps to inject disk-fill chaos
-func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func diskFill(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
 	if err != nil {
@@ -90,6 +91,9 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 			TargetContainer: t.TargetContainer,
 			Source:          chaosDetails.ChaosPodName,
 		}
+		if td.References, err = events.GetPodReferences(ctx, clients, td.Name, td.Namespace); err != nil {
+			log.Warnf("[Events]: Unable to get the references of %v pod, err: %v", td.Name, err)
+		}
 
 		// Derive the container id of the target container
 		td.ContainerId, err = common.GetContainerID(td.Namespace, td.Name, td.TargetContainer, clients, chaosDetails.ChaosPodName)
@@ -126,22 +130,30 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 	}
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(targets, experimentsDetails, clients, resultDetails.Name)
+	go abortWatcher(targets, experimentsDetails, clients, resultDetails.Name, chaosDetails)
 
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(1)
+		common.Exit(1)
 	default:
 	}
 
 	for _, t := range targets {
 		if t.SizeToFill > 0 {
+			// recording the revert details inside the journal before injecting the chaos
+			if err = journal.Record(getJournalEntry(t, experimentsDetails), chaosDetails, clients); err != nil {
+				return stacktrace.Propagate(err, "could not record the journal entry")
+			}
+			injectStart := time.Now()
 			if err := fillDisk(t, experimentsDetails.DataBlockSize); err != nil {
 				return stacktrace.Propagate(err, "could not fill ephemeral storage")
 			}
+			telemetry.RecordInjectionLatency(ctx, "pod", injectStart)
+			events.MarkUnderChaos(ctx, clients, chaosDetails, t.References)
+			records.Injected(records.FromJournalEntry(getJournalEntry(t, experimentsDetails)), resultDetails.Name, chaosDetails, clients)
 			log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
-			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
+			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name, clients); err != nil {
 				if revertErr := revertDiskFill(t, clients); revertErr != nil {
 					return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
 				}
@@ -154,7 +166,15 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDuration(experimentsDetails.ChaosDuration); err != nil {
+		return err
+	}
+
+	// waiting for the approval before reverting the chaos, if the revert gate is enabled
+	// the chaosresult is owned by the experiment pod, so its phase isn't updated by the helper
+	if err := approval.WaitForApproval(ctx, types.ApprovalGateRevert, clients, nil, eventsDetails, chaosDetails); err != nil {
+		log.Errorf("Approval to revert the chaos failed, reverting the chaos, err: %v", err)
+	}
 
 	log.Info("[Chaos]: Stopping the experiment")
 
@@ -163,11 +183,19 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 	for _, t := range targets {
 		// It will delete the target pod if target pod is evicted
 		// if target pod is still running then it will delete all the files, which was created earlier during chaos execution
-		if err = revertDiskFill(t, clients); err != nil {
+		revertStart := time.Now()
+		err = revertDiskFill(t, clients)
+		records.Reverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), resultDetails.Name, err, chaosDetails, clients)
+		if err != nil {
 			errList = append(errList, err.Error())
 			continue
 		}
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+		telemetry.RecordRevertLatency(ctx, "pod", revertStart)
+		events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+		if err = journal.MarkReverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), chaosDetails, clients); err != nil {
+			errList = append(errList, err.Error())
+		}
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 			errList = append(errList, err.Error())
 		}
 	}
@@ -292,8 +320,20 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.SocketPath = types.Getenv("SOCKET_PATH", "")
 }
 
+// getJournalEntry returns the journal entry of the target, containing the revert details
+func getJournalEntry(t targetDetails, experimentsDetails *experimentTypes.ExperimentDetails) *journal.Entry {
+	return journal.NewEntry(experimentsDetails.ExperimentName, "pod", t.Name, t.Namespace).
+		WithContainer(t.TargetContainer, t.ContainerId, t.TargetPID).
+		WithParam("sizeToFill", strconv.Itoa(t.SizeToFill)).
+		WithParam("dataBlockSize", strconv.Itoa(experimentsDetails.DataBlockSize)).
+		WithRevertCommands(fmt.Sprintf("sudo rm -rf /proc/%v/root/home/diskfill", t.TargetPID))
+}
+
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targets []targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string) {
+func abortWatcher(targets []targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	// waiting till the abort signal received
 	<-abort
 
@@ -304,11 +344,16 @@ func abortWatcher(targets []targetDetails, experimentsDetails *experimentTypes.E
 	for retry > 0 {
 		for _, t := range targets {
 			err := revertDiskFill(t, clients)
+			records.Reverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), resultName, err, chaosDetails, clients)
 			if err != nil {
 				log.Errorf("unable to kill disk-fill process, err :%v", err)
 				continue
 			}
-			if err = result.AnnotateChaosResult(resultName, experimentsDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+			events.UnmarkUnderChaos(context.Background(), clients, chaosDetails, t.References)
+			if err = journal.MarkReverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), chaosDetails, clients); err != nil {
+				log.Errorf("unable to update the journal, err :%v", err)
+			}
+			if err = result.AnnotateChaosResult(resultName, experimentsDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				log.Errorf("unable to annotate the chaosresult, err :%v", err)
 			}
 		}
@@ -316,7 +361,8 @@ func abortWatcher(targets []targetDetails, experimentsDetails *experimentTypes.E
 		time.Sleep(1 * time.Second)
 	}
 	log.Info("Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
 
 func getDiskSizeToFill(t targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (int, error) {
@@ -370,4 +416,5 @@ type targetDetails struct {
 	SizeToFill      int
 	TargetPID       int
 	Source          string
+	References      []events.TargetReference
 }
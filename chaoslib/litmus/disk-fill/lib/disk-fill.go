@@ -40,7 +40,7 @@ func PrepareDiskFill(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "provide one of the appLabel or TARGET_PODS"}
 	}
 	//set up the tunables if provided in range
-	setChaosTunables(experimentsDetails)
+	setChaosTunables(experimentsDetails, chaosDetails)
 
 	log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
 		"FillPercentage":            experimentsDetails.FillPercentage,
@@ -57,7 +57,9 @@ func PrepareDiskFill(ctx context.Context, experimentsDetails *experimentTypes.Ex
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// Getting the serviceAccountName, need permission inside helper pod to create the events
@@ -75,23 +77,20 @@ func PrepareDiskFill(ctx context.Context, experimentsDetails *experimentTypes.Ex
 	}
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails)
+	}, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails)
+	}); err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -124,7 +123,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 		log.Info("[Status]: Checking the status of the helper pods")
-		if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return stacktrace.Propagate(err, "could not check helper status")
 		}
@@ -132,7 +131,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		// Wait till the completion of the helper pod
 		// set an upper limit for the waiting time
 		log.Info("[Wait]: waiting till the completion of the helper pod")
-		podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+		podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout+chaosDetails.Approval.TimeoutOf(types.ApprovalGateRevert), common.GetContainerNames(chaosDetails)...)
 		if err != nil || podStatus == "Failed" {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -165,6 +164,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	targets := common.FilterPodsForNodes(targetPodList, experimentsDetails.TargetContainer)
 
 	for node, tar := range targets {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
+		}
 		var targetsPerNode []string
 		for _, k := range tar.Target {
 			targetsPerNode = append(targetsPerNode, fmt.Sprintf("%s:%s:%s", k.Name, k.Namespace, k.TargetContainer))
@@ -179,7 +181,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 	log.Info("[Status]: Checking the status of the helper pods")
-	if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+	if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return stacktrace.Propagate(err, "could not check helper status")
 	}
@@ -187,7 +189,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	// Wait till the completion of the helper pod
 	// set an upper limit for the waiting time
 	log.Info("[Wait]: waiting till the completion of the helper pod")
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout+chaosDetails.Approval.TimeoutOf(types.ApprovalGateRevert), common.GetContainerNames(chaosDetails)...)
 	if err != nil || podStatus == "Failed" {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -248,7 +250,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 						"./helpers -name disk-fill",
 					},
 					Resources: chaosDetails.Resources,
-					Env:       getPodEnv(ctx, experimentsDetails, targets),
+					Env:       getPodEnv(ctx, experimentsDetails, chaosDetails, targets),
 					VolumeMounts: []apiv1.VolumeMount{
 						{
 							Name:      "socket-path",
@@ -268,7 +270,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(context.Background(), helperPod, v1.CreateOptions{})
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(ctx, helperPod, v1.CreateOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
@@ -276,7 +278,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 }
 
 // getPodEnv derive all the env required for the helper pod
-func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targets string) []apiv1.EnvVar {
+func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, targets string) []apiv1.EnvVar {
 
 	var envDetails common.ENVDetails
 	envDetails.SetEnv("TARGETS", targets).
@@ -293,6 +295,10 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 		SetEnv("SOCKET_PATH", experimentsDetails.SocketPath).
 		SetEnv("CONTAINER_RUNTIME", experimentsDetails.ContainerRuntime).
 		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPEndpoint)).
+		SetEnv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPLogsEndpoint)).
+		SetEnv("APPROVAL_GATES", strings.Join(chaosDetails.Approval.Gates, ",")).
+		SetEnv("APPROVAL_TIMEOUT", strconv.Itoa(chaosDetails.Approval.Timeout)).
+		SetEnv("APPROVAL_CONFIGMAP", chaosDetails.Approval.ConfigMap).
 		SetEnv("TRACE_PARENT", telemetry.GetMarshalledSpanFromContext(ctx)).
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
@@ -301,9 +307,9 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 
 // setChaosTunables will setup a random value within a given range of values
 // If the value is not provided in range it'll setup the initial provided value.
-func setChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
-	experimentsDetails.FillPercentage = common.ValidateRange(experimentsDetails.FillPercentage)
-	experimentsDetails.EphemeralStorageMebibytes = common.ValidateRange(experimentsDetails.EphemeralStorageMebibytes)
-	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc)
-	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
+func setChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+	experimentsDetails.FillPercentage = common.ValidateRange(experimentsDetails.FillPercentage, chaosDetails)
+	experimentsDetails.EphemeralStorageMebibytes = common.ValidateRange(experimentsDetails.EphemeralStorageMebibytes, chaosDetails)
+	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc, chaosDetails)
+	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence, chaosDetails)
 }
@@ -2,21 +2,15 @@ package lib
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
-	"strings"
-	"syscall"
 
 	ebsloss "github.com/litmuschaos/litmus-go/chaoslib/litmus/ebs-loss/lib"
-	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ebs-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
-	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
 )
 
@@ -32,48 +26,47 @@ func PrepareEBSLossByTag(ctx context.Context, experimentsDetails *experimentType
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 
-		targetEBSVolumeIDList := common.FilterBasedOnPercentage(experimentsDetails.VolumeAffectedPerc, experimentsDetails.TargetVolumeIDList)
+		targetEBSVolumeIDList := common.FilterBasedOnPercentage(experimentsDetails.VolumeAffectedPerc, experimentsDetails.TargetVolumeIDList, chaosDetails)
 		log.Infof("[Chaos]:Number of volumes targeted: %v", len(targetEBSVolumeIDList))
 
 		// watching for the abort signal and revert the chaos
-		go ebsloss.AbortWatcher(experimentsDetails, targetEBSVolumeIDList, abort, chaosDetails)
+		go ebsloss.AbortWatcher(ctx, experimentsDetails, targetEBSVolumeIDList, abort, chaosDetails)
 
-		switch strings.ToLower(experimentsDetails.Sequence) {
-		case "serial":
-			if err = ebsloss.InjectChaosInSerialMode(ctx, experimentsDetails, targetEBSVolumeIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in serial mode")
-			}
-		case "parallel":
-			if err = ebsloss.InjectChaosInParallelMode(ctx, experimentsDetails, targetEBSVolumeIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-			}
-		default:
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+		if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, targetEBSVolumeIDList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+			return ebsloss.InjectChaosInSerialMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+		}, func(ctx context.Context, targets []string) error {
+			return ebsloss.InjectChaosInParallelMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+		}); err != nil {
+			return err
 		}
 		//Waiting for the ramp time after chaos injection
 		if experimentsDetails.RampTime != 0 {
 			log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-			common.WaitForDuration(experimentsDetails.RampTime)
+			if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -46,7 +46,7 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 			//Detaching the ebs volume from the instance
 			log.Info("[Chaos]: Detaching the EBS volume from the instance")
-			if err = ebs.EBSVolumeDetach(volumeID, experimentsDetails.Region); err != nil {
+			if err = ebs.EBSVolumeDetach(ctx, volumeID, experimentsDetails.Region); err != nil {
 				return stacktrace.Propagate(err, "ebs detachment failed")
 			}
 
@@ -68,7 +68,9 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 			//Wait for chaos duration
 			log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			//Getting the EBS volume attachment status
 			ebsState, err := ebs.GetEBSStatus(volumeID, ec2InstanceID, experimentsDetails.Region)
@@ -82,7 +84,7 @@ func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			default:
 				//Attaching the ebs volume from the instance
 				log.Info("[Chaos]: Attaching the EBS volume back to the instance")
-				if err = ebs.EBSVolumeAttach(volumeID, ec2InstanceID, device, experimentsDetails.Region); err != nil {
+				if err = ebs.EBSVolumeAttach(ctx, volumeID, ec2InstanceID, device, experimentsDetails.Region); err != nil {
 					return stacktrace.Propagate(err, "ebs attachment failed")
 				}
 
@@ -136,9 +138,12 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 		}
 
 		for _, volumeID := range targetEBSVolumeIDList {
+			if err := common.WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
 			//Detaching the ebs volume from the instance
 			log.Info("[Chaos]: Detaching the EBS volume from the instance")
-			if err := ebs.EBSVolumeDetach(volumeID, experimentsDetails.Region); err != nil {
+			if err := ebs.EBSVolumeDetach(ctx, volumeID, experimentsDetails.Region); err != nil {
 				return stacktrace.Propagate(err, "ebs detachment failed")
 			}
 			common.SetTargets(volumeID, "injected", "EBS", chaosDetails)
@@ -166,7 +171,9 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 		//Wait for chaos interval
 		log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-		common.WaitForDuration(experimentsDetails.ChaosInterval)
+		if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+			return err
+		}
 
 		for i, volumeID := range targetEBSVolumeIDList {
 
@@ -182,7 +189,7 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			default:
 				//Attaching the ebs volume from the instance
 				log.Info("[Chaos]: Attaching the EBS volume from the instance")
-				if err = ebs.EBSVolumeAttach(volumeID, ec2InstanceIDList[i], deviceList[i], experimentsDetails.Region); err != nil {
+				if err = ebs.EBSVolumeAttach(ctx, volumeID, ec2InstanceIDList[i], deviceList[i], experimentsDetails.Region); err != nil {
 					return stacktrace.Propagate(err, "ebs attachment failed")
 				}
 
@@ -200,7 +207,9 @@ func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // AbortWatcher will watching for the abort signal and revert the chaos
-func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, volumeIDList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+func AbortWatcher(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, volumeIDList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -227,7 +236,7 @@ func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, volumeI
 			}
 			//Attaching the ebs volume from the instance
 			log.Info("[Chaos]: Attaching the EBS volume from the instance")
-			err = ebs.EBSVolumeAttach(experimentsDetails.EBSVolumeID, instanceID, deviceName, experimentsDetails.Region)
+			err = ebs.EBSVolumeAttach(ctx, experimentsDetails.EBSVolumeID, instanceID, deviceName, experimentsDetails.Region)
 			if err != nil {
 				log.Errorf("EBS attachment failed when an abort signal is received: %v", err)
 			}
@@ -235,5 +244,6 @@ func AbortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, volumeI
 		common.SetTargets(volumeID, "reverted", "EBS", chaosDetails)
 	}
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
@@ -2,11 +2,8 @@ package lib
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -35,18 +32,20 @@ func PrepareEC2TerminateByID(ctx context.Context, experimentsDetails *experiment
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//get the instance id or list of instance ids
@@ -56,25 +55,22 @@ func PrepareEC2TerminateByID(ctx context.Context, experimentsDetails *experiment
 	}
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, instanceIDList, chaosDetails)
-
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	go abortWatcher(ctx, experimentsDetails, instanceIDList, chaosDetails)
+
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, instanceIDList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []string) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}); err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -87,7 +83,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -108,7 +104,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Stopping the EC2 instance
 				log.Info("[Chaos]: Stopping the desired EC2 instance")
-				if err := awslib.EC2Stop(id, experimentsDetails.Region); err != nil {
+				if err := awslib.EC2Stop(ctx, id, experimentsDetails.Region); err != nil {
 					return stacktrace.Propagate(err, "ec2 instance failed to stop")
 				}
 
@@ -130,12 +126,14 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Wait for chaos interval
 				log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-				time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+				if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+					return err
+				}
 
 				//Starting the EC2 instance
 				if experimentsDetails.ManagedNodegroup != "enable" {
 					log.Info("[Chaos]: Starting back the EC2 instance")
-					if err := awslib.EC2Start(id, experimentsDetails.Region); err != nil {
+					if err := awslib.EC2Start(ctx, id, experimentsDetails.Region); err != nil {
 						return stacktrace.Propagate(err, "ec2 instance failed to start")
 					}
 
@@ -161,7 +159,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -179,9 +177,12 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 			//PowerOff the instance
 			for _, id := range instanceIDList {
+				if err := common.WaitForStaggeredStart(ctx); err != nil {
+					return err
+				}
 				//Stopping the EC2 instance
 				log.Info("[Chaos]: Stopping the desired EC2 instance")
-				if err := awslib.EC2Stop(id, experimentsDetails.Region); err != nil {
+				if err := awslib.EC2Stop(ctx, id, experimentsDetails.Region); err != nil {
 					return stacktrace.Propagate(err, "ec2 instance failed to stop")
 				}
 				common.SetTargets(id, "injected", "EC2", chaosDetails)
@@ -205,14 +206,16 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 			//Wait for chaos interval
 			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-			time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			//Starting the EC2 instance
 			if experimentsDetails.ManagedNodegroup != "enable" {
 
 				for _, id := range instanceIDList {
 					log.Info("[Chaos]: Starting back the EC2 instance")
-					if err := awslib.EC2Start(id, experimentsDetails.Region); err != nil {
+					if err := awslib.EC2Start(ctx, id, experimentsDetails.Region); err != nil {
 						return stacktrace.Propagate(err, "ec2 instance failed to start")
 					}
 				}
@@ -235,7 +238,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // watching for the abort signal and revert the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -253,7 +258,7 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanc
 			}
 
 			log.Info("[Abort]: Starting EC2 instance as abort signal received")
-			err := awslib.EC2Start(id, experimentsDetails.Region)
+			err := awslib.EC2Start(ctx, id, experimentsDetails.Region)
 			if err != nil {
 				log.Errorf("EC2 instance failed to start when an abort signal is received: %v", err)
 			}
@@ -261,5 +266,6 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, instanc
 		common.SetTargets(id, "reverted", "EC2", chaosDetails)
 	}
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
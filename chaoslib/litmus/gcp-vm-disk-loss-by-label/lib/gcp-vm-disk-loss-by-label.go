@@ -2,14 +2,9 @@ package lib
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
-	"strings"
-	"syscall"
 	"time"
 
-	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
 	"github.com/litmuschaos/litmus-go/pkg/events"
@@ -36,21 +31,23 @@ func PrepareDiskVolumeLossByLabel(ctx context.Context, computeService *compute.S
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
-	diskVolumeNamesList := common.FilterBasedOnPercentage(experimentsDetails.DiskAffectedPerc, experimentsDetails.TargetDiskVolumeNamesList)
+	diskVolumeNamesList := common.FilterBasedOnPercentage(experimentsDetails.DiskAffectedPerc, experimentsDetails.TargetDiskVolumeNamesList, chaosDetails)
 
 	if err := getDeviceNamesAndVMInstanceNames(diskVolumeNamesList, computeService, experimentsDetails); err != nil {
 		return err
@@ -60,30 +57,27 @@ func PrepareDiskVolumeLossByLabel(ctx context.Context, computeService *compute.S
 
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 
 	default:
 		// watching for the abort signal and revert the chaos
-		go abortWatcher(computeService, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, abort, chaosDetails)
-
-		switch strings.ToLower(experimentsDetails.Sequence) {
-		case "serial":
-			if err = injectChaosInSerialMode(ctx, computeService, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in serial mode")
-			}
-		case "parallel":
-			if err = injectChaosInParallelMode(ctx, computeService, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-			}
-		default:
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+		go abortWatcher(ctx, computeService, experimentsDetails, diskVolumeNamesList, experimentsDetails.TargetDiskInstanceNamesList, experimentsDetails.Zones, abort, chaosDetails)
+
+		if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, common.Indices(len(diskVolumeNamesList)), clients, chaosDetails, func(ctx context.Context, targets []int) error {
+			return injectChaosInSerialMode(ctx, computeService, getTargetDetails(experimentsDetails, targets), common.SelectIndices(diskVolumeNamesList, targets), common.SelectIndices(experimentsDetails.TargetDiskInstanceNamesList, targets), experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails)
+		}, func(ctx context.Context, targets []int) error {
+			return injectChaosInParallelMode(ctx, computeService, getTargetDetails(experimentsDetails, targets), common.SelectIndices(diskVolumeNamesList, targets), common.SelectIndices(experimentsDetails.TargetDiskInstanceNamesList, targets), experimentsDetails.Zones, clients, resultDetails, eventsDetails, chaosDetails)
+		}); err != nil {
+			return err
 		}
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -110,7 +104,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Detaching the disk volume from the instance
 			log.Info("[Chaos]: Detaching the disk volume from the instance")
-			if err = gcp.DiskVolumeDetach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = gcp.DiskVolumeDetach(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i]); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -132,7 +126,9 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Wait for chaos duration
 			log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			//Getting the disk volume attachment status
 			diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, instanceNamesList[i], zone)
@@ -146,7 +142,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 			default:
 				//Attaching the disk volume to the instance
 				log.Info("[Chaos]: Attaching the disk volume back to the instance")
-				if err = gcp.DiskVolumeAttach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = gcp.DiskVolumeAttach(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
@@ -184,10 +180,13 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 		}
 
 		for i := range targetDiskVolumeNamesList {
+			if err := common.WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
 
 			//Detaching the disk volume from the instance
 			log.Info("[Chaos]: Detaching the disk volume from the instance")
-			if err = gcp.DiskVolumeDetach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = gcp.DiskVolumeDetach(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i]); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -212,7 +211,9 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 		//Wait for chaos interval
 		log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-		common.WaitForDuration(experimentsDetails.ChaosInterval)
+		if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+			return err
+		}
 
 		for i := range targetDiskVolumeNamesList {
 
@@ -228,7 +229,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 			default:
 				//Attaching the disk volume to the instance
 				log.Info("[Chaos]: Attaching the disk volume to the instance")
-				if err = gcp.DiskVolumeAttach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = gcp.DiskVolumeAttach(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
@@ -249,7 +250,9 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // AbortWatcher will watching for the abort signal and revert the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+func abortWatcher(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, instanceNamesList []string, zone string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -276,7 +279,7 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 			//Attaching the disk volume from the instance
 			log.Infof("[Chaos]: Attaching %s disk volume to the instance", targetDiskVolumeNamesList[i])
 
-			err = gcp.DiskVolumeAttach(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i])
+			err = gcp.DiskVolumeAttach(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zone, experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i])
 			if err != nil {
 				log.Errorf("%s disk attachment failed when an abort signal is received, err: %v", targetDiskVolumeNamesList[i], err)
 			}
@@ -286,7 +289,8 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 	}
 
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
 
 // getDeviceNamesAndVMInstanceNames fetches the device name and attached VM instance name for each target disk
@@ -310,3 +314,10 @@ func getDeviceNamesAndVMInstanceNames(diskVolumeNamesList []string, computeServi
 
 	return nil
 }
+
+// getTargetDetails returns a copy of the experiment details, containing the device names of the given targets only
+func getTargetDetails(experimentsDetails *experimentTypes.ExperimentDetails, indices []int) *experimentTypes.ExperimentDetails {
+	details := *experimentsDetails
+	details.DeviceNamesList = common.SelectIndices(experimentsDetails.DeviceNamesList, indices)
+	return &details
+}
@@ -4,12 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
-	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
 	"github.com/litmuschaos/litmus-go/pkg/events"
@@ -37,18 +34,20 @@ func PrepareDiskVolumeLoss(ctx context.Context, computeService *compute.Service,
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//get the disk volume names list
@@ -65,30 +64,27 @@ func PrepareDiskVolumeLoss(ctx context.Context, computeService *compute.Service,
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 
 		// watching for the abort signal and revert the chaos
-		go abortWatcher(computeService, experimentsDetails, diskNamesList, diskZonesList, abort, chaosDetails)
+		go abortWatcher(ctx, computeService, experimentsDetails, diskNamesList, diskZonesList, abort, chaosDetails)
 
-		switch strings.ToLower(experimentsDetails.Sequence) {
-		case "serial":
-			if err = injectChaosInSerialMode(ctx, computeService, experimentsDetails, diskNamesList, diskZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in serial mode")
-			}
-		case "parallel":
-			if err = injectChaosInParallelMode(ctx, computeService, experimentsDetails, diskNamesList, diskZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-				return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-			}
-		default:
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+		if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, common.Indices(len(diskNamesList)), clients, chaosDetails, func(ctx context.Context, targets []int) error {
+			return injectChaosInSerialMode(ctx, computeService, getTargetDetails(experimentsDetails, targets), common.SelectIndices(diskNamesList, targets), common.SelectIndices(diskZonesList, targets), clients, resultDetails, eventsDetails, chaosDetails)
+		}, func(ctx context.Context, targets []int) error {
+			return injectChaosInParallelMode(ctx, computeService, getTargetDetails(experimentsDetails, targets), common.SelectIndices(diskNamesList, targets), common.SelectIndices(diskZonesList, targets), clients, resultDetails, eventsDetails, chaosDetails)
+		}); err != nil {
+			return err
 		}
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -113,7 +109,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Detaching the disk volume from the instance
 			log.Infof("[Chaos]: Detaching %s disk volume from the instance", targetDiskVolumeNamesList[i])
-			if err = gcp.DiskVolumeDetach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = gcp.DiskVolumeDetach(ctx, computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i]); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -135,7 +131,9 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 			//Wait for chaos duration
 			log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			//Getting the disk volume attachment status
 			diskState, err := gcp.GetDiskVolumeState(computeService, targetDiskVolumeNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.TargetDiskInstanceNamesList[i], diskZonesList[i])
@@ -149,7 +147,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 			default:
 				//Attaching the disk volume to the instance
 				log.Infof("[Chaos]: Attaching %s disk volume back to the instance", targetDiskVolumeNamesList[i])
-				if err = gcp.DiskVolumeAttach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = gcp.DiskVolumeAttach(ctx, computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
@@ -184,10 +182,13 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 		}
 
 		for i := range targetDiskVolumeNamesList {
+			if err := common.WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
 
 			//Detaching the disk volume from the instance
 			log.Infof("[Chaos]: Detaching %s disk volume from the instance", targetDiskVolumeNamesList[i])
-			if err = gcp.DiskVolumeDetach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i]); err != nil {
+			if err = gcp.DiskVolumeDetach(ctx, computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i]); err != nil {
 				return stacktrace.Propagate(err, "disk detachment failed")
 			}
 
@@ -212,7 +213,9 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 		//Wait for chaos interval
 		log.Infof("[Wait]: Waiting for the chaos interval of %vs", experimentsDetails.ChaosInterval)
-		common.WaitForDuration(experimentsDetails.ChaosInterval)
+		if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+			return err
+		}
 
 		for i := range targetDiskVolumeNamesList {
 
@@ -228,7 +231,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 			default:
 				//Attaching the disk volume to the instance
 				log.Infof("[Chaos]: Attaching %s disk volume to the instance", targetDiskVolumeNamesList[i])
-				if err = gcp.DiskVolumeAttach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
+				if err = gcp.DiskVolumeAttach(ctx, computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i]); err != nil {
 					return stacktrace.Propagate(err, "disk attachment failed")
 				}
 
@@ -246,7 +249,9 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // AbortWatcher will watching for the abort signal and revert the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+func abortWatcher(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList, diskZonesList []string, abort chan os.Signal, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -273,7 +278,7 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 			//Attaching the disk volume from the instance
 			log.Infof("[Chaos]: Attaching %s disk volume from the instance", targetDiskVolumeNamesList[i])
 
-			err = gcp.DiskVolumeAttach(computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i])
+			err = gcp.DiskVolumeAttach(ctx, computeService, experimentsDetails.TargetDiskInstanceNamesList[i], experimentsDetails.GCPProjectID, diskZonesList[i], experimentsDetails.DeviceNamesList[i], targetDiskVolumeNamesList[i])
 			if err != nil {
 				log.Errorf("%s disk attachment failed when an abort signal is received, err: %v", targetDiskVolumeNamesList[i], err)
 			}
@@ -283,7 +288,8 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 	}
 
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
 
 // getDeviceNamesList fetches the device names for the target disks
@@ -301,3 +307,11 @@ func getDeviceNamesList(computeService *compute.Service, experimentsDetails *exp
 
 	return nil
 }
+
+// getTargetDetails returns a copy of the experiment details, containing the device names of the given targets only
+func getTargetDetails(experimentsDetails *experimentTypes.ExperimentDetails, indices []int) *experimentTypes.ExperimentDetails {
+	details := *experimentsDetails
+	details.TargetDiskInstanceNamesList = common.SelectIndices(experimentsDetails.TargetDiskInstanceNamesList, indices)
+	details.DeviceNamesList = common.SelectIndices(experimentsDetails.DeviceNamesList, indices)
+	return &details
+}
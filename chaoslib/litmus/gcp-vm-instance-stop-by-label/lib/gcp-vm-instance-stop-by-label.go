@@ -2,14 +2,9 @@ package lib
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
-	"strings"
-	"syscall"
 	"time"
 
-	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	gcplib "github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
 	"github.com/litmuschaos/litmus-go/pkg/events"
@@ -33,43 +28,42 @@ func PrepareVMStopByLabel(ctx context.Context, computeService *compute.Service,
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
-	instanceNamesList := common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, experimentsDetails.TargetVMInstanceNameList)
+	instanceNamesList := common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, experimentsDetails.TargetVMInstanceNameList, chaosDetails)
 	log.Infof("[Chaos]:Number of Instance targeted: %v", len(instanceNamesList))
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(computeService, experimentsDetails, instanceNamesList, chaosDetails)
-
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err := injectChaosInSerialMode(ctx, computeService, experimentsDetails, instanceNamesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err := injectChaosInParallelMode(ctx, computeService, experimentsDetails, instanceNamesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	go abortWatcher(ctx, computeService, experimentsDetails, instanceNamesList, chaosDetails)
+
+	if err := common.InjectInSequence(ctx, experimentsDetails.Sequence, instanceNamesList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+		return injectChaosInSerialMode(ctx, computeService, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []string) error {
+		return injectChaosInParallelMode(ctx, computeService, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}); err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -83,7 +77,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -104,7 +98,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				//Stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+				if err := gcplib.VMInstanceStop(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
 					return stacktrace.Propagate(err, "VM instance failed to stop")
 				}
 
@@ -126,7 +120,9 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				// wait for the chaos interval
 				log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-				common.WaitForDuration(experimentsDetails.ChaosInterval)
+				if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+					return err
+				}
 
 				switch experimentsDetails.ManagedInstanceGroup {
 				case "enable":
@@ -141,7 +137,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 					// starting the VM instance
 					log.Infof("[Chaos]: Starting back %s VM instance", instanceNamesList[i])
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := gcplib.VMInstanceStart(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 
@@ -169,7 +165,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -187,10 +183,13 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			// power-off the instance
 			for i := range instanceNamesList {
+				if err := common.WaitForStaggeredStart(ctx); err != nil {
+					return err
+				}
 
 				// stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+				if err := gcplib.VMInstanceStop(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to stop")
 				}
 
@@ -215,7 +214,9 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			// wait for chaos interval
 			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			switch experimentsDetails.ManagedInstanceGroup {
 			case "enable":
@@ -237,7 +238,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				for i := range instanceNamesList {
 
 					log.Info("[Chaos]: Starting back the VM instance")
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
+					if err := gcplib.VMInstanceStart(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 				}
@@ -262,7 +263,9 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // abortWatcher watches for the abort signal and reverts the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -280,7 +283,7 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 			}
 
 			log.Info("[Abort]: Starting VM instance as abort signal received")
-			err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones)
+			err := gcplib.VMInstanceStart(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, experimentsDetails.Zones)
 			if err != nil {
 				log.Errorf("%s instance failed to start when an abort signal is received, err: %v", instanceNamesList[i], err)
 			}
@@ -289,5 +292,6 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 	}
 
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
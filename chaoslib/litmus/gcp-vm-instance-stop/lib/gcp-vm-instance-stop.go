@@ -2,14 +2,10 @@ package lib
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
-	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	gcplib "github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
 	"github.com/litmuschaos/litmus-go/pkg/events"
@@ -36,18 +32,20 @@ func PrepareVMStop(ctx context.Context, computeService *compute.Service, experim
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	// waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// get the instance name or list of instance names
@@ -56,25 +54,22 @@ func PrepareVMStop(ctx context.Context, computeService *compute.Service, experim
 	// get the zone name or list of corresponding zones for the instances
 	instanceZonesList := strings.Split(experimentsDetails.Zones, ",")
 
-	go abortWatcher(computeService, experimentsDetails, instanceNamesList, instanceZonesList, chaosDetails)
+	go abortWatcher(ctx, computeService, experimentsDetails, instanceNamesList, instanceZonesList, chaosDetails)
 
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, computeService, experimentsDetails, instanceNamesList, instanceZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, computeService, experimentsDetails, instanceNamesList, instanceZonesList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, common.Indices(len(instanceNamesList)), clients, chaosDetails, func(ctx context.Context, targets []int) error {
+		return injectChaosInSerialMode(ctx, computeService, experimentsDetails, common.SelectIndices(instanceNamesList, targets), common.SelectIndices(instanceZonesList, targets), clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []int) error {
+		return injectChaosInParallelMode(ctx, computeService, experimentsDetails, common.SelectIndices(instanceNamesList, targets), common.SelectIndices(instanceZonesList, targets), clients, resultDetails, eventsDetails, chaosDetails)
+	}); err != nil {
+		return err
 	}
 
 	// wait for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -88,7 +83,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -109,7 +104,7 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				//Stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+				if err := gcplib.VMInstanceStop(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to stop")
 				}
 
@@ -131,14 +126,16 @@ func injectChaosInSerialMode(ctx context.Context, computeService *compute.Servic
 
 				// wait for the chaos interval
 				log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-				common.WaitForDuration(experimentsDetails.ChaosInterval)
+				if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+					return err
+				}
 
 				switch experimentsDetails.ManagedInstanceGroup {
 				case "disable":
 
 					// starting the VM instance
 					log.Infof("[Chaos]: Starting back %s VM instance", instanceNamesList[i])
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := gcplib.VMInstanceStart(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 
@@ -175,7 +172,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -193,10 +190,13 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			// power-off the instance
 			for i := range instanceNamesList {
+				if err := common.WaitForStaggeredStart(ctx); err != nil {
+					return err
+				}
 
 				// stopping the VM instance
 				log.Infof("[Chaos]: Stopping %s VM instance", instanceNamesList[i])
-				if err := gcplib.VMInstanceStop(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+				if err := gcplib.VMInstanceStop(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
 					return stacktrace.Propagate(err, "vm instance failed to stop")
 				}
 
@@ -221,7 +221,9 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 
 			// wait for chaos interval
 			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			switch experimentsDetails.ManagedInstanceGroup {
 			case "disable":
@@ -229,7 +231,7 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 				// starting the VM instance
 				for i := range instanceNamesList {
 					log.Infof("[Chaos]: Starting back %s VM instance", instanceNamesList[i])
-					if err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
+					if err := gcplib.VMInstanceStart(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, instanceZonesList[i]); err != nil {
 						return stacktrace.Propagate(err, "vm instance failed to start")
 					}
 				}
@@ -267,7 +269,10 @@ func injectChaosInParallelMode(ctx context.Context, computeService *compute.Serv
 }
 
 // abortWatcher watches for the abort signal and reverts the chaos
-func abortWatcher(computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, zonesList []string, chaosDetails *types.ChaosDetails) {
+func abortWatcher(ctx context.Context, computeService *compute.Service, experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, zonesList []string, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
@@ -289,7 +294,7 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 				}
 
 				log.Infof("[Abort]: Starting %s VM instance as abort signal is received", instanceNamesList[i])
-				err := gcplib.VMInstanceStart(computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zonesList[i])
+				err := gcplib.VMInstanceStart(ctx, computeService, instanceNamesList[i], experimentsDetails.GCPProjectID, zonesList[i])
 				if err != nil {
 					log.Errorf("%s VM instance failed to start when an abort signal is received, err: %v", instanceNamesList[i], err)
 				}
@@ -300,5 +305,6 @@ func abortWatcher(computeService *compute.Service, experimentsDetails *experimen
 	}
 
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
@@ -7,17 +7,19 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"os"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/http-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -41,13 +43,13 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Fetching all the ENV passed for the helper pod
 	log.Info("[PreReq]: Getting the ENV variables")
@@ -55,7 +57,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// Initialise the chaos attributes
 	types.InitialiseChaosVariables(&chaosDetails)
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	// Initialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -63,7 +65,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	err := prepareK8sHttpChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	err := prepareK8sHttpChaos(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
 	if err != nil {
 		// update failstep inside chaosresult
 		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
@@ -74,7 +76,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 }
 
 // prepareK8sHttpChaos contains the preparation steps before chaos injection
-func prepareK8sHttpChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func prepareK8sHttpChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
 	if err != nil {
@@ -101,27 +103,41 @@ func prepareK8sHttpChaos(experimentsDetails *experimentTypes.ExperimentDetails,
 		if err != nil {
 			return stacktrace.Propagate(err, "could not get container pid")
 		}
+
+		// deriving the owners and node of the target, to mark them as under chaos
+		if td.References, err = events.GetPodReferences(ctx, clients, td.Name, td.Namespace); err != nil {
+			log.Warnf("unable to get the references of the target: {name: %s, namespace: %v}, err: %v", td.Name, td.Namespace, err)
+		}
 		targets = append(targets, td)
 	}
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(targets, resultDetails.Name, chaosDetails.ChaosNamespace, experimentsDetails)
+	go abortWatcher(ctx, targets, resultDetails.Name, experimentsDetails, chaosDetails, clients)
 
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(1)
+		common.Exit(1)
 	default:
 	}
 
 	for _, t := range targets {
+		targetCtx := log.ContextWithTarget(ctx, fmt.Sprintf("%s/%s", t.Namespace, t.Name))
+		// recording the revert details inside the journal before injecting the chaos
+		if err = journal.Record(getJournalEntry(t, experimentsDetails), chaosDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not record the journal entry")
+		}
 		// injecting http chaos inside target container
-		if err = injectChaos(experimentsDetails, t); err != nil {
+		injectStart := time.Now()
+		if err = injectChaos(targetCtx, experimentsDetails, t); err != nil {
 			return stacktrace.Propagate(err, "could not inject chaos")
 		}
-		log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
-			if revertErr := revertChaos(experimentsDetails, t); revertErr != nil {
+		telemetry.RecordInjectionLatency(targetCtx, "pod", injectStart)
+		events.MarkUnderChaos(targetCtx, clients, chaosDetails, t.References)
+		records.Injected(records.FromJournalEntry(getJournalEntry(t, experimentsDetails)), resultDetails.Name, chaosDetails, clients)
+		log.InfofWithContext(targetCtx, "successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name, clients); err != nil {
+			if revertErr := revertChaos(targetCtx, experimentsDetails, t); revertErr != nil {
 				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
 			}
 			return stacktrace.Propagate(err, "could not annotate chaosresult")
@@ -137,19 +153,36 @@ func prepareK8sHttpChaos(experimentsDetails *experimentTypes.ExperimentDetails,
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDuration(experimentsDetails.ChaosDuration); err != nil {
+		return err
+	}
+
+	// waiting for the approval before reverting the chaos, if the revert gate is enabled
+	// the chaosresult is owned by the experiment pod, so its phase isn't updated by the helper
+	if err := approval.WaitForApproval(ctx, types.ApprovalGateRevert, clients, nil, eventsDetails, chaosDetails); err != nil {
+		log.Errorf("Approval to revert the chaos failed, reverting the chaos, err: %v", err)
+	}
 
 	log.Info("[Chaos]: chaos duration is over, reverting chaos")
 
 	var errList []string
 	for _, t := range targets {
+		targetCtx := log.ContextWithTarget(ctx, fmt.Sprintf("%s/%s", t.Namespace, t.Name))
 		// cleaning the ip rules process after chaos injection
-		err := revertChaos(experimentsDetails, t)
+		revertStart := time.Now()
+		err := revertChaos(targetCtx, experimentsDetails, t)
+		records.Reverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), resultDetails.Name, err, chaosDetails, clients)
 		if err != nil {
+			log.ErrorfWithContext(targetCtx, "unable to revert the http chaos, err: %v", err)
 			errList = append(errList, err.Error())
 			continue
 		}
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+		telemetry.RecordRevertLatency(targetCtx, "pod", revertStart)
+		events.UnmarkUnderChaos(targetCtx, clients, chaosDetails, t.References)
+		if err = journal.MarkReverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), chaosDetails, clients); err != nil {
+			errList = append(errList, err.Error())
+		}
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 			errList = append(errList, err.Error())
 		}
 	}
@@ -161,16 +194,19 @@ func prepareK8sHttpChaos(experimentsDetails *experimentTypes.ExperimentDetails,
 }
 
 // injectChaos inject the http chaos in target container and add ruleset to the iptables to redirect the ports
-func injectChaos(experimentDetails *experimentTypes.ExperimentDetails, t targetDetails) error {
-	if err := startProxy(experimentDetails, t.Pid); err != nil {
-		killErr := killProxy(t.Pid, t.Source)
+func injectChaos(ctx context.Context, experimentDetails *experimentTypes.ExperimentDetails, t targetDetails) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "InjectPodHTTPFaultOnTarget", t.attributes()...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if err := startProxy(ctx, experimentDetails, t.Pid); err != nil {
+		killErr := killProxy(ctx, t.Pid, t.Source)
 		if killErr != nil {
 			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(killErr).Error())}
 		}
 		return stacktrace.Propagate(err, "could not start proxy server")
 	}
-	if err := addIPRuleSet(experimentDetails, t.Pid); err != nil {
-		killErr := killProxy(t.Pid, t.Source)
+	if err := addIPRuleSet(ctx, experimentDetails, t.Pid); err != nil {
+		killErr := killProxy(ctx, t.Pid, t.Source)
 		if killErr != nil {
 			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(killErr).Error())}
 		}
@@ -180,15 +216,17 @@ func injectChaos(experimentDetails *experimentTypes.ExperimentDetails, t targetD
 }
 
 // revertChaos revert the http chaos in target container
-func revertChaos(experimentDetails *experimentTypes.ExperimentDetails, t targetDetails) error {
+func revertChaos(ctx context.Context, experimentDetails *experimentTypes.ExperimentDetails, t targetDetails) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RevertPodHTTPFaultOnTarget", t.attributes()...)
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	var errList []string
 
-	if err := removeIPRuleSet(experimentDetails, t.Pid); err != nil {
+	if err := removeIPRuleSet(ctx, experimentDetails, t.Pid); err != nil {
 		errList = append(errList, err.Error())
 	}
 
-	if err := killProxy(t.Pid, t.Source); err != nil {
+	if err := killProxy(ctx, t.Pid, t.Source); err != nil {
 		errList = append(errList, err.Error())
 	}
 	if len(errList) != 0 {
@@ -201,7 +239,7 @@ func revertChaos(experimentDetails *experimentTypes.ExperimentDetails, t targetD
 // startProxy starts the proxy process inside the target container
 // it is using nsenter command to enter into network namespace of target container
 // and execute the proxy related command inside it.
-func startProxy(experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
+func startProxy(ctx context.Context, experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
 
 	toxics := os.Getenv("TOXIC_COMMAND")
 
@@ -216,7 +254,7 @@ func startProxy(experimentDetails *experimentTypes.ExperimentDetails, pid int) e
 
 	log.Infof("[Chaos]: Starting proxy server")
 
-	if err := common.RunBashCommand(chaosCommand, "failed to start proxy server", experimentDetails.ChaosPodName); err != nil {
+	if err := common.RunBashCommandWithContext(ctx, chaosCommand, "failed to start proxy server", experimentDetails.ChaosPodName); err != nil {
 		return err
 	}
 
@@ -229,11 +267,11 @@ const NoProxyToKill = "you need to specify whom to kill"
 // killProxy kills the proxy process inside the target container
 // it is using nsenter command to enter into network namespace of target container
 // and execute the proxy related command inside it.
-func killProxy(pid int, source string) error {
+func killProxy(ctx context.Context, pid int, source string) error {
 	stopProxyServerCommand := fmt.Sprintf("sudo nsenter -t %d -n sudo kill -9 $(ps aux | grep [t]oxiproxy | awk 'FNR==2{print $2}')", pid)
 	log.Infof("[Chaos]: Stopping proxy server")
 
-	if err := common.RunBashCommand(stopProxyServerCommand, "failed to stop proxy server", source); err != nil {
+	if err := common.RunBashCommandWithContext(ctx, stopProxyServerCommand, "failed to stop proxy server", source); err != nil {
 		return err
 	}
 
@@ -244,14 +282,14 @@ func killProxy(pid int, source string) error {
 // addIPRuleSet adds the ip rule set to iptables in target container
 // it is using nsenter command to enter into network namespace of target container
 // and execute the iptables related command inside it.
-func addIPRuleSet(experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
+func addIPRuleSet(ctx context.Context, experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
 	// it adds the proxy port REDIRECT iprule in the beginning of the PREROUTING table
 	// so that it always matches all the incoming packets for the matching target port filters and
 	// if matches then it redirect the request to the proxy port
 	addIPRuleSetCommand := fmt.Sprintf("(sudo nsenter -t %d -n iptables -t nat -I PREROUTING -i %v -p tcp --dport %d -j REDIRECT --to-port %d)", pid, experimentDetails.NetworkInterface, experimentDetails.TargetServicePort, experimentDetails.ProxyPort)
 	log.Infof("[Chaos]: Adding IPtables ruleset")
 
-	if err := common.RunBashCommand(addIPRuleSetCommand, "failed to add ip rules", experimentDetails.ChaosPodName); err != nil {
+	if err := common.RunBashCommandWithContext(ctx, addIPRuleSetCommand, "failed to add ip rules", experimentDetails.ChaosPodName); err != nil {
 		return err
 	}
 
@@ -264,11 +302,11 @@ const NoIPRulesetToRemove = "No chain/target/match by that name"
 // removeIPRuleSet removes the ip rule set from iptables in target container
 // it is using nsenter command to enter into network namespace of target container
 // and execute the iptables related command inside it.
-func removeIPRuleSet(experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
+func removeIPRuleSet(ctx context.Context, experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
 	removeIPRuleSetCommand := fmt.Sprintf("sudo nsenter -t %d -n iptables -t nat -D PREROUTING -i %v -p tcp --dport %d -j REDIRECT --to-port %d", pid, experimentDetails.NetworkInterface, experimentDetails.TargetServicePort, experimentDetails.ProxyPort)
 	log.Infof("[Chaos]: Removing IPtables ruleset")
 
-	if err := common.RunBashCommand(removeIPRuleSetCommand, "failed to remove ip rules", experimentDetails.ChaosPodName); err != nil {
+	if err := common.RunBashCommandWithContext(ctx, removeIPRuleSetCommand, "failed to remove ip rules", experimentDetails.ChaosPodName); err != nil {
 		return err
 	}
 
@@ -293,8 +331,23 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.Toxicity, _ = strconv.Atoi(types.Getenv("TOXICITY", "100"))
 }
 
+// getJournalEntry returns the journal entry of the target, containing the revert details
+func getJournalEntry(t targetDetails, experimentDetails *experimentTypes.ExperimentDetails) *journal.Entry {
+	return journal.NewEntry(experimentDetails.ExperimentName, "pod", t.Name, t.Namespace).
+		WithContainer(t.TargetContainer, t.ContainerId, t.Pid).
+		WithParam("toxics", os.Getenv("TOXIC_COMMAND")).
+		WithParam("targetServicePort", strconv.Itoa(experimentDetails.TargetServicePort)).
+		WithParam("proxyPort", strconv.Itoa(experimentDetails.ProxyPort)).
+		WithRevertCommands(
+			fmt.Sprintf("sudo nsenter -t %d -n iptables -t nat -D PREROUTING -i %v -p tcp --dport %d -j REDIRECT --to-port %d", t.Pid, experimentDetails.NetworkInterface, experimentDetails.TargetServicePort, experimentDetails.ProxyPort),
+			fmt.Sprintf("sudo nsenter -t %d -n sudo kill -9 $(ps aux | grep [t]oxiproxy | awk 'FNR==2{print $2}')", t.Pid),
+		)
+}
+
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targets []targetDetails, resultName, chaosNS string, experimentDetails *experimentTypes.ExperimentDetails) {
+func abortWatcher(ctx context.Context, targets []targetDetails, resultName string, experimentDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 	log.Info("[Abort]: Killing process started because of terminated signal received")
@@ -303,14 +356,19 @@ func abortWatcher(targets []targetDetails, resultName, chaosNS string, experimen
 	retry := 3
 	for retry > 0 {
 		for _, t := range targets {
-			if err = revertChaos(experimentDetails, t); err != nil {
+			if err = revertChaos(ctx, experimentDetails, t); err != nil {
 				if strings.Contains(err.Error(), NoIPRulesetToRemove) && strings.Contains(err.Error(), NoProxyToKill) {
 					continue
 				}
 				log.Errorf("unable to revert for %v pod, err :%v", t.Name, err)
 				continue
 			}
-			if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", t.Name); err != nil {
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+			records.Reverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), resultName, nil, chaosDetails, clients)
+			if err = journal.MarkReverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), chaosDetails, clients); err != nil {
+				log.Errorf("unable to update the journal for %v pod, err :%v", t.Name, err)
+			}
+			if err = result.AnnotateChaosResult(resultName, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				log.Errorf("unable to annotate the chaosresult for %v pod, err :%v", t.Name, err)
 			}
 		}
@@ -319,7 +377,8 @@ func abortWatcher(targets []targetDetails, resultName, chaosNS string, experimen
 	}
 
 	log.Info("Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
 
 type targetDetails struct {
@@ -329,4 +388,14 @@ type targetDetails struct {
 	ContainerId     string
 	Pid             int
 	Source          string
+	References      []events.TargetReference
+}
+
+// attributes returns the span attributes identifying the target
+func (t targetDetails) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("k8s.pod.name", t.Name),
+		attribute.String("k8s.namespace.name", t.Namespace),
+		attribute.String("k8s.container.name", t.TargetContainer),
+	}
 }
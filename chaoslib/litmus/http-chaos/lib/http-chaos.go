@@ -35,7 +35,7 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "provide one of the appLabel or TARGET_PODS"}
 	}
 	//set up the tunables if provided in range
-	SetChaosTunables(experimentsDetails)
+	SetChaosTunables(experimentsDetails, chaosDetails)
 
 	targetPodList, err := common.GetTargetPods(experimentsDetails.NodeLabel, experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
 	if err != nil {
@@ -45,7 +45,9 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// Getting the serviceAccountName, need permission inside helper pod to create the events
@@ -64,20 +66,11 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
 
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, args, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, args, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
-	}
-
-	return nil
+	return common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, args, clients, chaosDetails, resultDetails, eventsDetails)
+	}, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, args, clients, chaosDetails, resultDetails, eventsDetails)
+	})
 }
 
 // injectChaosInSerialMode inject the http chaos in all target application serially (one by one)
@@ -115,7 +108,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 		log.Info("[Status]: Checking the status of the helper pods")
-		if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return stacktrace.Propagate(err, "could not check helper status")
 		}
@@ -123,7 +116,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		// Wait till the completion of the helper pod
 		// set an upper limit for the waiting time
 		log.Info("[Wait]: waiting till the completion of the helper pod")
-		podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+		podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout+chaosDetails.Approval.TimeoutOf(types.ApprovalGateRevert), common.GetContainerNames(chaosDetails)...)
 		if err != nil || podStatus == "Failed" {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -155,6 +148,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	targets := common.FilterPodsForNodes(targetPodList, experimentsDetails.TargetContainer)
 
 	for node, tar := range targets {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
+		}
 		var targetsPerNode []string
 		for _, k := range tar.Target {
 			targetsPerNode = append(targetsPerNode, fmt.Sprintf("%s:%s:%s", k.Name, k.Namespace, k.TargetContainer))
@@ -169,7 +165,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 	log.Info("[Status]: Checking the status of the helper pods")
-	if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+	if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return stacktrace.Propagate(err, "could not check helper status")
 	}
@@ -177,7 +173,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	// Wait till the completion of the helper pod
 	// set an upper limit for the waiting time
 	log.Info("[Wait]: waiting till the completion of the helper pod")
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout+chaosDetails.Approval.TimeoutOf(types.ApprovalGateRevert), common.GetContainerNames(chaosDetails)...)
 	if err != nil || podStatus == "Failed" {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -238,7 +234,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 						"./helpers -name http-chaos",
 					},
 					Resources: chaosDetails.Resources,
-					Env:       getPodEnv(ctx, experimentsDetails, targets, args),
+					Env:       getPodEnv(ctx, experimentsDetails, chaosDetails, targets, args),
 					VolumeMounts: []apiv1.VolumeMount{
 						{
 							Name:      "cri-socket",
@@ -264,7 +260,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(context.Background(), helperPod, v1.CreateOptions{})
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(ctx, helperPod, v1.CreateOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
@@ -272,7 +268,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 }
 
 // getPodEnv derive all the env required for the helper pod
-func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targets, args string) []apiv1.EnvVar {
+func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, targets, args string) []apiv1.EnvVar {
 
 	var envDetails common.ENVDetails
 	envDetails.SetEnv("TARGETS", targets).
@@ -289,6 +285,10 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 		SetEnv("PROXY_PORT", strconv.Itoa(experimentsDetails.ProxyPort)).
 		SetEnv("TOXICITY", strconv.Itoa(experimentsDetails.Toxicity)).
 		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPEndpoint)).
+		SetEnv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPLogsEndpoint)).
+		SetEnv("APPROVAL_GATES", strings.Join(chaosDetails.Approval.Gates, ",")).
+		SetEnv("APPROVAL_TIMEOUT", strconv.Itoa(chaosDetails.Approval.Timeout)).
+		SetEnv("APPROVAL_CONFIGMAP", chaosDetails.Approval.ConfigMap).
 		SetEnv("TRACE_PARENT", telemetry.GetMarshalledSpanFromContext(ctx)).
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
@@ -297,7 +297,7 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 
 // SetChaosTunables will set up a random value within a given range of values
 // If the value is not provided in range it'll set up the initial provided value.
-func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
-	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc)
-	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
+func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc, chaosDetails)
+	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence, chaosDetails)
 }
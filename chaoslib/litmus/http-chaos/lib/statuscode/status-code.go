@@ -4,10 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"math/rand"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
@@ -61,15 +59,14 @@ func PodHttpStatusCodeChaos(ctx context.Context, experimentsDetails *experimentT
 // GetStatusCode performs two functions:
 // 1. It checks if the status code is provided or not. If it's not then it selects a random status code from supported list
 // 2. It checks if the provided status code is valid or not.
-func GetStatusCode(statusCode string) (string, error) {
+func GetStatusCode(statusCode string, chaosDetails *types.ChaosDetails) (string, error) {
 
 	if statusCode == "" {
 		log.Info("[Info]: No status code provided. Selecting a status code randomly from supported status codes")
-		return acceptedStatusCodes[rand.Intn(len(acceptedStatusCodes))], nil
+		return acceptedStatusCodes[chaosDetails.GetRandom().Intn(len(acceptedStatusCodes))], nil
 	}
 
 	statusCodeList := strings.Split(statusCode, ",")
-	rand.Seed(time.Now().Unix())
 	if len(statusCodeList) == 1 {
 		if checkStatusCode(statusCodeList[0], acceptedStatusCodes) {
 			return statusCodeList[0], nil
@@ -79,7 +76,7 @@ func GetStatusCode(statusCode string) (string, error) {
 		if len(acceptedCodes) == 0 {
 			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid status code: %s", statusCode)}
 		}
-		return acceptedCodes[rand.Intn(len(acceptedCodes))], nil
+		return acceptedCodes[chaosDetails.GetRandom().Intn(len(acceptedCodes))], nil
 	}
 	return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("status code '%s' is not supported. Supported status codes are: %v", statusCode, acceptedStatusCodes)}
 }
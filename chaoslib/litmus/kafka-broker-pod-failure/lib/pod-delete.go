@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -22,6 +21,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,34 +33,27 @@ func PreparePodDelete(ctx context.Context, experimentsDetails *experimentTypes.E
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.ChaoslibDetail.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.ChaoslibDetail.RampTime)
-		common.WaitForDuration(experimentsDetails.ChaoslibDetail.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.ChaoslibDetail.RampTime); err != nil {
+			return err
+		}
 	}
 
-	switch strings.ToLower(experimentsDetails.ChaoslibDetail.Sequence) {
-	case "serial":
-		if err := injectChaosInSerialMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err := injectChaosInParallelMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.ChaoslibDetail.Sequence)}
+	if err := injectChaos(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.ChaoslibDetail.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.ChaoslibDetail.RampTime)
-		common.WaitForDuration(experimentsDetails.ChaoslibDetail.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.ChaoslibDetail.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// injectChaosInSerialMode delete the kafka broker pods in serial mode(one by one)
-func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
-	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectKafkaPodDeleteFaultInSerialMode")
-	defer span.End()
+// injectChaos deletes the kafka broker pods for the chaos duration, as per the sequence
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
 		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
@@ -68,7 +61,6 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		}
 	}
 
-	GracePeriod := int64(0)
 	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 	ChaosStartTimeStamp := time.Now()
 	duration := int(time.Since(ChaosStartTimeStamp).Seconds())
@@ -79,18 +71,17 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		if experimentsDetails.KafkaBroker == "" && chaosDetails.AppDetail == nil {
 			return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "please provide one of the appLabel or KAFKA_BROKER"}
 		}
-
 		podsAffectedPerc, _ := strconv.Atoi(experimentsDetails.ChaoslibDetail.PodsAffectedPerc)
 		targetPodList, err := common.GetPodList(experimentsDetails.KafkaBroker, podsAffectedPerc, clients, chaosDetails)
 		if err != nil {
-			return err
+			return stacktrace.Propagate(err, "could not get target pods")
 		}
 
 		// deriving the parent name of the target resources
 		for _, pod := range targetPodList.Items {
-			kind, parentName, err := workloads.GetPodOwnerTypeAndName(&pod, clients.DynamicClient)
+			kind, parentName, err := workloads.GetPodOwnerTypeAndName(&pod, clients)
 			if err != nil {
-				return err
+				return stacktrace.Propagate(err, "could not get pod owner name and kind")
 			}
 			common.SetParentName(parentName, kind, pod.Namespace, chaosDetails)
 		}
@@ -104,47 +95,12 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 		}
 
-		//Deleting the application pod
-		for _, pod := range targetPodList.Items {
-
-			log.InfoWithValues("[Info]: Killing the following pods", logrus.Fields{
-				"PodName": pod.Name})
-
-			if experimentsDetails.ChaoslibDetail.Force {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{GracePeriodSeconds: &GracePeriod})
-			} else {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{})
-			}
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to delete the target pod: %s", err.Error())}
-			}
-
-			switch chaosDetails.Randomness {
-			case true:
-				if err := common.RandomInterval(experimentsDetails.ChaoslibDetail.ChaosInterval); err != nil {
-					return stacktrace.Propagate(err, "could not get random chaos interval")
-				}
-			default:
-				//Waiting for the chaos interval after chaos injection
-				if experimentsDetails.ChaoslibDetail.ChaosInterval != "" {
-					log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaoslibDetail.ChaosInterval)
-					waitTime, _ := strconv.Atoi(experimentsDetails.ChaoslibDetail.ChaosInterval)
-					common.WaitForDuration(waitTime)
-				}
-			}
-
-			//Verify the status of pod after the chaos injection
-			log.Info("[Status]: Verification for the recreation of application pod")
-			for _, parent := range chaosDetails.ParentsResources {
-				target := types.AppDetails{
-					Names:     []string{parent.Name},
-					Kind:      parent.Kind,
-					Namespace: parent.Namespace,
-				}
-				if err = status.CheckUnTerminatedPodStatusesByWorkloadName(target, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
-					return stacktrace.Propagate(err, "could not check pod statuses by workload names")
-				}
-			}
+		if err := common.InjectInSequence(ctx, experimentsDetails.ChaoslibDetail.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, pods []corev1.Pod) error {
+			return injectChaosInSerialMode(ctx, experimentsDetails, pods, clients, chaosDetails)
+		}, func(ctx context.Context, pods []corev1.Pod) error {
+			return injectChaosInParallelMode(ctx, experimentsDetails, pods, clients, chaosDetails)
+		}); err != nil {
+			return err
 		}
 		duration = int(time.Since(ChaosStartTimeStamp).Seconds())
 	}
@@ -153,99 +109,109 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	return nil
 }
 
-// injectChaosInParallelMode delete the kafka broker pods in parallel mode (all at once)
-func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
-	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectKafkaPodDeleteFaultInParallelMode")
+// injectChaosInSerialMode delete the kafka broker pods in serial mode(one by one)
+func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, pods []corev1.Pod, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectKafkaPodDeleteFaultInSerialMode")
 	defer span.End()
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+	defer unmarkPods(ctx, clients, chaosDetails, pods)
+
+	//Deleting the application pod
+	for _, pod := range pods {
+		if err := deletePod(ctx, experimentsDetails, clients, chaosDetails, pod); err != nil {
 			return err
 		}
-	}
-
-	GracePeriod := int64(0)
-	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
-	ChaosStartTimeStamp := time.Now()
-	duration := int(time.Since(ChaosStartTimeStamp).Seconds())
-
-	for duration < experimentsDetails.ChaoslibDetail.ChaosDuration {
-		// Get the target pod details for the chaos execution
-		// if the target pod is not defined it will derive the random target pod list using pod affected percentage
-		if experimentsDetails.KafkaBroker == "" && chaosDetails.AppDetail == nil {
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "please provide one of the appLabel or KAFKA_BROKER"}
+		if err := waitForChaosInterval(experimentsDetails, chaosDetails); err != nil {
+			return err
 		}
-		podsAffectedPerc, _ := strconv.Atoi(experimentsDetails.ChaoslibDetail.PodsAffectedPerc)
-		targetPodList, err := common.GetPodList(experimentsDetails.KafkaBroker, podsAffectedPerc, clients, chaosDetails)
-		if err != nil {
-			return stacktrace.Propagate(err, "could not get target pods")
+		if err := verifyPodRecreation(ctx, experimentsDetails, clients, chaosDetails); err != nil {
+			return err
 		}
+		events.UnmarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
+	}
+	return nil
+}
 
-		// deriving the parent name of the target resources
-		for _, pod := range targetPodList.Items {
-			kind, parentName, err := workloads.GetPodOwnerTypeAndName(&pod, clients.DynamicClient)
-			if err != nil {
-				return stacktrace.Propagate(err, "could not get pod owner name and kind")
-			}
-			common.SetParentName(parentName, kind, pod.Namespace, chaosDetails)
+// injectChaosInParallelMode delete the kafka broker pods in parallel mode (all at once)
+func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, pods []corev1.Pod, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectKafkaPodDeleteFaultInParallelMode")
+	defer span.End()
+	defer unmarkPods(ctx, clients, chaosDetails, pods)
+
+	//Deleting the application pod
+	for _, pod := range pods {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
 		}
-		for _, target := range chaosDetails.ParentsResources {
-			common.SetTargets(target.Name, "targeted", target.Kind, chaosDetails)
+		if err := deletePod(ctx, experimentsDetails, clients, chaosDetails, pod); err != nil {
+			return err
 		}
+	}
 
-		if experimentsDetails.ChaoslibDetail.EngineName != "" {
-			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
-			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
-		}
+	if err := waitForChaosInterval(experimentsDetails, chaosDetails); err != nil {
+		return err
+	}
+	return verifyPodRecreation(ctx, experimentsDetails, clients, chaosDetails)
+}
 
-		//Deleting the application pod
-		for _, pod := range targetPodList.Items {
+// deletePod deletes the kafka broker pod, honouring the force option
+// the pod, its owners and its node are marked as under chaos, till the pod is recreated
+func deletePod(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, pod corev1.Pod) error {
+	log.InfoWithValues("[Info]: Killing the following pods", logrus.Fields{
+		"PodName": pod.Name})
+
+	events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
+	var err error
+	if experimentsDetails.ChaoslibDetail.Force {
+		GracePeriod := int64(0)
+		err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, v1.DeleteOptions{GracePeriodSeconds: &GracePeriod})
+	} else {
+		err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, v1.DeleteOptions{})
+	}
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to delete the target pod: %s", err.Error())}
+	}
+	return nil
+}
 
-			log.InfoWithValues("[Info]: Killing the following pods", logrus.Fields{
-				"PodName": pod.Name})
+// unmarkPods removes the under chaos marks of the deleted pods
+func unmarkPods(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, pods []corev1.Pod) {
+	for _, pod := range pods {
+		events.UnmarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
+	}
+}
 
-			if experimentsDetails.ChaoslibDetail.Force {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{GracePeriodSeconds: &GracePeriod})
-			} else {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{})
-			}
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to delete the target pod: %s", err.Error())}
-			}
+// waitForChaosInterval waits for the chaos interval after the chaos injection
+func waitForChaosInterval(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	switch chaosDetails.Randomness {
+	case true:
+		if err := common.RandomInterval(experimentsDetails.ChaoslibDetail.ChaosInterval, chaosDetails); err != nil {
+			return stacktrace.Propagate(err, "could not get random chaos interval")
 		}
-
-		switch chaosDetails.Randomness {
-		case true:
-			if err := common.RandomInterval(experimentsDetails.ChaoslibDetail.ChaosInterval); err != nil {
-				return stacktrace.Propagate(err, "could not get random chaos interval")
-			}
-		default:
-			//Waiting for the chaos interval after chaos injection
-			if experimentsDetails.ChaoslibDetail.ChaosInterval != "" {
-				log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaoslibDetail.ChaosInterval)
-				waitTime, _ := strconv.Atoi(experimentsDetails.ChaoslibDetail.ChaosInterval)
-				common.WaitForDuration(waitTime)
+	default:
+		//Waiting for the chaos interval after chaos injection
+		if experimentsDetails.ChaoslibDetail.ChaosInterval != "" {
+			log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaoslibDetail.ChaosInterval)
+			waitTime, _ := strconv.Atoi(experimentsDetails.ChaoslibDetail.ChaosInterval)
+			if err := common.WaitForDuration(waitTime); err != nil {
+				return err
 			}
 		}
+	}
+	return nil
+}
 
-		//Verify the status of pod after the chaos injection
-		log.Info("[Status]: Verification for the recreation of application pod")
-		for _, parent := range chaosDetails.ParentsResources {
-			target := types.AppDetails{
-				Names:     []string{parent.Name},
-				Kind:      parent.Kind,
-				Namespace: parent.Namespace,
-			}
-			if err = status.CheckUnTerminatedPodStatusesByWorkloadName(target, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
-				return stacktrace.Propagate(err, "could not check pod statuses by workload names")
-			}
+// verifyPodRecreation verifies that the pods of the parent workloads are recreated after the chaos injection
+func verifyPodRecreation(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	log.Info("[Status]: Verification for the recreation of application pod")
+	for _, parent := range chaosDetails.ParentsResources {
+		target := types.AppDetails{
+			Names:     []string{parent.Name},
+			Kind:      parent.Kind,
+			Namespace: parent.Namespace,
+		}
+		if err := status.CheckUnTerminatedPodStatusesByWorkloadName(ctx, target, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
+			return stacktrace.Propagate(err, "could not check pod statuses by workload names")
 		}
-
-		duration = int(time.Since(ChaosStartTimeStamp).Seconds())
 	}
-
-	log.Infof("[Completion]: %v chaos is done", experimentsDetails.ExperimentName)
-
 	return nil
 }
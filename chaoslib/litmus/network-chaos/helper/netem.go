@@ -5,20 +5,22 @@ import (
 	"fmt"
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"os"
 	"os/exec"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -48,13 +50,13 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Fetching all the ENV passed for the helper pod
 	log.Info("[PreReq]: Getting the ENV variables")
@@ -62,7 +64,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// Initialise the chaos attributes
 	types.InitialiseChaosVariables(&chaosDetails)
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	// Initialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -70,7 +72,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	err := preparePodNetworkChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	err := preparePodNetworkChaos(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
 	if err != nil {
 		// update failstep inside chaosresult
 		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
@@ -82,7 +84,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 }
 
 // preparePodNetworkChaos contains the prepration steps before chaos injection
-func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func preparePodNetworkChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	targetEnv := os.Getenv("TARGETS")
 	if targetEnv == "" {
@@ -115,27 +117,41 @@ func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetail
 			return stacktrace.Propagate(err, "could not get container pid")
 		}
 
+		// deriving the owners and node of the target, to mark them as under chaos
+		if td.References, err = events.GetPodReferences(ctx, clients, td.Name, td.Namespace); err != nil {
+			log.Warnf("unable to get the references of the target: {name: %s, namespace: %v}, err: %v", td.Name, td.Namespace, err)
+		}
+
 		targets = append(targets, td)
 	}
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(targets, experimentsDetails.NetworkInterface, resultDetails.Name, chaosDetails.ChaosNamespace)
+	go abortWatcher(ctx, targets, experimentsDetails.NetworkInterface, resultDetails.Name, chaosDetails, clients)
 
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(1)
+		common.Exit(1)
 	default:
 	}
 
 	for _, t := range targets {
+		targetCtx := log.ContextWithTarget(ctx, fmt.Sprintf("%s/%s", t.Namespace, t.Name))
+		// recording the revert details inside the journal before injecting the chaos
+		if err = journal.Record(getJournalEntry(t, experimentsDetails), chaosDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not record the journal entry")
+		}
 		// injecting network chaos inside target container
-		if err = injectChaos(experimentsDetails.NetworkInterface, t); err != nil {
+		injectStart := time.Now()
+		if err = injectChaos(targetCtx, experimentsDetails.NetworkInterface, t); err != nil {
 			return stacktrace.Propagate(err, "could not inject chaos")
 		}
-		log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
-			if _, revertErr := killnetem(t, experimentsDetails.NetworkInterface); revertErr != nil {
+		telemetry.RecordInjectionLatency(targetCtx, "pod", injectStart)
+		events.MarkUnderChaos(targetCtx, clients, chaosDetails, t.References)
+		records.Injected(records.FromJournalEntry(getJournalEntry(t, experimentsDetails)), resultDetails.Name, chaosDetails, clients)
+		log.InfofWithContext(targetCtx, "successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name, clients); err != nil {
+			if _, revertErr := killnetem(targetCtx, t, experimentsDetails.NetworkInterface); revertErr != nil {
 				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
 			}
 			return stacktrace.Propagate(err, "could not annotate chaosresult")
@@ -150,20 +166,39 @@ func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetail
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDuration(experimentsDetails.ChaosDuration); err != nil {
+		return err
+	}
+
+	// waiting for the approval before reverting the chaos, if the revert gate is enabled
+	// the chaosresult is owned by the experiment pod, so its phase isn't updated by the helper
+	if err := approval.WaitForApproval(ctx, types.ApprovalGateRevert, clients, nil, eventsDetails, chaosDetails); err != nil {
+		log.Errorf("Approval to revert the chaos failed, reverting the chaos, err: %v", err)
+	}
 
 	log.Info("[Chaos]: duration is over, reverting chaos")
 
 	var errList []string
 	for _, t := range targets {
+		targetCtx := log.ContextWithTarget(ctx, fmt.Sprintf("%s/%s", t.Namespace, t.Name))
 		// cleaning the netem process after chaos injection
-		killed, err := killnetem(t, experimentsDetails.NetworkInterface)
+		revertStart := time.Now()
+		killed, err := killnetem(targetCtx, t, experimentsDetails.NetworkInterface)
+		records.Reverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), resultDetails.Name, err, chaosDetails, clients)
 		if !killed && err != nil {
+			log.ErrorfWithContext(targetCtx, "unable to revert the network chaos, err: %v", err)
 			errList = append(errList, err.Error())
 			continue
 		}
+		if killed {
+			telemetry.RecordRevertLatency(targetCtx, "pod", revertStart)
+			events.UnmarkUnderChaos(targetCtx, clients, chaosDetails, t.References)
+			if err := journal.MarkReverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), chaosDetails, clients); err != nil {
+				errList = append(errList, err.Error())
+			}
+		}
 		if killed && err == nil {
-			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				errList = append(errList, err.Error())
 			}
 		}
@@ -178,14 +213,18 @@ func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetail
 // injectChaos inject the network chaos in target container
 // it is using nsenter command to enter into network namespace of target container
 // and execute the netem command inside it.
-func injectChaos(netInterface string, target targetDetails) error {
+func injectChaos(ctx context.Context, netInterface string, target targetDetails) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "InjectPodNetworkFaultOnTarget", target.attributes()...)
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	netemCommands := os.Getenv("NETEM_COMMAND")
+	// the qdisc is netem by default, tbf is used to limit the rate
+	qdisc := types.Getenv("QDISC", "netem")
 
 	if len(target.DestinationIps) == 0 && len(sPorts) == 0 && len(dPorts) == 0 && len(whitelistDPorts) == 0 && len(whitelistSPorts) == 0 {
-		tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc replace dev %s root netem %v", target.Pid, netInterface, netemCommands)
+		tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc replace dev %s root %s %v", target.Pid, netInterface, qdisc, netemCommands)
 		log.Info(tc)
-		if err := common.RunBashCommand(tc, "failed to create tc rules", target.Source); err != nil {
+		if err := common.RunBashCommandWithContext(ctx, tc, "failed to create tc rules", target.Source); err != nil {
 			return err
 		}
 	} else {
@@ -194,15 +233,15 @@ func injectChaos(netInterface string, target targetDetails) error {
 		// This instantly creates classes 1:1, 1:2, 1:3
 		priority := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v root handle 1: prio", target.Pid, netInterface)
 		log.Info(priority)
-		if err := common.RunBashCommand(priority, "failed to create priority-based queue", target.Source); err != nil {
+		if err := common.RunBashCommandWithContext(ctx, priority, "failed to create priority-based queue", target.Source); err != nil {
 			return err
 		}
 
 		// Add queueing discipline for 1:3 class.
 		// No traffic is going through 1:3 yet
-		traffic := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v parent 1:3 netem %v", target.Pid, netInterface, netemCommands)
+		traffic := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v parent 1:3 %v %v", target.Pid, netInterface, qdisc, netemCommands)
 		log.Info(traffic)
-		if err := common.RunBashCommand(traffic, "failed to create netem queueing discipline", target.Source); err != nil {
+		if err := common.RunBashCommandWithContext(ctx, traffic, fmt.Sprintf("failed to create %s queueing discipline", qdisc), target.Source); err != nil {
 			return err
 		}
 
@@ -211,7 +250,7 @@ func injectChaos(netInterface string, target targetDetails) error {
 				//redirect traffic to specific dport through band 2
 				tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 2 u32 match ip dport %v 0xffff flowid 1:2", target.Pid, netInterface, port)
 				log.Info(tc)
-				if err := common.RunBashCommand(tc, "failed to create whitelist dport match filters", target.Source); err != nil {
+				if err := common.RunBashCommandWithContext(ctx, tc, "failed to create whitelist dport match filters", target.Source); err != nil {
 					return err
 				}
 			}
@@ -220,14 +259,14 @@ func injectChaos(netInterface string, target targetDetails) error {
 				//redirect traffic to specific sport through band 2
 				tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 2 u32 match ip sport %v 0xffff flowid 1:2", target.Pid, netInterface, port)
 				log.Info(tc)
-				if err := common.RunBashCommand(tc, "failed to create whitelist sport match filters", target.Source); err != nil {
+				if err := common.RunBashCommandWithContext(ctx, tc, "failed to create whitelist sport match filters", target.Source); err != nil {
 					return err
 				}
 			}
 
 			tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip dst 0.0.0.0/0 flowid 1:3", target.Pid, netInterface)
 			log.Info(tc)
-			if err := common.RunBashCommand(tc, "failed to create rule for all ports match filters", target.Source); err != nil {
+			if err := common.RunBashCommandWithContext(ctx, tc, "failed to create rule for all ports match filters", target.Source); err != nil {
 				return err
 			}
 		} else {
@@ -239,7 +278,7 @@ func injectChaos(netInterface string, target targetDetails) error {
 					tc = fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip6 dst %v flowid 1:3", target.Pid, netInterface, ip)
 				}
 				log.Info(tc)
-				if err := common.RunBashCommand(tc, "failed to create destination ips match filters", target.Source); err != nil {
+				if err := common.RunBashCommandWithContext(ctx, tc, "failed to create destination ips match filters", target.Source); err != nil {
 					return err
 				}
 			}
@@ -248,7 +287,7 @@ func injectChaos(netInterface string, target targetDetails) error {
 				//redirect traffic to specific sport through band 3
 				tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip sport %v 0xffff flowid 1:3", target.Pid, netInterface, port)
 				log.Info(tc)
-				if err := common.RunBashCommand(tc, "failed to create source ports match filters", target.Source); err != nil {
+				if err := common.RunBashCommandWithContext(ctx, tc, "failed to create source ports match filters", target.Source); err != nil {
 					return err
 				}
 			}
@@ -257,7 +296,7 @@ func injectChaos(netInterface string, target targetDetails) error {
 				//redirect traffic to specific dport through band 3
 				tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip dport %v 0xffff flowid 1:3", target.Pid, netInterface, port)
 				log.Info(tc)
-				if err := common.RunBashCommand(tc, "failed to create destination ports match filters", target.Source); err != nil {
+				if err := common.RunBashCommandWithContext(ctx, tc, "failed to create destination ports match filters", target.Source); err != nil {
 					return err
 				}
 			}
@@ -269,7 +308,9 @@ func injectChaos(netInterface string, target targetDetails) error {
 }
 
 // killnetem kill the netem process for all the target containers
-func killnetem(target targetDetails, networkInterface string) (bool, error) {
+func killnetem(ctx context.Context, target targetDetails, networkInterface string) (bool, error) {
+	_, span := telemetry.StartSpan(ctx, "RevertPodNetworkFaultOnTarget", target.attributes()...)
+	defer span.End()
 
 	tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc delete dev %s root", target.Pid, networkInterface)
 	cmd := exec.Command("/bin/bash", "-c", tc)
@@ -283,7 +324,9 @@ func killnetem(target targetDetails, networkInterface string) (bool, error) {
 			return true, err
 		}
 		log.Error(err.Error())
-		return false, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: target.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", target.Name, target.Namespace, target.TargetContainer), Reason: fmt.Sprintf("failed to revert network faults: %s", string(out))}
+		revertErr := cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Source: target.Source, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", target.Name, target.Namespace, target.TargetContainer), Reason: fmt.Sprintf("failed to revert network faults: %s", string(out))}
+		telemetry.RecordError(span, revertErr)
+		return false, revertErr
 	}
 	log.Infof("successfully reverted chaos on target: {name: %s, namespace: %v, container: %v}", target.Name, target.Namespace, target.TargetContainer)
 	return true, nil
@@ -298,6 +341,16 @@ type targetDetails struct {
 	ContainerId     string
 	Pid             int
 	Source          string
+	References      []events.TargetReference
+}
+
+// attributes returns the span attributes identifying the target
+func (target targetDetails) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("k8s.pod.name", target.Name),
+		attribute.String("k8s.namespace.name", target.Namespace),
+		attribute.String("k8s.container.name", target.TargetContainer),
+	}
 }
 
 // getENV fetches all the env variables from the runner pod
@@ -332,8 +385,20 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	}
 }
 
+// getJournalEntry returns the journal entry of the target, containing the revert details
+func getJournalEntry(target targetDetails, experimentsDetails *experimentTypes.ExperimentDetails) *journal.Entry {
+	return journal.NewEntry(experimentsDetails.ExperimentName, "pod", target.Name, target.Namespace).
+		WithContainer(target.TargetContainer, target.ContainerId, target.Pid).
+		WithParam("qdisc", types.Getenv("QDISC", "netem")).
+		WithParam("netem", os.Getenv("NETEM_COMMAND")).
+		WithParam("networkInterface", experimentsDetails.NetworkInterface).
+		WithRevertCommands(fmt.Sprintf("sudo nsenter -t %d -n tc qdisc delete dev %s root", target.Pid, experimentsDetails.NetworkInterface))
+}
+
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targets []targetDetails, networkInterface, resultName, chaosNS string) {
+func abortWatcher(ctx context.Context, targets []targetDetails, networkInterface, resultName string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 	log.Info("[Chaos]: Killing process started because of terminated signal received")
@@ -342,13 +407,20 @@ func abortWatcher(targets []targetDetails, networkInterface, resultName, chaosNS
 	retry := 3
 	for retry > 0 {
 		for _, t := range targets {
-			killed, err := killnetem(t, networkInterface)
+			killed, err := killnetem(ctx, t, networkInterface)
 			if err != nil && !killed {
 				log.Errorf("unable to kill netem process, err :%v", err)
 				continue
 			}
+			if killed {
+				records.Reverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), resultName, err, chaosDetails, clients)
+				events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+				if err := journal.MarkReverted(journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer), chaosDetails, clients); err != nil {
+					log.Errorf("unable to update the journal, err :%v", err)
+				}
+			}
 			if killed && err == nil {
-				if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", t.Name); err != nil {
+				if err = result.AnnotateChaosResult(resultName, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 					log.Errorf("unable to annotate the chaosresult, err :%v", err)
 				}
 			}
@@ -357,7 +429,8 @@ func abortWatcher(targets []targetDetails, networkInterface, resultName, chaosNS
 		time.Sleep(1 * time.Second)
 	}
 	log.Info("Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
 func getDestIps(serviceMesh string) []string {
 	var (
@@ -16,6 +16,8 @@ func PodNetworkCorruptionChaos(ctx context.Context, experimentsDetails *experime
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PreparePodNetworkCorruptionFault")
 	defer span.End()
 
-	args := "corrupt " + experimentsDetails.NetworkPacketCorruptionPercentage
-	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, args)
+	getArgs := func() string {
+		return network_chaos.GetCorruptionArgs(experimentsDetails)
+	}
+	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, getArgs)
 }
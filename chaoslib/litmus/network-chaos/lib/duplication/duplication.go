@@ -16,6 +16,8 @@ func PodNetworkDuplicationChaos(ctx context.Context, experimentsDetails *experim
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PreparePodNetworkDuplicationFault")
 	defer span.End()
 
-	args := "duplicate " + experimentsDetails.NetworkPacketDuplicationPercentage
-	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, args)
+	getArgs := func() string {
+		return network_chaos.GetDuplicationArgs(experimentsDetails)
+	}
+	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, getArgs)
 }
@@ -16,6 +16,8 @@ func PodNetworkLossChaos(ctx context.Context, experimentsDetails *experimentType
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PreparePodNetworkLossFault")
 	defer span.End()
 
-	args := "loss " + experimentsDetails.NetworkPacketLossPercentage
-	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, args)
+	getArgs := func() string {
+		return network_chaos.GetLossArgs(experimentsDetails)
+	}
+	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, getArgs)
 }
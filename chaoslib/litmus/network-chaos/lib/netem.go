@@ -0,0 +1,139 @@
+package lib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+)
+
+// distributions are the delay distribution tables shipped with iproute2, the jitter is uniform without the distribution
+var distributions = []string{"normal", "pareto", "paretonormal", "experimental"}
+
+// GetLatencyArgs returns the netem args of the latency, along with its correlation, distribution and reordering
+func GetLatencyArgs(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	args := fmt.Sprintf("delay %vms %vms", experimentsDetails.NetworkLatency, experimentsDetails.Jitter)
+	if experimentsDetails.Correlation != "" {
+		args += " " + experimentsDetails.Correlation
+	}
+	if experimentsDetails.Distribution != "" {
+		args += " distribution " + experimentsDetails.Distribution
+	}
+	if experimentsDetails.ReorderPercentage != "" {
+		args += " reorder " + experimentsDetails.ReorderPercentage
+		if experimentsDetails.ReorderCorrelation != "" {
+			args += " " + experimentsDetails.ReorderCorrelation
+		}
+		if experimentsDetails.ReorderGap != 0 {
+			args += " gap " + strconv.Itoa(experimentsDetails.ReorderGap)
+		}
+	}
+	return args
+}
+
+// GetLossArgs returns the netem args of the loss, as per the loss model
+func GetLossArgs(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	if experimentsDetails.LossModel != "gemodel" {
+		return withCorrelation("loss "+experimentsDetails.NetworkPacketLossPercentage, experimentsDetails.Correlation)
+	}
+	args := "loss gemodel " + experimentsDetails.NetworkPacketLossPercentage
+	for _, p := range []string{experimentsDetails.GEModel.R, experimentsDetails.GEModel.OneMinusH, experimentsDetails.GEModel.OneMinusK} {
+		if p == "" {
+			break
+		}
+		args += " " + p
+	}
+	return args
+}
+
+// GetCorruptionArgs returns the netem args of the corruption
+func GetCorruptionArgs(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	return withCorrelation("corrupt "+experimentsDetails.NetworkPacketCorruptionPercentage, experimentsDetails.Correlation)
+}
+
+// GetDuplicationArgs returns the netem args of the duplication
+func GetDuplicationArgs(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	return withCorrelation("duplicate "+experimentsDetails.NetworkPacketDuplicationPercentage, experimentsDetails.Correlation)
+}
+
+// withCorrelation appends the correlation to the args, if provided
+func withCorrelation(args, correlation string) string {
+	if correlation == "" {
+		return args
+	}
+	return args + " " + correlation
+}
+
+// ValidateNetemTunables validates the netem tunables, before they reach the helper
+func ValidateNetemTunables(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if err := validatePercentage("CORRELATION", experimentsDetails.Correlation); err != nil {
+		return err
+	}
+
+	switch experimentsDetails.NetworkChaosType {
+	case "network-latency":
+		if experimentsDetails.Jitter == 0 && (experimentsDetails.Correlation != "" || experimentsDetails.Distribution != "") {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "CORRELATION and DISTRIBUTION of the latency need the JITTER"}
+		}
+		if experimentsDetails.Distribution != "" && !common.Contains(experimentsDetails.Distribution, distributions) {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid DISTRIBUTION: '%s', supported distributions are %v", experimentsDetails.Distribution, distributions)}
+		}
+		if experimentsDetails.ReorderPercentage == "" {
+			if experimentsDetails.ReorderCorrelation != "" || experimentsDetails.ReorderGap != 0 {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "REORDER_CORRELATION and REORDER_GAP need the REORDER_PERCENTAGE"}
+			}
+			return nil
+		}
+		if experimentsDetails.NetworkLatency <= 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "reordering needs the NETWORK_LATENCY, as the packets are reordered by sending some of them without the delay"}
+		}
+		if experimentsDetails.ReorderGap < 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid REORDER_GAP: '%d', it can't be negative", experimentsDetails.ReorderGap)}
+		}
+		if err := validatePercentage("REORDER_PERCENTAGE", experimentsDetails.ReorderPercentage); err != nil {
+			return err
+		}
+		return validatePercentage("REORDER_CORRELATION", experimentsDetails.ReorderCorrelation)
+	case "network-loss":
+		ge := experimentsDetails.GEModel
+		switch experimentsDetails.LossModel {
+		case "", "random":
+			if ge.R != "" || ge.OneMinusH != "" || ge.OneMinusK != "" {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "GEMODEL_R, GEMODEL_1_H and GEMODEL_1_K need the gemodel LOSS_MODEL"}
+			}
+			return nil
+		case "gemodel":
+			if experimentsDetails.Correlation != "" {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "CORRELATION isn't supported by the gemodel LOSS_MODEL, the burstiness is derived from its transition probabilities"}
+			}
+			if (ge.OneMinusH != "" && ge.R == "") || (ge.OneMinusK != "" && ge.OneMinusH == "") {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "the gemodel probabilities should be provided in order, GEMODEL_1_H needs the GEMODEL_R and GEMODEL_1_K needs the GEMODEL_1_H"}
+			}
+			if err := validatePercentage("GEMODEL_R", ge.R); err != nil {
+				return err
+			}
+			if err := validatePercentage("GEMODEL_1_H", ge.OneMinusH); err != nil {
+				return err
+			}
+			return validatePercentage("GEMODEL_1_K", ge.OneMinusK)
+		default:
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid LOSS_MODEL: '%s', supported models are [random gemodel]", experimentsDetails.LossModel)}
+		}
+	}
+	return nil
+}
+
+// validatePercentage validates the optional percentage, with or without the % suffix
+func validatePercentage(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	p, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || p < 0 || p > 100 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid %s: '%s', provide the percentage between 0 and 100", name, value)}
+	}
+	return nil
+}
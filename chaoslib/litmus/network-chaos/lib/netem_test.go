@@ -0,0 +1,119 @@
+package lib
+
+import (
+	"testing"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetemArgs(t *testing.T) {
+	latency := func(details experimentTypes.ExperimentDetails) experimentTypes.ExperimentDetails {
+		details.NetworkChaosType, details.NetworkLatency = "network-latency", 100
+		return details
+	}
+	loss := func(details experimentTypes.ExperimentDetails) experimentTypes.ExperimentDetails {
+		details.NetworkChaosType, details.NetworkPacketLossPercentage = "network-loss", "5"
+		return details
+	}
+
+	testCases := map[string]struct {
+		details experimentTypes.ExperimentDetails
+		getArgs func(*experimentTypes.ExperimentDetails) string
+		args    string
+		err     string
+	}{
+		"latency": {
+			details: latency(experimentTypes.ExperimentDetails{Jitter: 10}),
+			getArgs: GetLatencyArgs,
+			args:    "delay 100ms 10ms",
+		},
+		"latency with correlation and distribution": {
+			details: latency(experimentTypes.ExperimentDetails{Jitter: 10, Correlation: "25%", Distribution: "paretonormal"}),
+			getArgs: GetLatencyArgs,
+			args:    "delay 100ms 10ms 25% distribution paretonormal",
+		},
+		"reorder": {
+			details: latency(experimentTypes.ExperimentDetails{ReorderPercentage: "25", ReorderCorrelation: "50", ReorderGap: 5}),
+			getArgs: GetLatencyArgs,
+			args:    "delay 100ms 0ms reorder 25 50 gap 5",
+		},
+		"distribution without jitter": {
+			details: latency(experimentTypes.ExperimentDetails{Distribution: "normal"}),
+			err:     "need the JITTER",
+		},
+		"unknown distribution": {
+			details: latency(experimentTypes.ExperimentDetails{Jitter: 10, Distribution: "gaussian"}),
+			err:     "invalid DISTRIBUTION: 'gaussian'",
+		},
+		"uniform distribution": {
+			details: latency(experimentTypes.ExperimentDetails{Jitter: 10, Distribution: "uniform"}),
+			err:     "invalid DISTRIBUTION: 'uniform'",
+		},
+		"reorder gap without reorder": {
+			details: latency(experimentTypes.ExperimentDetails{ReorderGap: 5}),
+			err:     "need the REORDER_PERCENTAGE",
+		},
+		"reorder without latency": {
+			details: experimentTypes.ExperimentDetails{NetworkChaosType: "network-latency", ReorderPercentage: "25"},
+			err:     "reordering needs the NETWORK_LATENCY",
+		},
+		"invalid reorder percentage": {
+			details: latency(experimentTypes.ExperimentDetails{ReorderPercentage: "125"}),
+			err:     "invalid REORDER_PERCENTAGE: '125'",
+		},
+		"random loss with correlation": {
+			details: loss(experimentTypes.ExperimentDetails{LossModel: "random", Correlation: "25"}),
+			getArgs: GetLossArgs,
+			args:    "loss 5 25",
+		},
+		"gemodel loss": {
+			details: loss(experimentTypes.ExperimentDetails{LossModel: "gemodel", GEModel: experimentTypes.GEModel{R: "20", OneMinusH: "70"}}),
+			getArgs: GetLossArgs,
+			args:    "loss gemodel 5 20 70",
+		},
+		"gemodel out of order": {
+			details: loss(experimentTypes.ExperimentDetails{LossModel: "gemodel", GEModel: experimentTypes.GEModel{OneMinusK: "1"}}),
+			err:     "should be provided in order",
+		},
+		"gemodel with correlation": {
+			details: loss(experimentTypes.ExperimentDetails{LossModel: "gemodel", Correlation: "25"}),
+			err:     "CORRELATION isn't supported by the gemodel",
+		},
+		"gemodel probabilities with random loss": {
+			details: loss(experimentTypes.ExperimentDetails{LossModel: "random", GEModel: experimentTypes.GEModel{R: "20"}}),
+			err:     "need the gemodel LOSS_MODEL",
+		},
+		"unknown loss model": {
+			details: loss(experimentTypes.ExperimentDetails{LossModel: "bursty"}),
+			err:     "invalid LOSS_MODEL: 'bursty'",
+		},
+		"corruption with correlation": {
+			details: experimentTypes.ExperimentDetails{NetworkChaosType: "network-corruption", NetworkPacketCorruptionPercentage: "10", Correlation: "5"},
+			getArgs: GetCorruptionArgs,
+			args:    "corrupt 10 5",
+		},
+		"invalid correlation": {
+			details: experimentTypes.ExperimentDetails{NetworkChaosType: "network-duplication", NetworkPacketDuplicationPercentage: "10", Correlation: "high"},
+			err:     "invalid CORRELATION: 'high'",
+		},
+		"duplication": {
+			details: experimentTypes.ExperimentDetails{NetworkChaosType: "network-duplication", NetworkPacketDuplicationPercentage: "10"},
+			getArgs: GetDuplicationArgs,
+			args:    "duplicate 10",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateNetemTunables(&tc.details)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.args, tc.getArgs(&tc.details))
+		})
+	}
+}
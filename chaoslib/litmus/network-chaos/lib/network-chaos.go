@@ -16,6 +16,7 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/intensity"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/status"
@@ -28,11 +29,10 @@ import (
 )
 
 var serviceMesh = []string{"istio", "envoy"}
-var destIpsSvcMesh string
-var destIps string
 
 // PrepareAndInjectChaos contains the preparation & injection steps
-func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, args string) error {
+// the args of the helper are derived by getArgs, just before the injection, so that the parameters can be stepped
+func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, getArgs func() string) error {
 
 	var err error
 	// Get the target pod details for the chaos execution
@@ -41,18 +41,23 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "provide one of the appLabel or TARGET_PODS"}
 	}
 	//set up the tunables if provided in range
-	SetChaosTunables(experimentsDetails)
+	SetChaosTunables(experimentsDetails, chaosDetails)
+	if err := ValidateNetemTunables(experimentsDetails); err != nil {
+		return err
+	}
 	logExperimentFields(experimentsDetails)
 
-	targetPodList, err := common.GetTargetPods(experimentsDetails.NodeLabel, experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
+	profile, err := intensity.ParseProfile(experimentsDetails.IntensitySteps, experimentsDetails.StepDuration, experimentsDetails.ChaosDuration, getSteppedParameters(experimentsDetails))
 	if err != nil {
-		return stacktrace.Propagate(err, "could not get target pods")
+		return stacktrace.Propagate(err, "could not get intensity steps")
 	}
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// Getting the serviceAccountName, need permission inside helper pod to create the events
@@ -70,20 +75,63 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 	}
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, args, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
+
+	// the probes during chaos are started once, only the step probes are evaluated in every step
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return err
 		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, args, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
+	}
+
+	// the target pods are selected once, so that all the steps are injected in the same pods
+	// unless the pods affected percentage is stepped, then the pods are selected again in every step
+	var targetPodList apiv1.PodList
+	if profile == nil || profile.Parameter != "PODS_AFFECTED_PERC" {
+		if targetPodList, err = getTargetPods(experimentsDetails, clients, chaosDetails); err != nil {
+			return err
 		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
 	}
 
-	return nil
+	if profile == nil {
+		return injectChaos(ctx, experimentsDetails, targetPodList, clients, chaosDetails, getArgs(), resultDetails, eventsDetails)
+	}
+
+	// the chaos is injected for every step, with the parameter set to the value of the step
+	chaosDuration := experimentsDetails.ChaosDuration
+	defer func() { experimentsDetails.ChaosDuration = chaosDuration }()
+	return profile.Run(ctx, chaosDetails, clients, resultDetails, func(ctx context.Context, step intensity.Step) error {
+		experimentsDetails.ChaosDuration = step.Duration
+		// the stepped parameters are validated again, as the step may change the tunables the others depend on (e.g, the latency of the reordering)
+		if err := ValidateNetemTunables(experimentsDetails); err != nil {
+			return err
+		}
+		logExperimentFields(experimentsDetails)
+		stepTargetPodList := targetPodList
+		if step.Parameter == "PODS_AFFECTED_PERC" {
+			if stepTargetPodList, err = getTargetPods(experimentsDetails, clients, chaosDetails); err != nil {
+				return err
+			}
+		}
+		return injectChaos(ctx, experimentsDetails, stepTargetPodList, clients, chaosDetails, getArgs(), resultDetails, eventsDetails)
+	})
+}
+
+// getTargetPods selects the target pods of the network chaos
+func getTargetPods(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, error) {
+	targetPodList, err := common.GetTargetPods(experimentsDetails.NodeLabel, experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
+	if err != nil {
+		return apiv1.PodList{}, stacktrace.Propagate(err, "could not get target pods")
+	}
+	return targetPodList, nil
+}
+
+// injectChaos injects the network chaos in the target pods, as per the sequence
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targetPodList apiv1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails, args string, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails) error {
+	return common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, args, resultDetails, eventsDetails)
+	}, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, args, resultDetails, eventsDetails)
+	})
 }
 
 // injectChaosInSerialMode inject the network chaos in all target application serially (one by one)
@@ -91,13 +139,6 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectPodNetworkFaultInSerialMode")
 	defer span.End()
 
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
-			return err
-		}
-	}
-
 	// creating the helper pod to perform network chaos
 	for _, pod := range targetPodList.Items {
 
@@ -121,7 +162,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 		log.Info("[Status]: Checking the status of the helper pods")
-		if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return stacktrace.Propagate(err, "could not check helper status")
 		}
@@ -129,7 +170,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		// Wait till the completion of the helper pod
 		// set an upper limit for the waiting time
 		log.Info("[Wait]: waiting till the completion of the helper pod")
-		podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+		podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout+chaosDetails.Approval.TimeoutOf(types.ApprovalGateRevert), common.GetContainerNames(chaosDetails)...)
 		if err != nil || podStatus == "Failed" {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -151,13 +192,6 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	defer span.End()
 	var err error
 
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
-			return err
-		}
-	}
-
 	targets, err := filterPodsForNodes(targetPodList, experimentsDetails, clients)
 	if err != nil {
 		return stacktrace.Propagate(err, "could not filter target pods")
@@ -166,6 +200,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	runID := stringutils.GetRunID()
 
 	for node, tar := range targets {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
+		}
 		var targetsPerNode []string
 		for _, k := range tar.Target {
 			targetsPerNode = append(targetsPerNode, fmt.Sprintf("%s:%s:%s:%s", k.Name, k.Namespace, k.TargetContainer, k.ServiceMesh))
@@ -180,7 +217,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 	log.Info("[Status]: Checking the status of the helper pods")
-	if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+	if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return stacktrace.Propagate(err, "could not check helper status")
 	}
@@ -188,7 +225,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	// Wait till the completion of the helper pod
 	// set an upper limit for the waiting time
 	log.Info("[Wait]: waiting till the completion of the helper pod")
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout+chaosDetails.Approval.TimeoutOf(types.ApprovalGateRevert), common.GetContainerNames(chaosDetails)...)
 	if err != nil || podStatus == "Failed" {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -249,7 +286,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 						"./helpers -name network-chaos",
 					},
 					Resources: chaosDetails.Resources,
-					Env:       getPodEnv(ctx, experimentsDetails, targets, args),
+					Env:       getPodEnv(ctx, experimentsDetails, chaosDetails, targets, args),
 					VolumeMounts: []apiv1.VolumeMount{
 						{
 							Name:      "cri-socket",
@@ -275,7 +312,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(context.Background(), helperPod, v1.CreateOptions{})
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(ctx, helperPod, v1.CreateOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
@@ -283,7 +320,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 }
 
 // getPodEnv derive all the env required for the helper pod
-func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targets string, args string) []apiv1.EnvVar {
+func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, targets string, args string) []apiv1.EnvVar {
 
 	var envDetails common.ENVDetails
 	envDetails.SetEnv("TARGETS", targets).
@@ -293,15 +330,20 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 		SetEnv("CHAOS_UID", string(experimentsDetails.ChaosUID)).
 		SetEnv("CONTAINER_RUNTIME", experimentsDetails.ContainerRuntime).
 		SetEnv("NETEM_COMMAND", args).
+		SetEnv("QDISC", experimentsDetails.Qdisc).
 		SetEnv("NETWORK_INTERFACE", experimentsDetails.NetworkInterface).
 		SetEnv("EXPERIMENT_NAME", experimentsDetails.ExperimentName).
 		SetEnv("SOCKET_PATH", experimentsDetails.SocketPath).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
-		SetEnv("DESTINATION_IPS", destIps).
-		SetEnv("DESTINATION_IPS_SERVICE_MESH", destIpsSvcMesh).
+		SetEnv("DESTINATION_IPS", experimentsDetails.TargetIPs).
+		SetEnv("DESTINATION_IPS_SERVICE_MESH", experimentsDetails.TargetIPsServiceMesh).
 		SetEnv("SOURCE_PORTS", experimentsDetails.SourcePorts).
 		SetEnv("DESTINATION_PORTS", experimentsDetails.DestinationPorts).
 		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPEndpoint)).
+		SetEnv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPLogsEndpoint)).
+		SetEnv("APPROVAL_GATES", strings.Join(chaosDetails.Approval.Gates, ",")).
+		SetEnv("APPROVAL_TIMEOUT", strconv.Itoa(chaosDetails.Approval.Timeout)).
+		SetEnv("APPROVAL_CONFIGMAP", chaosDetails.Approval.ConfigMap).
 		SetEnv("TRACE_PARENT", telemetry.GetMarshalledSpanFromContext(ctx)).
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
@@ -428,12 +470,24 @@ func getIpsForTargetHosts(targetHosts string, clients clients.ClientSets, servic
 
 // SetChaosTunables will set up a random value within a given range of values
 // If the value is not provided in range it'll set up the initial provided value.
-func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
-	experimentsDetails.NetworkPacketLossPercentage = common.ValidateRange(experimentsDetails.NetworkPacketLossPercentage)
-	experimentsDetails.NetworkPacketCorruptionPercentage = common.ValidateRange(experimentsDetails.NetworkPacketCorruptionPercentage)
-	experimentsDetails.NetworkPacketDuplicationPercentage = common.ValidateRange(experimentsDetails.NetworkPacketDuplicationPercentage)
-	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc)
-	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
+func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+	experimentsDetails.NetworkPacketLossPercentage = common.ValidateRange(experimentsDetails.NetworkPacketLossPercentage, chaosDetails)
+	experimentsDetails.NetworkPacketCorruptionPercentage = common.ValidateRange(experimentsDetails.NetworkPacketCorruptionPercentage, chaosDetails)
+	experimentsDetails.NetworkPacketDuplicationPercentage = common.ValidateRange(experimentsDetails.NetworkPacketDuplicationPercentage, chaosDetails)
+	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc, chaosDetails)
+	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence, chaosDetails)
+}
+
+// getSteppedParameters returns the parameters of the network chaos, which can be stepped
+func getSteppedParameters(experimentsDetails *experimentTypes.ExperimentDetails) intensity.Parameters {
+	return intensity.Parameters{
+		"NETWORK_LATENCY":                       intensity.IntParameter(&experimentsDetails.NetworkLatency, "ms"),
+		"NETWORK_PACKET_LOSS_PERCENTAGE":        intensity.StringParameter(&experimentsDetails.NetworkPacketLossPercentage),
+		"NETWORK_PACKET_CORRUPTION_PERCENTAGE":  intensity.StringParameter(&experimentsDetails.NetworkPacketCorruptionPercentage),
+		"NETWORK_PACKET_DUPLICATION_PERCENTAGE": intensity.StringParameter(&experimentsDetails.NetworkPacketDuplicationPercentage),
+		"NETWORK_BANDWIDTH":                     intensity.StringParameter(&experimentsDetails.NetworkBandwidth),
+		"PODS_AFFECTED_PERC":                    intensity.StringParameter(&experimentsDetails.PodsAffectedPerc),
+	}
 }
 
 // It checks if pod contains service mesh sidecar
@@ -449,16 +503,16 @@ func isServiceMeshEnabledForPod(pod apiv1.Pod) bool {
 func setDestIps(pod apiv1.Pod, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
 	var err error
 	if isServiceMeshEnabledForPod(pod) {
-		if destIpsSvcMesh == "" {
-			destIpsSvcMesh, err = GetTargetIps(experimentsDetails.DestinationIPs, experimentsDetails.DestinationHosts, clients, true)
+		if experimentsDetails.TargetIPsServiceMesh == "" {
+			experimentsDetails.TargetIPsServiceMesh, err = GetTargetIps(experimentsDetails.DestinationIPs, experimentsDetails.DestinationHosts, clients, true)
 			if err != nil {
 				return "false", err
 			}
 		}
 		return "true", nil
 	}
-	if destIps == "" {
-		destIps, err = GetTargetIps(experimentsDetails.DestinationIPs, experimentsDetails.DestinationHosts, clients, false)
+	if experimentsDetails.TargetIPs == "" {
+		experimentsDetails.TargetIPs, err = GetTargetIps(experimentsDetails.DestinationIPs, experimentsDetails.DestinationHosts, clients, false)
 		if err != nil {
 			return "false", err
 		}
@@ -503,27 +557,46 @@ func logExperimentFields(experimentsDetails *experimentTypes.ExperimentDetails)
 	case "network-loss":
 		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
 			"NetworkPacketLossPercentage": experimentsDetails.NetworkPacketLossPercentage,
+			"Correlation":                 experimentsDetails.Correlation,
+			"LossModel":                   experimentsDetails.LossModel,
+			"GEModel":                     experimentsDetails.GEModel,
 			"Sequence":                    experimentsDetails.Sequence,
 			"PodsAffectedPerc":            experimentsDetails.PodsAffectedPerc,
 		})
 	case "network-latency":
 		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
-			"NetworkLatency":   strconv.Itoa(experimentsDetails.NetworkLatency),
-			"Jitter":           experimentsDetails.Jitter,
-			"Sequence":         experimentsDetails.Sequence,
-			"PodsAffectedPerc": experimentsDetails.PodsAffectedPerc,
+			"NetworkLatency":     strconv.Itoa(experimentsDetails.NetworkLatency),
+			"Jitter":             experimentsDetails.Jitter,
+			"Correlation":        experimentsDetails.Correlation,
+			"Distribution":       experimentsDetails.Distribution,
+			"ReorderPercentage":  experimentsDetails.ReorderPercentage,
+			"ReorderCorrelation": experimentsDetails.ReorderCorrelation,
+			"ReorderGap":         experimentsDetails.ReorderGap,
+			"Sequence":           experimentsDetails.Sequence,
+			"PodsAffectedPerc":   experimentsDetails.PodsAffectedPerc,
 		})
 	case "network-corruption":
 		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
 			"NetworkPacketCorruptionPercentage": experimentsDetails.NetworkPacketCorruptionPercentage,
+			"Correlation":                       experimentsDetails.Correlation,
 			"Sequence":                          experimentsDetails.Sequence,
 			"PodsAffectedPerc":                  experimentsDetails.PodsAffectedPerc,
 		})
 	case "network-duplication":
 		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
 			"NetworkPacketDuplicationPercentage": experimentsDetails.NetworkPacketDuplicationPercentage,
+			"Correlation":                        experimentsDetails.Correlation,
 			"Sequence":                           experimentsDetails.Sequence,
 			"PodsAffectedPerc":                   experimentsDetails.PodsAffectedPerc,
 		})
+	case "network-rate-limit":
+		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
+			"NetworkBandwidth": experimentsDetails.NetworkBandwidth,
+			"Burst":            experimentsDetails.Burst,
+			"Limit":            experimentsDetails.Limit,
+			"QueueLatency":     experimentsDetails.QueueLatency,
+			"Sequence":         experimentsDetails.Sequence,
+			"PodsAffectedPerc": experimentsDetails.PodsAffectedPerc,
+		})
 	}
 }
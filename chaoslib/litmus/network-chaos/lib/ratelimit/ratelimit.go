@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	network_chaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"go.opentelemetry.io/otel"
+)
+
+var (
+	// rateFormat matches the rates accepted by tc, like 1mbit or 512kbps
+	rateFormat = regexp.MustCompile(`^\d+(\.\d+)?([kmgt]?(bit|bps))$`)
+	// sizeFormat matches the sizes accepted by tc, like 32kb or 1mbit
+	sizeFormat = regexp.MustCompile(`^\d+([kmg]?(b|bit))?$`)
+	// timeFormat matches the times accepted by tc, like 50ms or 1s
+	timeFormat = regexp.MustCompile(`^\d+(\.\d+)?(s|sec|ms|msec|us|usec)$`)
+)
+
+// PodNetworkRateLimitChaos contains the steps to prepare and inject chaos
+// the bandwidth is limited by the tbf qdisc, which replaces the netem qdisc of the network chaos
+func PodNetworkRateLimitChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PreparePodNetworkRateLimitFault")
+	defer span.End()
+
+	if err := ValidateTunables(experimentsDetails); err != nil {
+		return err
+	}
+
+	getArgs := func() string {
+		return GetArgs(experimentsDetails)
+	}
+	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, getArgs)
+}
+
+// GetArgs returns the args of the tbf qdisc
+// the size of the queue is bounded by the LIMIT if provided, otherwise by the QUEUE_LATENCY
+func GetArgs(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	args := fmt.Sprintf("rate %s burst %s", experimentsDetails.NetworkBandwidth, experimentsDetails.Burst)
+	if experimentsDetails.Limit != "" {
+		return args + " limit " + experimentsDetails.Limit
+	}
+	return args + " latency " + experimentsDetails.QueueLatency
+}
+
+// ValidateTunables validates the rate limit tunables, before they reach the helper
+func ValidateTunables(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	if !rateFormat.MatchString(experimentsDetails.NetworkBandwidth) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid NETWORK_BANDWIDTH: '%s', provide the rate like 1mbit or 512kbit", experimentsDetails.NetworkBandwidth)}
+	}
+	if !sizeFormat.MatchString(experimentsDetails.Burst) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid BURST: '%s', provide the size like 32kb or 32kbit", experimentsDetails.Burst)}
+	}
+	if experimentsDetails.Limit != "" {
+		if !sizeFormat.MatchString(experimentsDetails.Limit) {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid LIMIT: '%s', provide the size like 64kb", experimentsDetails.Limit)}
+		}
+		return nil
+	}
+	if !timeFormat.MatchString(experimentsDetails.QueueLatency) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid QUEUE_LATENCY: '%s', provide the time like 50ms, or the LIMIT", experimentsDetails.QueueLatency)}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"testing"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitArgs(t *testing.T) {
+	testCases := map[string]struct {
+		details experimentTypes.ExperimentDetails
+		args    string
+		err     string
+	}{
+		"latency": {
+			details: experimentTypes.ExperimentDetails{NetworkBandwidth: "1mbit", Burst: "32kb", QueueLatency: "50ms"},
+			args:    "rate 1mbit burst 32kb latency 50ms",
+		},
+		"limit takes precedence": {
+			details: experimentTypes.ExperimentDetails{NetworkBandwidth: "512kbit", Burst: "16kbit", Limit: "64kb", QueueLatency: "50ms"},
+			args:    "rate 512kbit burst 16kbit limit 64kb",
+		},
+		"fractional rate": {
+			details: experimentTypes.ExperimentDetails{NetworkBandwidth: "1.5mbps", Burst: "1500", QueueLatency: "1s"},
+			args:    "rate 1.5mbps burst 1500 latency 1s",
+		},
+		"rate without unit": {
+			details: experimentTypes.ExperimentDetails{NetworkBandwidth: "1000", Burst: "32kb", QueueLatency: "50ms"},
+			err:     "invalid NETWORK_BANDWIDTH: '1000'",
+		},
+		"invalid burst": {
+			details: experimentTypes.ExperimentDetails{NetworkBandwidth: "1mbit", Burst: "32 kb", QueueLatency: "50ms"},
+			err:     "invalid BURST: '32 kb'",
+		},
+		"invalid limit": {
+			details: experimentTypes.ExperimentDetails{NetworkBandwidth: "1mbit", Burst: "32kb", Limit: "-1"},
+			err:     "invalid LIMIT: '-1'",
+		},
+		"neither limit nor latency": {
+			details: experimentTypes.ExperimentDetails{NetworkBandwidth: "1mbit", Burst: "32kb"},
+			err:     "invalid QUEUE_LATENCY: ''",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateTunables(&tc.details)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.args, GetArgs(&tc.details))
+		})
+	}
+}
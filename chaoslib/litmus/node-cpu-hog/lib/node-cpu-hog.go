@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
@@ -31,7 +30,7 @@ func PrepareNodeCPUHog(ctx context.Context, experimentsDetails *experimentTypes.
 	defer span.End()
 
 	//set up the tunables if provided in range
-	setChaosTunables(experimentsDetails)
+	setChaosTunables(experimentsDetails, chaosDetails)
 
 	log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
 		"Node CPU Cores":           experimentsDetails.NodeCPUcores,
@@ -43,12 +42,14 @@ func PrepareNodeCPUHog(ctx context.Context, experimentsDetails *experimentTypes.
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//Select node for node-cpu-hog
 	nodesAffectedPerc, _ := strconv.Atoi(experimentsDetails.NodesAffectedPerc)
-	targetNodeList, err := common.GetNodeList(experimentsDetails.TargetNodes, experimentsDetails.NodeLabel, nodesAffectedPerc, clients)
+	targetNodeList, err := common.GetNodeList(experimentsDetails.TargetNodes, experimentsDetails.NodeLabel, nodesAffectedPerc, clients, chaosDetails)
 	if err != nil {
 		return stacktrace.Propagate(err, "could not get node list")
 	}
@@ -64,23 +65,20 @@ func PrepareNodeCPUHog(ctx context.Context, experimentsDetails *experimentTypes.
 		}
 	}
 
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetNodeList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetNodeList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	if err = common.InjectInSequence(ctx, experimentsDetails.Sequence, targetNodeList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []string) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, targets, clients, resultDetails, eventsDetails, chaosDetails)
+	}); err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -130,16 +128,18 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		//Checking the status of helper pod
 		log.Info("[Status]: Checking the status of the helper pod")
-		if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return stacktrace.Propagate(err, "could not check helper status")
 		}
 
 		common.SetTargets(appNode, "targeted", "node", chaosDetails)
+		events.MarkNodeUnderChaos(ctx, clients, chaosDetails, appNode)
 
 		// Wait till the completion of helper pod
 		log.Info("[Wait]: Waiting till the completion of the helper pod")
-		podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.ExperimentName)
+		podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, experimentsDetails.ExperimentName)
+		events.UnmarkNodeUnderChaos(ctx, clients, chaosDetails, appNode)
 		if err != nil || podStatus == "Failed" {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, false)
@@ -171,6 +171,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	experimentsDetails.RunID = stringutils.GetRunID()
 
 	for _, appNode := range targetNodeList {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
+		}
 
 		if experimentsDetails.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + appNode + " node"
@@ -200,18 +203,22 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	//Checking the status of helper pod
 	log.Info("[Status]: Checking the status of the helper pods")
-	if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+	if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return stacktrace.Propagate(err, "could not check helper status")
 	}
 
 	for _, appNode := range targetNodeList {
 		common.SetTargets(appNode, "targeted", "node", chaosDetails)
+		events.MarkNodeUnderChaos(ctx, clients, chaosDetails, appNode)
 	}
 
 	// Wait till the completion of helper pod
 	log.Info("[Wait]: Waiting till the completion of the helper pod")
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	for _, appNode := range targetNodeList {
+		events.UnmarkNodeUnderChaos(ctx, clients, chaosDetails, appNode)
+	}
 	if err != nil || podStatus == "Failed" {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, false)
@@ -282,7 +289,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(context.Background(), helperPod, v1.CreateOptions{})
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(ctx, helperPod, v1.CreateOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
@@ -291,9 +298,9 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 
 // setChaosTunables will set up a random value within a given range of values
 // If the value is not provided in range it'll set up the initial provided value.
-func setChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
-	experimentsDetails.NodeCPUcores = common.ValidateRange(experimentsDetails.NodeCPUcores)
-	experimentsDetails.CPULoad = common.ValidateRange(experimentsDetails.CPULoad)
-	experimentsDetails.NodesAffectedPerc = common.ValidateRange(experimentsDetails.NodesAffectedPerc)
-	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
+func setChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+	experimentsDetails.NodeCPUcores = common.ValidateRange(experimentsDetails.NodeCPUcores, chaosDetails)
+	experimentsDetails.CPULoad = common.ValidateRange(experimentsDetails.CPULoad, chaosDetails)
+	experimentsDetails.NodesAffectedPerc = common.ValidateRange(experimentsDetails.NodesAffectedPerc, chaosDetails)
+	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence, chaosDetails)
 }
@@ -0,0 +1,197 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// mirrorPodAnnotation is present on the static pods, which are managed by the kubelet and can't be evicted
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// drainOptions contains the rules for draining the node, similar to the kubectl drain flags
+type drainOptions struct {
+	// IgnoreDaemonSets skips the daemonset managed pods, otherwise the drain fails if they are present
+	IgnoreDaemonSets bool
+	// DeleteEmptyDirData evicts the pods using emptyDir volumes, otherwise the drain fails if they are present
+	DeleteEmptyDirData bool
+	// Force evicts the pods without any controller, otherwise the drain fails if they are present
+	Force bool
+	// Timeout is the maximum duration for the eviction of all the pods
+	Timeout time.Duration
+	// Interval is the wait between the eviction retries (blocked by the disruption budgets) and deletion checks
+	Interval time.Duration
+}
+
+// drain cordons the node and evicts all the pods running on it, using the eviction api
+// so that the pod disruption budgets are respected. The evictions blocked by the disruption budgets are retried till the timeout
+func drain(ctx context.Context, clients clients.ClientSets, nodeName string, opts drainOptions) error {
+	if err := setUnschedulable(ctx, clients, nodeName, true); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{node: %s}", nodeName), Reason: fmt.Sprintf("failed to cordon the node: %s", err.Error())}
+	}
+
+	pods, err := getPodsForEviction(ctx, clients, nodeName, opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []string
+	)
+	for i := range pods {
+		wg.Add(1)
+		go func(pod apiv1.Pod) {
+			defer wg.Done()
+			if err := evictPod(ctx, clients, pod, opts.Interval); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Sprintf("%s/%s: %s", pod.Namespace, pod.Name, err.Error()))
+				mu.Unlock()
+			}
+		}(pods[i])
+	}
+	wg.Wait()
+
+	if len(errs) != 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{node: %s}", nodeName), Reason: fmt.Sprintf("failed to evict the pods: [%s]", strings.Join(errs, ", "))}
+	}
+	return nil
+}
+
+// uncordon marks the node as schedulable
+func uncordon(ctx context.Context, clients clients.ClientSets, nodeName string) error {
+	if err := setUnschedulable(ctx, clients, nodeName, false); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{node: %s}", nodeName), Reason: fmt.Sprintf("failed to uncordon the node: %s", err.Error())}
+	}
+	return nil
+}
+
+// setUnschedulable patches the unschedulable field of the node
+func setUnschedulable(ctx context.Context, clients clients.ClientSets, nodeName string, unschedulable bool) error {
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	_, err := clients.KubeClient.CoreV1().Nodes().Patch(ctx, nodeName, k8stypes.StrategicMergePatchType, []byte(patch), v1.PatchOptions{})
+	return err
+}
+
+// getPodsForEviction returns the pods of the node, which should be evicted
+// it fails if any pod violates the drain rules, before evicting any pod
+func getPodsForEviction(ctx context.Context, clients clients.ClientSets, nodeName string, opts drainOptions) ([]apiv1.Pod, error) {
+	podList, err := clients.KubeClient.CoreV1().Pods(v1.NamespaceAll).List(ctx, v1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": nodeName}).String(),
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{node: %s}", nodeName), Reason: fmt.Sprintf("failed to list the pods: %s", err.Error())}
+	}
+
+	var (
+		pods       []apiv1.Pod
+		violations []string
+	)
+	for _, pod := range podList.Items {
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		// the completed pods can be deleted without any restrictions
+		if pod.Status.Phase == apiv1.PodSucceeded || pod.Status.Phase == apiv1.PodFailed {
+			pods = append(pods, pod)
+			continue
+		}
+
+		controller := v1.GetControllerOf(&pod)
+		switch {
+		case controller != nil && controller.Kind == "DaemonSet":
+			if !opts.IgnoreDaemonSets {
+				violations = append(violations, fmt.Sprintf("%s/%s is managed by daemonset", pod.Namespace, pod.Name))
+			}
+			continue
+		case controller == nil && !opts.Force:
+			violations = append(violations, fmt.Sprintf("%s/%s is not managed by any controller", pod.Namespace, pod.Name))
+			continue
+		case hasEmptyDir(pod) && !opts.DeleteEmptyDirData:
+			violations = append(violations, fmt.Sprintf("%s/%s is using emptyDir volume", pod.Namespace, pod.Name))
+			continue
+		}
+		pods = append(pods, pod)
+	}
+
+	if len(violations) != 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{node: %s}", nodeName), Reason: fmt.Sprintf("cannot drain the node: [%s]", strings.Join(violations, ", "))}
+	}
+	return pods, nil
+}
+
+// hasEmptyDir checks if the pod is using any emptyDir volume
+func hasEmptyDir(pod apiv1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// evictPod evicts the pod and waits till it is deleted
+// the eviction is retried, if it is blocked by a pod disruption budget
+func evictPod(ctx context.Context, clients clients.ClientSets, pod apiv1.Pod, interval time.Duration) error {
+	eviction := &policyv1beta1.Eviction{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+
+	for {
+		err := clients.KubeClient.CoreV1().Pods(pod.Namespace).Evict(ctx, eviction)
+		switch {
+		case err == nil:
+			return waitForDeletion(ctx, clients, pod, interval)
+		case apierrors.IsNotFound(err):
+			return nil
+		case apierrors.IsTooManyRequests(err):
+			log.Infof("[Drain]: Eviction of %v/%v pod is blocked by the disruption budget, retrying", pod.Namespace, pod.Name)
+		default:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while evicting the pod, eviction is blocked by the disruption budget")
+		case <-time.After(interval):
+		}
+	}
+}
+
+// waitForDeletion waits till the pod is deleted or replaced by a new pod with the same name
+func waitForDeletion(ctx context.Context, clients clients.ClientSets, pod apiv1.Pod, interval time.Duration) error {
+	for {
+		current, err := clients.KubeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, v1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while waiting for the pod deletion")
+		case <-time.After(interval):
+		}
+	}
+}
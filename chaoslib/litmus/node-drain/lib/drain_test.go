@@ -0,0 +1,148 @@
+package lib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var podsResource = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func testPod(name, kind string, emptyDir bool) *apiv1.Pod {
+	pod := &apiv1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+		Spec:       apiv1.PodSpec{NodeName: "node-1"},
+		Status:     apiv1.PodStatus{Phase: apiv1.PodRunning},
+	}
+	if kind != "" {
+		controller := true
+		pod.OwnerReferences = []v1.OwnerReference{{Kind: kind, Name: name + "-owner", Controller: &controller}}
+	}
+	if emptyDir {
+		pod.Spec.Volumes = []apiv1.Volume{{Name: "data", VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}}}}
+	}
+	return pod
+}
+
+// newFakeClients returns the fake clients, which deletes the pods on eviction
+// the evictions are rejected with the given number of disruption budget failures first
+func newFakeClients(budgetFailures int, objects ...runtime.Object) (clients.ClientSets, *fake.Clientset) {
+	kubeClient := fake.NewSimpleClientset(objects...)
+	kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		if budgetFailures > 0 {
+			budgetFailures--
+			return true, nil, apierrors.NewTooManyRequests("cannot evict pod as it would violate the pod's disruption budget", 0)
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1beta1.Eviction)
+		return true, nil, kubeClient.Tracker().Delete(podsResource, eviction.Namespace, eviction.Name)
+	})
+	return clients.ClientSets{KubeClient: kubeClient}, kubeClient
+}
+
+func testOptions() drainOptions {
+	return drainOptions{IgnoreDaemonSets: true, DeleteEmptyDirData: true, Force: true, Timeout: 5 * time.Second, Interval: 10 * time.Millisecond}
+}
+
+func TestDrainEvictsPods(t *testing.T) {
+	mirror := testPod("static", "", false)
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "true"}
+
+	clientSets, kubeClient := newFakeClients(2,
+		&apiv1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}},
+		testPod("app", "ReplicaSet", true),
+		testPod("unmanaged", "", false),
+		testPod("agent", "DaemonSet", false),
+		mirror,
+	)
+
+	require.NoError(t, drain(context.Background(), clientSets, "node-1", testOptions()))
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), "node-1", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable)
+
+	pods, err := kubeClient.CoreV1().Pods("default").List(context.Background(), v1.ListOptions{})
+	require.NoError(t, err)
+	var remaining []string
+	for _, pod := range pods.Items {
+		remaining = append(remaining, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"agent", "static"}, remaining)
+}
+
+func TestDrainRules(t *testing.T) {
+	testCases := map[string]struct {
+		pod    *apiv1.Pod
+		opts   func(*drainOptions)
+		reason string
+	}{
+		"daemonset": {
+			pod:    testPod("agent", "DaemonSet", false),
+			opts:   func(opts *drainOptions) { opts.IgnoreDaemonSets = false },
+			reason: "default/agent is managed by daemonset",
+		},
+		"unmanaged": {
+			pod:    testPod("unmanaged", "", false),
+			opts:   func(opts *drainOptions) { opts.Force = false },
+			reason: "default/unmanaged is not managed by any controller",
+		},
+		"emptyDir": {
+			pod:    testPod("app", "ReplicaSet", true),
+			opts:   func(opts *drainOptions) { opts.DeleteEmptyDirData = false },
+			reason: "default/app is using emptyDir volume",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			clientSets, kubeClient := newFakeClients(0, &apiv1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}}, tc.pod)
+			opts := testOptions()
+			tc.opts(&opts)
+
+			err := drain(context.Background(), clientSets, "node-1", opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.reason)
+
+			_, err = kubeClient.CoreV1().Pods("default").Get(context.Background(), tc.pod.Name, v1.GetOptions{})
+			assert.NoError(t, err, "no pod should be evicted if the drain rules are violated")
+		})
+	}
+}
+
+func TestDrainTimeoutOnDisruptionBudget(t *testing.T) {
+	clientSets, _ := newFakeClients(1000, &apiv1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}}, testPod("app", "ReplicaSet", false))
+	opts := testOptions()
+	opts.Timeout = 100 * time.Millisecond
+
+	err := drain(context.Background(), clientSets, "node-1", opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disruption budget")
+}
+
+func TestUncordon(t *testing.T) {
+	clientSets, kubeClient := newFakeClients(0, &apiv1.Node{ObjectMeta: v1.ObjectMeta{Name: "node-1"}, Spec: apiv1.NodeSpec{Unschedulable: true}})
+
+	require.NoError(t, uncordon(context.Background(), clientSets, "node-1"))
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), "node-1", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable)
+
+	assert.Error(t, uncordon(context.Background(), clientSets, "node-2"))
+}
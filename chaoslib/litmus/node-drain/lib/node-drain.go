@@ -4,11 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"os/signal"
-	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -16,11 +12,14 @@ import (
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
 
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-drain/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -41,23 +40,25 @@ func PrepareNodeDrain(ctx context.Context, experimentsDetails *experimentTypes.E
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	if experimentsDetails.TargetNode == "" {
 		//Select node for kubelet-service-kill
-		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients)
+		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients, chaosDetails)
 		if err != nil {
 			return stacktrace.Propagate(err, "could not get node name")
 		}
@@ -80,19 +81,21 @@ func PrepareNodeDrain(ctx context.Context, experimentsDetails *experimentTypes.E
 	go abortWatcher(experimentsDetails, clients, resultDetails, chaosDetails, eventsDetails)
 
 	// Drain the application node
-	if err := drainNode(ctx, experimentsDetails, clients, chaosDetails); err != nil {
+	injectStart := time.Now()
+	if err := drainNode(ctx, experimentsDetails, clients, resultDetails.Name, chaosDetails); err != nil {
 		log.Info("[Revert]: Reverting chaos because error during draining of node")
-		if uncordonErr := uncordonNode(experimentsDetails, clients, chaosDetails); uncordonErr != nil {
+		if uncordonErr := uncordonNode(experimentsDetails, clients, resultDetails.Name, chaosDetails); uncordonErr != nil {
 			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(uncordonErr).Error())}
 		}
 		return stacktrace.Propagate(err, "could not drain node")
 	}
+	telemetry.RecordInjectionLatency(ctx, "node", injectStart)
 
 	// Verify the status of AUT after reschedule
 	log.Info("[Status]: Verify the status of AUT after reschedule")
-	if err = status.AUTStatusCheck(clients, chaosDetails); err != nil {
+	if err = status.AUTStatusCheck(ctx, clients, chaosDetails); err != nil {
 		log.Info("[Revert]: Reverting chaos because application status check failed")
-		if uncordonErr := uncordonNode(experimentsDetails, clients, chaosDetails); uncordonErr != nil {
+		if uncordonErr := uncordonNode(experimentsDetails, clients, resultDetails.Name, chaosDetails); uncordonErr != nil {
 			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(uncordonErr).Error())}
 		}
 		return err
@@ -101,9 +104,9 @@ func PrepareNodeDrain(ctx context.Context, experimentsDetails *experimentTypes.E
 	// Verify the status of Auxiliary Applications after reschedule
 	if experimentsDetails.AuxiliaryAppInfo != "" {
 		log.Info("[Status]: Verify that the Auxiliary Applications are running")
-		if err = status.CheckAuxiliaryApplicationStatus(experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err = status.CheckAuxiliaryApplicationStatus(ctx, experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			log.Info("[Revert]: Reverting chaos because auxiliary application status check failed")
-			if uncordonErr := uncordonNode(experimentsDetails, clients, chaosDetails); uncordonErr != nil {
+			if uncordonErr := uncordonNode(experimentsDetails, clients, resultDetails.Name, chaosDetails); uncordonErr != nil {
 				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(uncordonErr).Error())}
 			}
 			return err
@@ -112,47 +115,74 @@ func PrepareNodeDrain(ctx context.Context, experimentsDetails *experimentTypes.E
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDuration(experimentsDetails.ChaosDuration); err != nil {
+		return err
+	}
+
+	// waiting for the approval before reverting the chaos, if the revert gate is enabled
+	// the chaos is reverted even if the approval fails, so that it isn't left behind
+	if err := approval.WaitForApproval(ctx, types.ApprovalGateRevert, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		log.Errorf("Approval to revert the chaos failed, reverting the chaos, err: %v", err)
+	}
 
 	log.Info("[Chaos]: Stopping the experiment")
 
 	// Uncordon the application node
-	if err := uncordonNode(experimentsDetails, clients, chaosDetails); err != nil {
+	revertStart := time.Now()
+	if err := uncordonNode(experimentsDetails, clients, resultDetails.Name, chaosDetails); err != nil {
 		return stacktrace.Propagate(err, "could not uncordon the target node")
 	}
+	telemetry.RecordRevertLatency(ctx, "node", revertStart)
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // drainNode drain the target node
-func drainNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func drainNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string, chaosDetails *types.ChaosDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectNodeDrainFault")
 	defer span.End()
 
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		log.Infof("[Inject]: Draining the %v node", experimentsDetails.TargetNode)
 
-		command := exec.Command("kubectl", "drain", experimentsDetails.TargetNode, "--ignore-daemonsets", "--delete-emptydir-data", "--force", "--timeout", strconv.Itoa(experimentsDetails.ChaosDuration)+"s")
-		if err := common.RunCLICommands(command, "", fmt.Sprintf("{node: %s}", experimentsDetails.TargetNode), "failed to drain the target node", cerrors.ErrorTypeChaosInject); err != nil {
+		// recording the revert details inside the journal before injecting the chaos
+		entry := journal.NewEntry(experimentsDetails.ExperimentName, "node", experimentsDetails.TargetNode, "").
+			WithRevertAction(journal.ActionUncordonNode, map[string]string{"node": experimentsDetails.TargetNode})
+		if err := journal.Record(entry, chaosDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not record the journal entry")
+		}
+
+		opts := drainOptions{
+			IgnoreDaemonSets:   true,
+			DeleteEmptyDirData: true,
+			Force:              true,
+			Timeout:            time.Duration(experimentsDetails.ChaosDuration) * time.Second,
+			Interval:           time.Duration(experimentsDetails.Delay) * time.Second,
+		}
+		if err := drain(ctx, clients, experimentsDetails.TargetNode, opts); err != nil {
 			return err
 		}
 
 		common.SetTargets(experimentsDetails.TargetNode, "injected", "node", chaosDetails)
+		records.Injected(records.FromJournalEntry(entry), resultName, chaosDetails, clients)
+		events.MarkNodeUnderChaos(ctx, clients, chaosDetails, experimentsDetails.TargetNode)
 
 		return retry.
 			Times(uint(experimentsDetails.Timeout / experimentsDetails.Delay)).
 			Wait(time.Duration(experimentsDetails.Delay) * time.Second).
 			Try(func(attempt uint) error {
-				nodeSpec, err := clients.KubeClient.CoreV1().Nodes().Get(context.Background(), experimentsDetails.TargetNode, v1.GetOptions{})
+				nodeSpec, err := clients.KubeClient.CoreV1().Nodes().Get(ctx, experimentsDetails.TargetNode, v1.GetOptions{})
 				if err != nil {
 					return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{node: %s}", experimentsDetails.TargetNode), Reason: err.Error()}
 				}
@@ -166,7 +196,7 @@ func drainNode(ctx context.Context, experimentsDetails *experimentTypes.Experime
 }
 
 // uncordonNode uncordon the application node
-func uncordonNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func uncordonNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string, chaosDetails *types.ChaosDetails) error {
 
 	targetNodes := strings.Split(experimentsDetails.TargetNode, ",")
 	for _, targetNode := range targetNodes {
@@ -177,6 +207,9 @@ func uncordonNode(experimentsDetails *experimentTypes.ExperimentDetails, clients
 			if apierrors.IsNotFound(err) {
 				log.Infof("[Info]: The %v node is no longer exist, skip uncordon the node", targetNode)
 				common.SetTargets(targetNode, "noLongerExist", "node", chaosDetails)
+				if err := journal.MarkReverted(journal.EntryID("node", "", targetNode, ""), chaosDetails, clients); err != nil {
+					return stacktrace.Propagate(err, "could not update the journal")
+				}
 				continue
 			} else {
 				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{node: %s}", targetNode), Reason: err.Error()}
@@ -184,11 +217,16 @@ func uncordonNode(experimentsDetails *experimentTypes.ExperimentDetails, clients
 		}
 
 		log.Infof("[Recover]: Uncordon the %v node", targetNode)
-		command := exec.Command("kubectl", "uncordon", targetNode)
-		if err := common.RunCLICommands(command, "", fmt.Sprintf("{node: %s}", targetNode), "failed to uncordon the target node", cerrors.ErrorTypeChaosInject); err != nil {
+		if err := uncordon(context.Background(), clients, targetNode); err != nil {
+			records.Reverted(journal.EntryID("node", "", targetNode, ""), resultName, err, chaosDetails, clients)
 			return err
 		}
+		records.Reverted(journal.EntryID("node", "", targetNode, ""), resultName, nil, chaosDetails, clients)
+		if err := journal.MarkReverted(journal.EntryID("node", "", targetNode, ""), chaosDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not update the journal")
+		}
 		common.SetTargets(targetNode, "reverted", "node", chaosDetails)
+		events.UnmarkNodeUnderChaos(context.Background(), clients, chaosDetails, targetNode)
 	}
 
 	return retry.
@@ -215,6 +253,9 @@ func uncordonNode(experimentsDetails *experimentTypes.ExperimentDetails, clients
 
 // abortWatcher continuously watch for the abort signals
 func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	// waiting till the abort signal received
 	<-abort
 
@@ -223,12 +264,13 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients
 	// retry thrice for the chaos revert
 	retry := 3
 	for retry > 0 {
-		if err := uncordonNode(experimentsDetails, clients, chaosDetails); err != nil {
+		if err := uncordonNode(experimentsDetails, clients, resultDetails.Name, chaosDetails); err != nil {
 			log.Errorf("Unable to uncordon the node, err: %v", err)
 		}
 		retry--
 		time.Sleep(1 * time.Second)
 	}
 	log.Info("Chaos Revert Completed")
-	os.Exit(0)
+	revertDone()
+	common.Exit(0)
 }
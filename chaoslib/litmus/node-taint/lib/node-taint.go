@@ -4,21 +4,23 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-taint/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -38,23 +40,25 @@ func PrepareNodeTaint(ctx context.Context, experimentsDetails *experimentTypes.E
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	if experimentsDetails.TargetNode == "" {
 		//Select node for kubelet-service-kill
-		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients)
+		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients, chaosDetails)
 		if err != nil {
 			return stacktrace.Propagate(err, "could not get node name")
 		}
@@ -74,18 +78,20 @@ func PrepareNodeTaint(ctx context.Context, experimentsDetails *experimentTypes.E
 	}
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, clients, resultDetails, chaosDetails, eventsDetails)
+	go abortWatcher(ctx, experimentsDetails, clients, resultDetails, chaosDetails, eventsDetails)
 
 	// taint the application node
-	if err := taintNode(ctx, experimentsDetails, clients, chaosDetails); err != nil {
+	injectStart := time.Now()
+	if err := taintNode(ctx, experimentsDetails, clients, resultDetails.Name, chaosDetails); err != nil {
 		return stacktrace.Propagate(err, "could not taint node")
 	}
+	telemetry.RecordInjectionLatency(ctx, "node", injectStart)
 
 	// Verify the status of AUT after reschedule
 	log.Info("[Status]: Verify the status of AUT after reschedule")
-	if err = status.AUTStatusCheck(clients, chaosDetails); err != nil {
+	if err = status.AUTStatusCheck(ctx, clients, chaosDetails); err != nil {
 		log.Info("[Revert]: Reverting chaos because application status check failed")
-		if taintErr := removeTaintFromNode(experimentsDetails, clients, chaosDetails); taintErr != nil {
+		if taintErr := removeTaintFromNode(ctx, experimentsDetails, clients, resultDetails.Name, chaosDetails); taintErr != nil {
 			return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(taintErr).Error())}
 		}
 		return err
@@ -93,9 +99,9 @@ func PrepareNodeTaint(ctx context.Context, experimentsDetails *experimentTypes.E
 
 	if experimentsDetails.AuxiliaryAppInfo != "" {
 		log.Info("[Status]: Verify that the Auxiliary Applications are running")
-		if err = status.CheckAuxiliaryApplicationStatus(experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err = status.CheckAuxiliaryApplicationStatus(ctx, experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			log.Info("[Revert]: Reverting chaos because auxiliary application status check failed")
-			if taintErr := removeTaintFromNode(experimentsDetails, clients, chaosDetails); taintErr != nil {
+			if taintErr := removeTaintFromNode(ctx, experimentsDetails, clients, resultDetails.Name, chaosDetails); taintErr != nil {
 				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(taintErr).Error())}
 			}
 			return err
@@ -104,35 +110,48 @@ func PrepareNodeTaint(ctx context.Context, experimentsDetails *experimentTypes.E
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDuration(experimentsDetails.ChaosDuration); err != nil {
+		return err
+	}
+
+	// waiting for the approval before reverting the chaos, if the revert gate is enabled
+	// the chaos is reverted even if the approval fails, so that it isn't left behind
+	if err := approval.WaitForApproval(ctx, types.ApprovalGateRevert, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		log.Errorf("Approval to revert the chaos failed, reverting the chaos, err: %v", err)
+	}
 
 	log.Info("[Chaos]: Stopping the experiment")
 
 	// remove taint from the application node
-	if err := removeTaintFromNode(experimentsDetails, clients, chaosDetails); err != nil {
+	revertStart := time.Now()
+	if err := removeTaintFromNode(ctx, experimentsDetails, clients, resultDetails.Name, chaosDetails); err != nil {
 		return stacktrace.Propagate(err, "could not remove taint from node")
 	}
+	telemetry.RecordRevertLatency(ctx, "node", revertStart)
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // taintNode taint the application node
-func taintNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
-	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectNodeTaintFault")
-	defer span.End()
+func taintNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string, chaosDetails *types.ChaosDetails) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "InjectNodeTaintFault", attribute.String("k8s.node.name", experimentsDetails.TargetNode))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	// get the taint labels & effect
 	taintKey, taintValue, taintEffect := getTaintDetails(experimentsDetails)
 
-	log.Infof("Add %v taints to the %v node", taintKey+"="+taintValue+":"+taintEffect, experimentsDetails.TargetNode)
+	ctx = log.ContextWithTarget(ctx, experimentsDetails.TargetNode)
+	log.InfofWithContext(ctx, "Add %v taints to the %v node", taintKey+"="+taintValue+":"+taintEffect, experimentsDetails.TargetNode)
 
 	// get the node details
-	node, err := clients.KubeClient.CoreV1().Nodes().Get(context.Background(), experimentsDetails.TargetNode, v1.GetOptions{})
+	node, err := clients.KubeClient.CoreV1().Nodes().Get(ctx, experimentsDetails.TargetNode, v1.GetOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{nodeName: %s}", experimentsDetails.TargetNode), Reason: err.Error()}
 	}
@@ -149,37 +168,52 @@ func taintNode(ctx context.Context, experimentsDetails *experimentTypes.Experime
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		if !tainted {
+			// recording the revert details inside the journal before injecting the chaos
+			entry := journal.NewEntry(experimentsDetails.ExperimentName, "node", node.Name, "").
+				WithParam("taint", taintKey+"="+taintValue+":"+taintEffect).
+				WithRevertAction(journal.ActionRemoveTaint, map[string]string{"node": node.Name, "key": taintKey, "value": taintValue, "effect": taintEffect})
+			if err := journal.Record(entry, chaosDetails, clients); err != nil {
+				return stacktrace.Propagate(err, "could not record the journal entry")
+			}
+
 			node.Spec.Taints = append(node.Spec.Taints, apiv1.Taint{
 				Key:    taintKey,
 				Value:  taintValue,
 				Effect: apiv1.TaintEffect(taintEffect),
 			})
 
-			_, err := clients.KubeClient.CoreV1().Nodes().Update(context.Background(), node, v1.UpdateOptions{})
+			_, err := clients.KubeClient.CoreV1().Nodes().Update(ctx, node, v1.UpdateOptions{})
 			if err != nil {
 				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{nodeName: %s}", node.Name), Reason: fmt.Sprintf("failed to add taints: %s", err.Error())}
 			}
+			records.Injected(records.FromJournalEntry(entry), resultName, chaosDetails, clients)
 		}
 
 		common.SetTargets(node.Name, "injected", "node", chaosDetails)
+		events.MarkNodeUnderChaos(ctx, clients, chaosDetails, node.Name)
 
-		log.Infof("Successfully added taint in %v node", experimentsDetails.TargetNode)
+		log.InfofWithContext(ctx, "Successfully added taint in %v node", experimentsDetails.TargetNode)
 	}
 	return nil
 }
 
 // removeTaintFromNode remove the taint from the application node
-func removeTaintFromNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func removeTaintFromNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultName string, chaosDetails *types.ChaosDetails) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "RevertNodeTaintFault", attribute.String("k8s.node.name", experimentsDetails.TargetNode))
+	defer func() { telemetry.EndSpan(span, err) }()
+	defer func() {
+		records.Reverted(journal.EntryID("node", "", experimentsDetails.TargetNode, ""), resultName, err, chaosDetails, clients)
+	}()
 
 	// Get the taint key
 	taintLabel := strings.Split(experimentsDetails.Taints, ":")
 	taintKey := strings.Split(taintLabel[0], "=")[0]
 
 	// get the node details
-	node, err := clients.KubeClient.CoreV1().Nodes().Get(context.Background(), experimentsDetails.TargetNode, v1.GetOptions{})
+	node, err := clients.KubeClient.CoreV1().Nodes().Get(ctx, experimentsDetails.TargetNode, v1.GetOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{nodeName: %s}", experimentsDetails.TargetNode), Reason: err.Error()}
 	}
@@ -202,13 +236,18 @@ func removeTaintFromNode(experimentsDetails *experimentTypes.ExperimentDetails,
 			}
 		}
 		node.Spec.Taints = newTaints
-		updatedNodeWithTaint, err := clients.KubeClient.CoreV1().Nodes().Update(context.Background(), node, v1.UpdateOptions{})
+		updatedNodeWithTaint, err := clients.KubeClient.CoreV1().Nodes().Update(ctx, node, v1.UpdateOptions{})
 		if err != nil || updatedNodeWithTaint == nil {
 			return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{nodeName: %s}", node.Name), Reason: fmt.Sprintf("failed to remove taints: %s", err.Error())}
 		}
 	}
 
+	if err := journal.MarkReverted(journal.EntryID("node", "", node.Name, ""), chaosDetails, clients); err != nil {
+		return stacktrace.Propagate(err, "could not update the journal")
+	}
+
 	common.SetTargets(node.Name, "reverted", "node", chaosDetails)
+	events.UnmarkNodeUnderChaos(ctx, clients, chaosDetails, node.Name)
 
 	log.Infof("Successfully removed taint from the %v node", node.Name)
 	return nil
@@ -238,7 +277,10 @@ func getTaintDetails(experimentsDetails *experimentTypes.ExperimentDetails) (str
 }
 
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
+func abortWatcher(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	// waiting till the abort signal received
 	<-abort
 
@@ -247,12 +289,13 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients
 	// retry thrice for the chaos revert
 	retry := 3
 	for retry > 0 {
-		if err := removeTaintFromNode(experimentsDetails, clients, chaosDetails); err != nil {
+		if err := removeTaintFromNode(ctx, experimentsDetails, clients, resultDetails.Name, chaosDetails); err != nil {
 			log.Errorf("Unable to untaint node, err: %v", err)
 		}
 		retry--
 		time.Sleep(1 * time.Second)
 	}
 	log.Info("Chaos Revert Completed")
-	os.Exit(0)
+	revertDone()
+	common.Exit(0)
 }
@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -42,7 +40,9 @@ func PreparePodAutoscaler(ctx context.Context, experimentsDetails *experimentTyp
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// initialise the resource clients
@@ -111,7 +111,9 @@ func PreparePodAutoscaler(ctx context.Context, experimentsDetails *experimentTyp
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -259,7 +261,9 @@ func deploymentStatusCheck(ctx context.Context, experimentsDetails *experimentTy
 	duration := int(time.Since(ChaosStartTimeStamp).Seconds())
 	if duration < experimentsDetails.ChaosDuration {
 		log.Info("[Wait]: Waiting for completion of chaos duration")
-		time.Sleep(time.Duration(experimentsDetails.ChaosDuration-duration) * time.Second)
+		if err := common.WaitForDuration(experimentsDetails.ChaosDuration - duration); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -304,7 +308,9 @@ func statefulsetStatusCheck(ctx context.Context, experimentsDetails *experimentT
 	duration := int(time.Since(ChaosStartTimeStamp).Seconds())
 	if duration < experimentsDetails.ChaosDuration {
 		log.Info("[Wait]: Waiting for completion of chaos duration")
-		time.Sleep(time.Duration(experimentsDetails.ChaosDuration-duration) * time.Second)
+		if err := common.WaitForDuration(experimentsDetails.ChaosDuration - duration); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -406,11 +412,13 @@ func int32Ptr(i int32) *int32 { return &i }
 
 // abortPodAutoScalerChaos go routine will continuously watch for the abort signal for the entire chaos duration and generate the required events and result
 func abortPodAutoScalerChaos(appsUnderTest []experimentTypes.ApplicationUnderTest, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
 
 	// waiting till the abort signal received
 	<-signChan
@@ -440,5 +448,6 @@ func abortPodAutoScalerChaos(appsUnderTest []experimentTypes.ApplicationUnderTes
 	}
 	log.Info("[Chaos]: Revert Completed")
 
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -35,13 +33,15 @@ func PrepareCPUExecStress(ctx context.Context, experimentsDetails *experimentTyp
 	defer span.End()
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	//Starting the CPU stress experiment
 	if err := experimentCPU(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
@@ -50,7 +50,9 @@ func PrepareCPUExecStress(ctx context.Context, experimentsDetails *experimentTyp
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -87,20 +89,11 @@ func experimentCPU(ctx context.Context, experimentsDetails *experimentTypes.Expe
 	log.Infof("Target pods list for chaos, %v", podNames)
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
-	}
-
-	return nil
+	return common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, corev1.PodList{Items: targets}, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, corev1.PodList{Items: targets}, clients, resultDetails, eventsDetails, chaosDetails)
+	})
 }
 
 // injectChaosInSerialMode stressed the cpu of all target application serially (one by one)
@@ -117,8 +110,11 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -127,7 +123,8 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
 		time.Sleep(10 * time.Second)
-		os.Exit(0)
+		revertDone()
+		common.Exit(0)
 	default:
 		for _, pod := range targetPodList.Items {
 
@@ -156,6 +153,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			}
 
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
+			events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 
 			log.Infof("[Chaos]:Waiting for: %vs", experimentsDetails.ChaosDuration)
 
@@ -176,7 +174,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 					}
 				case <-signChan:
 					log.Info("[Chaos]: Revert Started")
-					if err := killStressCPUSerial(experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
+					if err := killStressCPUSerial(ctx, experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
 						log.Errorf("Error in Kill stress after abortion, err: %v", err)
 					}
 					// updating the chaosresult after stopped
@@ -187,14 +185,15 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 						log.Errorf("failed to update chaos result %s", err.Error())
 					}
 					log.Info("[Chaos]: Revert Completed")
-					os.Exit(1)
+					revertDone()
+					common.Exit(1)
 				case <-endTime:
 					log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 					endTime = nil
 					break loop
 				}
 			}
-			if err := killStressCPUSerial(experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
+			if err := killStressCPUSerial(ctx, experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not revert cpu stress")
 			}
 		}
@@ -219,8 +218,11 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -229,9 +231,13 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
 		time.Sleep(10 * time.Second)
-		os.Exit(0)
+		revertDone()
+		common.Exit(0)
 	default:
 		for _, pod := range targetPodList.Items {
+			if err := common.WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
 
 			if experimentsDetails.EngineName != "" {
 				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
@@ -252,6 +258,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 				go stressCPU(experimentsDetails, pod.Name, pod.Namespace, clients, stressErr)
 			}
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
+			events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 		}
 	}
 
@@ -274,7 +281,7 @@ loop:
 			}
 		case <-signChan:
 			log.Info("[Chaos]: Revert Started")
-			if err := killStressCPUParallel(experimentsDetails, targetPodList, clients, chaosDetails); err != nil {
+			if err := killStressCPUParallel(ctx, experimentsDetails, targetPodList, clients, chaosDetails); err != nil {
 				log.Errorf("Error in Kill stress after abortion, err: %v", err)
 			}
 			// updating the chaosresult after stopped
@@ -285,20 +292,21 @@ loop:
 				log.Errorf("failed to update chaos result %s", err.Error())
 			}
 			log.Info("[Chaos]: Revert Completed")
-			os.Exit(1)
+			revertDone()
+			common.Exit(1)
 		case <-endTime:
 			log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 			endTime = nil
 			break loop
 		}
 	}
-	return killStressCPUParallel(experimentsDetails, targetPodList, clients, chaosDetails)
+	return killStressCPUParallel(ctx, experimentsDetails, targetPodList, clients, chaosDetails)
 }
 
 // killStressCPUSerial function to kill a stress process running inside target container
 //
 //	Triggered by either timeout of chaos duration or termination of the experiment
-func killStressCPUSerial(experimentsDetails *experimentTypes.ExperimentDetails, podName, ns string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func killStressCPUSerial(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, podName, ns string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	// It will contain all the pod & container details required for exec command
 	execCommandDetails := litmusexec.PodDetails{}
 
@@ -310,15 +318,16 @@ func killStressCPUSerial(experimentsDetails *experimentTypes.ExperimentDetails,
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{podName: %s, namespace: %s}", podName, ns), Reason: fmt.Sprintf("failed to revert chaos: %s", out)}
 	}
 	common.SetTargets(podName, "reverted", "pod", chaosDetails)
+	events.UnmarkPodUnderChaos(ctx, clients, chaosDetails, podName, ns)
 	return nil
 }
 
 // killStressCPUParallel function to kill all the stress process running inside target container
 // Triggered by either timeout of chaos duration or termination of the experiment
-func killStressCPUParallel(experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func killStressCPUParallel(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	var errList []string
 	for _, pod := range targetPodList.Items {
-		if err := killStressCPUSerial(experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
+		if err := killStressCPUSerial(ctx, experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
 			errList = append(errList, err.Error())
 		}
 	}
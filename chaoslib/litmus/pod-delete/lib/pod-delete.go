@@ -4,15 +4,16 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-delete/types"
+	"github.com/litmuschaos/litmus-go/pkg/intensity"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -21,6 +22,8 @@ import (
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,51 +35,57 @@ func PreparePodDelete(ctx context.Context, experimentsDetails *experimentTypes.E
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//set up the tunables if provided in range
-	SetChaosTunables(experimentsDetails)
+	SetChaosTunables(experimentsDetails, chaosDetails)
 
-	log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
-		"PodsAffectedPerc": experimentsDetails.PodsAffectedPerc,
-		"Sequence":         experimentsDetails.Sequence,
-	})
+	logExperimentFields(experimentsDetails)
 
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err := injectChaosInSerialMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err := injectChaosInParallelMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
+	profile, err := intensity.ParseProfile(experimentsDetails.IntensitySteps, experimentsDetails.StepDuration, experimentsDetails.ChaosDuration, getSteppedParameters(experimentsDetails))
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get intensity steps")
+	}
+
+	// the probes during chaos are started once, only the step probes are evaluated in every step
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return err
 		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	}
+
+	if profile == nil {
+		err = injectChaos(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails)
+	} else {
+		// the pods are deleted for every step, with the parameter set to the value of the step
+		chaosDuration := experimentsDetails.ChaosDuration
+		err = profile.Run(ctx, chaosDetails, clients, resultDetails, func(ctx context.Context, step intensity.Step) error {
+			experimentsDetails.ChaosDuration = step.Duration
+			logExperimentFields(experimentsDetails)
+			return injectChaos(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails)
+		})
+		experimentsDetails.ChaosDuration = chaosDuration
+	}
+	if err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
-	}
-	return nil
-}
-
-// injectChaosInSerialMode delete the target application pods serial mode(one by one)
-func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
-	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectPodDeleteFaultInSerialMode")
-	defer span.End()
-
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	GracePeriod := int64(0)
+// injectChaos deletes the target pods for the chaos duration, as per the sequence
+// the target pods are derived again in every iteration, as the deleted pods are replaced by the new pods
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
 	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 	ChaosStartTimeStamp := time.Now()
 	duration := int(time.Since(ChaosStartTimeStamp).Seconds())
@@ -95,7 +104,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		// deriving the parent name of the target resources
 		for _, pod := range targetPodList.Items {
-			kind, parentName, err := workloads.GetPodOwnerTypeAndName(&pod, clients.DynamicClient)
+			kind, parentName, err := workloads.GetPodOwnerTypeAndName(&pod, clients)
 			if err != nil {
 				return stacktrace.Propagate(err, "could not get pod owner name and kind")
 			}
@@ -111,157 +120,171 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 		}
 
-		//Deleting the application pod
-		for _, pod := range targetPodList.Items {
+		if err := common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, pods []apiv1.Pod) error {
+			return injectChaosInSerialMode(ctx, experimentsDetails, pods, clients, chaosDetails, resultDetails)
+		}, func(ctx context.Context, pods []apiv1.Pod) error {
+			return injectChaosInParallelMode(ctx, experimentsDetails, pods, clients, chaosDetails, resultDetails)
+		}); err != nil {
+			return err
+		}
+		duration = int(time.Since(ChaosStartTimeStamp).Seconds())
+	}
+	log.Infof("[Completion]: %v chaos is done", experimentsDetails.ExperimentName)
 
-			log.InfoWithValues("[Info]: Killing the following pods", logrus.Fields{
-				"PodName": pod.Name})
+	return nil
+}
 
-			if experimentsDetails.Force {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{GracePeriodSeconds: &GracePeriod})
-			} else {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{})
-			}
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to delete the target pod: %s", err.Error())}
-			}
+// injectChaosInSerialMode delete the target application pods serial mode(one by one)
+func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, pods []apiv1.Pod, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectPodDeleteFaultInSerialMode")
+	defer span.End()
 
-			switch chaosDetails.Randomness {
-			case true:
-				if err := common.RandomInterval(experimentsDetails.ChaosInterval); err != nil {
-					return stacktrace.Propagate(err, "could not get random chaos interval")
-				}
-			default:
-				//Waiting for the chaos interval after chaos injection
-				if experimentsDetails.ChaosInterval != "" {
-					log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaosInterval)
-					waitTime, _ := strconv.Atoi(experimentsDetails.ChaosInterval)
-					common.WaitForDuration(waitTime)
-				}
-			}
+	//Deleting the application pod
+	for _, pod := range pods {
 
-			//Verify the status of pod after the chaos injection
-			log.Info("[Status]: Verification for the recreation of application pod")
-			for _, parent := range chaosDetails.ParentsResources {
-				target := types.AppDetails{
-					Names:     []string{parent.Name},
-					Kind:      parent.Kind,
-					Namespace: parent.Namespace,
-				}
-				if err = status.CheckUnTerminatedPodStatusesByWorkloadName(target, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
-					return stacktrace.Propagate(err, "could not check pod statuses by workload names")
-				}
-			}
+		log.InfoWithValues("[Info]: Killing the following pods", logrus.Fields{
+			"PodName": pod.Name})
+
+		refs, err := deletePod(ctx, experimentsDetails, clients, chaosDetails, pod)
+		if err != nil {
+			return err
+		}
+		records.Injected(getRecord(experimentsDetails, pod), resultDetails.Name, chaosDetails, clients)
 
-			duration = int(time.Since(ChaosStartTimeStamp).Seconds())
+		if err := waitForChaosInterval(experimentsDetails, chaosDetails); err != nil {
+			return err
 		}
 
+		//Verify the status of pod after the chaos injection
+		err = verifyPodRecreation(ctx, experimentsDetails, clients, chaosDetails)
+		events.UnmarkUnderChaos(ctx, clients, chaosDetails, refs)
+		if err != nil {
+			return err
+		}
 	}
-	log.Infof("[Completion]: %v chaos is done", experimentsDetails.ExperimentName)
-
 	return nil
-
 }
 
 // injectChaosInParallelMode delete the target application pods in parallel mode (all at once)
-func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
+func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, pods []apiv1.Pod, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectPodDeleteFaultInParallelMode")
 	defer span.End()
 
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+	//Deleting the application pod
+	var refs []events.TargetReference
+	for _, pod := range pods {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, refs)
 			return err
 		}
-	}
 
-	GracePeriod := int64(0)
-	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
-	ChaosStartTimeStamp := time.Now()
-	duration := int(time.Since(ChaosStartTimeStamp).Seconds())
+		log.InfoWithValues("[Info]: Killing the following pods", logrus.Fields{
+			"PodName": pod.Name})
 
-	for duration < experimentsDetails.ChaosDuration {
-		// Get the target pod details for the chaos execution
-		// if the target pod is not defined it will derive the random target pod list using pod affected percentage
-		if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail == nil {
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "please provide one of the appLabel or TARGET_PODS"}
-		}
-		targetPodList, err := common.GetTargetPods(experimentsDetails.NodeLabel, experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
+		podRefs, err := deletePod(ctx, experimentsDetails, clients, chaosDetails, pod)
 		if err != nil {
-			return stacktrace.Propagate(err, "could not get target pods")
-		}
-
-		// deriving the parent name of the target resources
-		for _, pod := range targetPodList.Items {
-			kind, parentName, err := workloads.GetPodOwnerTypeAndName(&pod, clients.DynamicClient)
-			if err != nil {
-				return stacktrace.Propagate(err, "could not get pod owner name and kind")
-			}
-			common.SetParentName(parentName, kind, pod.Namespace, chaosDetails)
-		}
-		for _, target := range chaosDetails.ParentsResources {
-			common.SetTargets(target.Name, "targeted", target.Kind, chaosDetails)
-		}
-
-		if experimentsDetails.EngineName != "" {
-			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on application pod"
-			types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, refs)
+			return err
 		}
+		refs = append(refs, podRefs...)
+		records.Injected(getRecord(experimentsDetails, pod), resultDetails.Name, chaosDetails, clients)
+	}
 
-		//Deleting the application pod
-		for _, pod := range targetPodList.Items {
+	if err := waitForChaosInterval(experimentsDetails, chaosDetails); err != nil {
+		return err
+	}
 
-			log.InfoWithValues("[Info]: Killing the following pods", logrus.Fields{
-				"PodName": pod.Name})
+	//Verify the status of pod after the chaos injection
+	err := verifyPodRecreation(ctx, experimentsDetails, clients, chaosDetails)
+	events.UnmarkUnderChaos(ctx, clients, chaosDetails, refs)
+	return err
+}
 
-			if experimentsDetails.Force {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{GracePeriodSeconds: &GracePeriod})
-			} else {
-				err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{})
-			}
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to delete the target pod: %s", err.Error())}
-			}
+// waitForChaosInterval waits for the chaos interval after the chaos injection
+func waitForChaosInterval(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) error {
+	switch chaosDetails.Randomness {
+	case true:
+		if err := common.RandomInterval(experimentsDetails.ChaosInterval, chaosDetails); err != nil {
+			return stacktrace.Propagate(err, "could not get random chaos interval")
 		}
-
-		switch chaosDetails.Randomness {
-		case true:
-			if err := common.RandomInterval(experimentsDetails.ChaosInterval); err != nil {
-				return stacktrace.Propagate(err, "could not get random chaos interval")
-			}
-		default:
-			//Waiting for the chaos interval after chaos injection
-			if experimentsDetails.ChaosInterval != "" {
-				log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaosInterval)
-				waitTime, _ := strconv.Atoi(experimentsDetails.ChaosInterval)
-				common.WaitForDuration(waitTime)
+	default:
+		//Waiting for the chaos interval after chaos injection
+		if experimentsDetails.ChaosInterval != "" {
+			log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaosInterval)
+			waitTime, _ := strconv.Atoi(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(waitTime); err != nil {
+				return err
 			}
 		}
+	}
+	return nil
+}
 
-		//Verify the status of pod after the chaos injection
-		log.Info("[Status]: Verification for the recreation of application pod")
-		for _, parent := range chaosDetails.ParentsResources {
-			target := types.AppDetails{
-				Names:     []string{parent.Name},
-				Kind:      parent.Kind,
-				Namespace: parent.Namespace,
-			}
-			if err = status.CheckUnTerminatedPodStatusesByWorkloadName(target, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
-				return stacktrace.Propagate(err, "could not check pod statuses by workload names")
-			}
+// verifyPodRecreation verifies that the pods of the parent workloads are recreated after the chaos injection
+func verifyPodRecreation(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	log.Info("[Status]: Verification for the recreation of application pod")
+	for _, parent := range chaosDetails.ParentsResources {
+		target := types.AppDetails{
+			Names:     []string{parent.Name},
+			Kind:      parent.Kind,
+			Namespace: parent.Namespace,
 		}
-		duration = int(time.Since(ChaosStartTimeStamp).Seconds())
+		if err := status.CheckUnTerminatedPodStatusesByWorkloadName(ctx, target, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+			return stacktrace.Propagate(err, "could not check pod statuses by workload names")
+		}
+	}
+	return nil
+}
+
+// deletePod deletes the target pod, honouring the force option
+// it marks the pod, its owners and its node as under chaos and returns their references
+func deletePod(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, pod apiv1.Pod) (_ []events.TargetReference, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "DeleteTargetPod", attribute.String("k8s.pod.name", pod.Name), attribute.String("k8s.namespace.name", pod.Namespace))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	refs := events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
+	injectStart := time.Now()
+	if experimentsDetails.Force {
+		GracePeriod := int64(0)
+		err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, v1.DeleteOptions{GracePeriodSeconds: &GracePeriod})
+	} else {
+		err = clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, v1.DeleteOptions{})
+	}
+	if err != nil {
+		events.UnmarkUnderChaos(ctx, clients, chaosDetails, refs)
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to delete the target pod: %s", err.Error())}
 	}
+	telemetry.RecordInjectionLatency(ctx, "pod", injectStart)
+	return refs, nil
+}
 
-	log.Infof("[Completion]: %v chaos is done", experimentsDetails.ExperimentName)
+// getRecord returns the record of the deleted pod, the pod-delete chaos doesn't need a revert
+func getRecord(experimentsDetails *experimentTypes.ExperimentDetails, pod apiv1.Pod) *records.Record {
+	return records.NewRecord(experimentsDetails.ExperimentName, "pod", pod.Name, pod.Namespace).
+		WithParam("force", strconv.FormatBool(experimentsDetails.Force)).
+		WithParam("node", pod.Spec.NodeName).
+		WithoutRevert()
+}
 
-	return nil
+// getSteppedParameters returns the parameters of the pod-delete chaos, which can be stepped
+func getSteppedParameters(experimentsDetails *experimentTypes.ExperimentDetails) intensity.Parameters {
+	return intensity.Parameters{
+		"PODS_AFFECTED_PERC": intensity.StringParameter(&experimentsDetails.PodsAffectedPerc),
+		"CHAOS_INTERVAL":     intensity.StringParameter(&experimentsDetails.ChaosInterval),
+	}
+}
+
+// logExperimentFields logs the chaos tunables
+func logExperimentFields(experimentsDetails *experimentTypes.ExperimentDetails) {
+	log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
+		"PodsAffectedPerc": experimentsDetails.PodsAffectedPerc,
+		"Sequence":         experimentsDetails.Sequence,
+	})
 }
 
 // SetChaosTunables will setup a random value within a given range of values
 // If the value is not provided in range it'll setup the initial provided value.
-func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
-	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc)
-	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
+func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc, chaosDetails)
+	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence, chaosDetails)
 }
@@ -10,15 +10,14 @@ import (
 	"go.opentelemetry.io/otel"
 	"os"
 	"os/exec"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-dns-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -51,10 +50,10 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 	// injectAbort channel is used to transmit signal notifications.
 	injectAbort = make(chan os.Signal, 1)
 
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(injectAbort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(injectAbort)
 
 	//Fetching all the ENV passed for the helper pod
 	log.Info("[PreReq]: Getting the ENV variables")
@@ -69,7 +68,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	if err := preparePodDNSChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+	if err := preparePodDNSChaos(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
 		// update failstep inside chaosresult
 		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
 			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
@@ -80,7 +79,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 }
 
 // preparePodDNSChaos contains the preparation steps before chaos injection
-func preparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func preparePodDNSChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	targetList, err := common.ParseTargets(chaosDetails.ChaosPodName)
 	if err != nil {
@@ -96,6 +95,9 @@ func preparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 			TargetContainer: t.TargetContainer,
 			Source:          chaosDetails.ChaosPodName,
 		}
+		if td.References, err = events.GetPodReferences(ctx, clients, td.Name, td.Namespace); err != nil {
+			log.Warnf("[Events]: Unable to get the references of %v pod, err: %v", td.Name, err)
+		}
 
 		td.ContainerId, err = common.GetContainerID(td.Namespace, td.Name, td.TargetContainer, clients, td.Source)
 		if err != nil {
@@ -111,24 +113,31 @@ func preparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 	}
 
 	// watching for the abort signal and revert the chaos if an abort signal is received
-	go abortWatcher(targets, resultDetails.Name, chaosDetails.ChaosNamespace)
+	go abortWatcher(targets, resultDetails.Name, chaosDetails, clients)
 
 	select {
 	case <-injectAbort:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(1)
+		common.Exit(1)
 	default:
 	}
 
 	done := make(chan error, 1)
 
 	for index, t := range targets {
+		// recording the revert details inside the journal before injecting the chaos
+		if err = journal.Record(getJournalEntry(t, experimentsDetails), chaosDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not record the journal entry")
+		}
+		injectStart := time.Now()
 		targets[index].Cmd, err = injectChaos(experimentsDetails, t)
 		if err != nil {
 			return stacktrace.Propagate(err, "could not inject chaos")
 		}
+		telemetry.RecordInjectionLatency(ctx, "pod", injectStart)
+		events.MarkUnderChaos(ctx, clients, chaosDetails, t.References)
 		log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name, clients); err != nil {
 			if revertErr := terminateProcess(t); revertErr != nil {
 				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
 			}
@@ -170,11 +179,17 @@ func preparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		log.Info("[Timeout]: Killing the stress process")
 		var errList []string
 		for _, t := range targets {
+			revertStart := time.Now()
 			if err = terminateProcess(t); err != nil {
 				errList = append(errList, err.Error())
 				continue
 			}
-			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+			telemetry.RecordRevertLatency(ctx, "pod", revertStart)
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+			if err := journal.MarkReverted(t.journalID(), chaosDetails, clients); err != nil {
+				errList = append(errList, err.Error())
+			}
+			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				errList = append(errList, err.Error())
 			}
 		}
@@ -190,11 +205,17 @@ func preparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 			log.Info("[Info]: Reverting Chaos")
 			var errList []string
 			for _, t := range targets {
+				revertStart := time.Now()
 				if err := terminateProcess(t); err != nil {
 					errList = append(errList, err.Error())
 					continue
 				}
-				if err := result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+				telemetry.RecordRevertLatency(ctx, "pod", revertStart)
+				events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+				if err := journal.MarkReverted(t.journalID(), chaosDetails, clients); err != nil {
+					errList = append(errList, err.Error())
+				}
+				if err := result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 					errList = append(errList, err.Error())
 				}
 			}
@@ -243,8 +264,20 @@ func terminateProcess(t targetDetails) error {
 	return nil
 }
 
+// getJournalEntry returns the journal entry of the target, containing the revert details
+func getJournalEntry(t targetDetails, experimentsDetails *experimentTypes.ExperimentDetails) *journal.Entry {
+	return journal.NewEntry(experimentsDetails.ExperimentName, "pod", t.Name, t.Namespace).
+		WithContainer(t.TargetContainer, t.ContainerId, t.Pid).
+		WithParam("chaosType", experimentsDetails.ChaosType).
+		WithParam("targetHostNames", experimentsDetails.TargetHostNames).
+		WithParam("spoofMap", experimentsDetails.SpoofMap).
+		WithRevertCommands(fmt.Sprintf("sudo pkill -f 'nsutil -p -n -t %d -- dns_interceptor'", t.Pid))
+}
+
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targets []targetDetails, resultName, chaosNS string) {
+func abortWatcher(targets []targetDetails, resultName string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -258,7 +291,11 @@ func abortWatcher(targets []targetDetails, resultName, chaosNS string) {
 				log.Errorf("unable to revert for %v pod, err :%v", t.Name, err)
 				continue
 			}
-			if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", t.Name); err != nil {
+			events.UnmarkUnderChaos(context.Background(), clients, chaosDetails, t.References)
+			if err := journal.MarkReverted(t.journalID(), chaosDetails, clients); err != nil {
+				log.Errorf("unable to update the journal, err :%v", err)
+			}
+			if err = result.AnnotateChaosResult(resultName, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				log.Errorf("unable to annotate the chaosresult for %v pod, err :%v", t.Name, err)
 			}
 		}
@@ -266,7 +303,8 @@ func abortWatcher(targets []targetDetails, resultName, chaosNS string) {
 		time.Sleep(1 * time.Second)
 	}
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
 
 // getENV fetches all the env variables from the runner pod
@@ -295,4 +333,10 @@ type targetDetails struct {
 	CommandPid      int
 	Cmd             *exec.Cmd
 	Source          string
+	References      []events.TargetReference
+}
+
+// journalID returns the id of the journal entry of the target
+func (t targetDetails) journalID() string {
+	return journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer)
 }
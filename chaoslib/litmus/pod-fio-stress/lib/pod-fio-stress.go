@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -35,7 +33,9 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	//Starting the Fio stress experiment
 	if err := experimentExecution(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
@@ -44,7 +44,9 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -92,20 +94,11 @@ func experimentExecution(ctx context.Context, experimentsDetails *experimentType
 	log.Infof("Target pods list for chaos, %v", podNames)
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
-	}
-
-	return nil
+	return common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, corev1.PodList{Items: targets}, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, corev1.PodList{Items: targets}, clients, resultDetails, eventsDetails, chaosDetails)
+	})
 }
 
 // injectChaosInSerialMode stressed the storage of all target application in serial mode (one by one)
@@ -143,13 +136,17 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 			"Space Consumption(MB)": experimentsDetails.Size,
 		})
 		go stressStorage(experimentsDetails, pod.Name, pod.Namespace, clients, stressErr)
+		events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 
 		log.Infof("[Chaos]:Waiting for: %vs", experimentsDetails.ChaosDuration)
 
 		// signChan channel is used to transmit signal notifications.
 		signChan := make(chan os.Signal, 1)
-		// Catch and relay certain signal(s) to signChan channel.
-		signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+		// Relay the abort of the run to signChan channel.
+		common.NotifyAbort(signChan)
+		// registering the revert, so that the abort is recorded and the process exits only after it is completed
+		revertDone := common.TrackRevert()
+		defer revertDone()
 
 	loop:
 		for {
@@ -168,7 +165,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				}
 			case <-signChan:
 				log.Info("[Chaos]: Revert Started")
-				if err := killStressSerial(experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients); err != nil {
+				if err := killStressSerial(ctx, experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 					log.Errorf("Error in Kill stress after abortion, err: %v", err)
 				}
 				err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", pod.Name, pod.Namespace, experimentsDetails.TargetContainer), Reason: "experiment is aborted"}
@@ -178,16 +175,18 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 					log.Errorf("failed to update chaos result %s", err.Error())
 				}
 				log.Info("[Chaos]: Revert Completed")
-				os.Exit(1)
+				revertDone()
+				common.Exit(1)
 			case <-endTime:
 				log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 				endTime = nil
 				break loop
 			}
 		}
-		if err := killStressSerial(experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients); err != nil {
+		if err := killStressSerial(ctx, experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 			return stacktrace.Propagate(err, "could not revert chaos")
 		}
+		revertDone()
 	}
 	return nil
 }
@@ -210,6 +209,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
 
 	for _, pod := range targetPodList.Items {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
+		}
 
 		if experimentsDetails.EngineName != "" {
 			msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
@@ -227,14 +229,18 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			"Storage Consumption(MB)": experimentsDetails.Size,
 		})
 		go stressStorage(experimentsDetails, pod.Name, pod.Namespace, clients, stressErr)
+		events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 	}
 
 	log.Infof("[Chaos]:Waiting for: %vs", experimentsDetails.ChaosDuration)
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
 loop:
 	for {
 		endTime = time.After(timeDelay)
@@ -252,7 +258,7 @@ loop:
 			}
 		case <-signChan:
 			log.Info("[Chaos]: Revert Started")
-			if err := killStressParallel(experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients); err != nil {
+			if err := killStressParallel(ctx, experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 				log.Errorf("Error in Kill stress after abortion, err: %v", err)
 			}
 			err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: "experiment is aborted"}
@@ -262,13 +268,14 @@ loop:
 				log.Errorf("failed to update chaos result %s", err.Error())
 			}
 			log.Info("[Chaos]: Revert Completed")
-			os.Exit(1)
+			revertDone()
+			common.Exit(1)
 		case <-endTime:
 			log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 			break loop
 		}
 	}
-	if err := killStressParallel(experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients); err != nil {
+	if err := killStressParallel(ctx, experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 		return stacktrace.Propagate(err, "could revert chaos")
 	}
 
@@ -278,7 +285,7 @@ loop:
 // killStressSerial function to kill a stress process running inside target container
 //
 //	Triggered by either timeout of chaos duration or termination of the experiment
-func killStressSerial(containerName, podName, namespace, KillCmd string, clients clients.ClientSets) error {
+func killStressSerial(ctx context.Context, containerName, podName, namespace, KillCmd string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	// It will contain all the pod & container details required for exec command
 	execCommandDetails := litmusexec.PodDetails{}
 
@@ -289,15 +296,16 @@ func killStressSerial(containerName, podName, namespace, KillCmd string, clients
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{podName: %s, namespace: %s}", podName, namespace), Reason: fmt.Sprintf("failed to revert chaos: %s", out)}
 	}
+	events.UnmarkPodUnderChaos(ctx, clients, chaosDetails, podName, namespace)
 	return nil
 }
 
 // killStressParallel function to kill all the stress process running inside target container
 // Triggered by either timeout of chaos duration or termination of the experiment
-func killStressParallel(containerName string, targetPodList corev1.PodList, KillCmd string, clients clients.ClientSets) error {
+func killStressParallel(ctx context.Context, containerName string, targetPodList corev1.PodList, KillCmd string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	var errList []string
 	for _, pod := range targetPodList.Items {
-		if err := killStressSerial(containerName, pod.Name, pod.Namespace, KillCmd, clients); err != nil {
+		if err := killStressSerial(ctx, containerName, pod.Name, pod.Namespace, KillCmd, clients, chaosDetails); err != nil {
 			errList = append(errList, err.Error())
 		}
 	}
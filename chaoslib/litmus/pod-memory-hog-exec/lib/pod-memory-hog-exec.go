@@ -4,10 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -37,13 +35,15 @@ func PrepareMemoryExecStress(ctx context.Context, experimentsDetails *experiment
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	//Starting the Memory stress experiment
 	if err := experimentMemory(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
@@ -52,7 +52,9 @@ func PrepareMemoryExecStress(ctx context.Context, experimentsDetails *experiment
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -97,20 +99,11 @@ func experimentMemory(ctx context.Context, experimentsDetails *experimentTypes.E
 	log.Infof("Target pods list for chaos, %v", podNames)
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
-	}
-
-	return nil
+	return common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, corev1.PodList{Items: targets}, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, corev1.PodList{Items: targets}, clients, resultDetails, eventsDetails, chaosDetails)
+	})
 }
 
 // injectChaosInSerialMode stressed the memory of all target application serially (one by one)
@@ -127,8 +120,11 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -137,7 +133,8 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
 		time.Sleep(10 * time.Second)
-		os.Exit(0)
+		revertDone()
+		common.Exit(0)
 	default:
 		for _, pod := range targetPodList.Items {
 
@@ -161,6 +158,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				"Memory Consumption(MB)": experimentsDetails.MemoryConsumption,
 			})
 			go stressMemory(strconv.Itoa(experimentsDetails.MemoryConsumption), experimentsDetails.TargetContainer, pod.Name, pod.Namespace, clients, stressErr)
+			events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
 
@@ -183,7 +181,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 					}
 				case <-signChan:
 					log.Info("[Chaos]: Revert Started")
-					if err := killStressMemorySerial(experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
+					if err := killStressMemorySerial(ctx, experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 						log.Errorf("Error in Kill stress after abortion, err: %v", err)
 					}
 					// updating the chaosresult after stopped
@@ -194,14 +192,15 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 						log.Errorf("failed to update chaos result %s", err.Error())
 					}
 					log.Info("[Chaos]: Revert Completed")
-					os.Exit(1)
+					revertDone()
+					common.Exit(1)
 				case <-endTime:
 					log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 					endTime = nil
 					break loop
 				}
 			}
-			if err := killStressMemorySerial(experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
+			if err := killStressMemorySerial(ctx, experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 				return stacktrace.Propagate(err, "could not revert memory stress")
 			}
 		}
@@ -225,8 +224,11 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -235,9 +237,13 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
 		time.Sleep(10 * time.Second)
-		os.Exit(0)
+		revertDone()
+		common.Exit(0)
 	default:
 		for _, pod := range targetPodList.Items {
+			if err := common.WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
 
 			if experimentsDetails.EngineName != "" {
 				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
@@ -258,6 +264,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			})
 
 			go stressMemory(strconv.Itoa(experimentsDetails.MemoryConsumption), experimentsDetails.TargetContainer, pod.Name, pod.Namespace, clients, stressErr)
+			events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 		}
 	}
 
@@ -280,7 +287,7 @@ loop:
 			}
 		case <-signChan:
 			log.Info("[Chaos]: Revert Started")
-			if err := killStressMemoryParallel(experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
+			if err := killStressMemoryParallel(ctx, experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 				log.Errorf("Error in Kill stress after abortion, err: %v", err)
 			}
 			// updating the chaosresult after stopped
@@ -291,19 +298,20 @@ loop:
 				log.Errorf("failed to update chaos result %s", err.Error())
 			}
 			log.Info("[Chaos]: Revert Completed")
-			os.Exit(1)
+			revertDone()
+			common.Exit(1)
 		case <-endTime:
 			log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 			break loop
 		}
 	}
-	return killStressMemoryParallel(experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients, chaosDetails)
+	return killStressMemoryParallel(ctx, experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients, chaosDetails)
 }
 
 // killStressMemorySerial function to kill a stress process running inside target container
 //
 //	Triggered by either timeout of chaos duration or termination of the experiment
-func killStressMemorySerial(containerName, podName, namespace, memFreeCmd string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func killStressMemorySerial(ctx context.Context, containerName, podName, namespace, memFreeCmd string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	// It will contains all the pod & container details required for exec command
 	execCommandDetails := litmusexec.PodDetails{}
 
@@ -315,15 +323,16 @@ func killStressMemorySerial(containerName, podName, namespace, memFreeCmd string
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{podName: %s, namespace: %s}", podName, namespace), Reason: fmt.Sprintf("failed to revert chaos: %s", out)}
 	}
 	common.SetTargets(podName, "reverted", "pod", chaosDetails)
+	events.UnmarkPodUnderChaos(ctx, clients, chaosDetails, podName, namespace)
 	return nil
 }
 
 // killStressMemoryParallel function to kill all the stress process running inside target container
 // Triggered by either timeout of chaos duration or termination of the experiment
-func killStressMemoryParallel(containerName string, targetPodList corev1.PodList, memFreeCmd string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func killStressMemoryParallel(ctx context.Context, containerName string, targetPodList corev1.PodList, memFreeCmd string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	var errList []string
 	for _, pod := range targetPodList.Items {
-		if err := killStressMemorySerial(containerName, pod.Name, pod.Namespace, memFreeCmd, clients, chaosDetails); err != nil {
+		if err := killStressMemorySerial(ctx, containerName, pod.Name, pod.Namespace, memFreeCmd, clients, chaosDetails); err != nil {
 			errList = append(errList, err.Error())
 		}
 	}
@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -14,10 +12,14 @@ import (
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
 
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-network-partition/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -40,13 +42,13 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	// validate the appLabels
 	if chaosDetails.AppDetail == nil {
@@ -71,7 +73,9 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// collect all the data for the network policy
@@ -103,15 +107,27 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
+		// recording the revert details inside the journal before injecting the chaos
+		entry := journal.NewEntry(experimentsDetails.ExperimentName, "networkpolicy", experimentsDetails.ExperimentName+"-np-"+runID, experimentsDetails.AppNS).
+			WithParam("policyTypes", experimentsDetails.PolicyTypes).
+			WithRevertAction(journal.ActionDeleteNetworkPolicy, map[string]string{"name": experimentsDetails.ExperimentName + "-np-" + runID, "namespace": experimentsDetails.AppNS})
+		if err := journal.Record(entry, chaosDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not record the journal entry")
+		}
+
 		// creating the network policy to block the traffic
+		injectStart := time.Now()
 		if err := createNetworkPolicy(ctx, experimentsDetails, clients, np, runID); err != nil {
 			return stacktrace.Propagate(err, "could not create network policy")
 		}
+		telemetry.RecordInjectionLatency(ctx, "networkpolicy", injectStart)
+		records.Injected(records.FromJournalEntry(entry), resultDetails.Name, chaosDetails, clients)
 		// updating chaos status to injected for the target pods
 		for _, pod := range targetPodList.Items {
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
+			events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 		}
 	}
 
@@ -121,12 +137,22 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 	}
 
 	log.Infof("[Wait]: Wait for %v chaos duration", experimentsDetails.ChaosDuration)
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDuration(experimentsDetails.ChaosDuration); err != nil {
+		return err
+	}
+
+	// waiting for the approval before reverting the chaos, if the revert gate is enabled
+	// the chaos is reverted even if the approval fails, so that it isn't left behind
+	if err := approval.WaitForApproval(ctx, types.ApprovalGateRevert, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		log.Errorf("Approval to revert the chaos failed, reverting the chaos, err: %v", err)
+	}
 
 	// deleting the network policy after chaos duration over
-	if err := deleteNetworkPolicy(experimentsDetails, clients, &targetPodList, chaosDetails, experimentsDetails.Timeout, experimentsDetails.Delay, runID); err != nil {
+	revertStart := time.Now()
+	if err := deleteNetworkPolicy(experimentsDetails, clients, &targetPodList, resultDetails.Name, chaosDetails, experimentsDetails.Timeout, experimentsDetails.Delay, runID); err != nil {
 		return stacktrace.Propagate(err, "could not delete network policy")
 	}
+	telemetry.RecordRevertLatency(ctx, "networkpolicy", revertStart)
 
 	// updating chaos status to reverted for the target pods
 	for _, pod := range targetPodList.Items {
@@ -136,7 +162,9 @@ func PrepareAndInjectChaos(ctx context.Context, experimentsDetails *experimentTy
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -176,14 +204,17 @@ func createNetworkPolicy(ctx context.Context, experimentsDetails *experimentType
 }
 
 // deleteNetworkPolicy deletes the network policy and wait until the network policy deleted completely
-func deleteNetworkPolicy(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, targetPodList *corev1.PodList, chaosDetails *types.ChaosDetails, timeout, delay int, runID string) error {
+func deleteNetworkPolicy(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, targetPodList *corev1.PodList, resultName string, chaosDetails *types.ChaosDetails, timeout, delay int, runID string) (err error) {
 	name := experimentsDetails.ExperimentName + "-np-" + runID
+	defer func() {
+		records.Reverted(journal.EntryID("networkpolicy", experimentsDetails.AppNS, name, ""), resultName, err, chaosDetails, clients)
+	}()
 	labels := "name=" + experimentsDetails.ExperimentName + "-np-" + runID
 	if err := clients.KubeClient.NetworkingV1().NetworkPolicies(experimentsDetails.AppNS).Delete(context.Background(), name, v1.DeleteOptions{}); err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{name: %s, namespace: %s}", name, experimentsDetails.AppNS), Reason: fmt.Sprintf("failed to delete network policy: %s", err.Error())}
 	}
 
-	err := retry.
+	err = retry.
 		Times(uint(timeout / delay)).
 		Wait(time.Duration(delay) * time.Second).
 		Try(func(attempt uint) error {
@@ -200,8 +231,13 @@ func deleteNetworkPolicy(experimentsDetails *experimentTypes.ExperimentDetails,
 		return err
 	}
 
+	if err := journal.MarkReverted(journal.EntryID("networkpolicy", experimentsDetails.AppNS, name, ""), chaosDetails, clients); err != nil {
+		return stacktrace.Propagate(err, "could not update the journal")
+	}
+
 	for _, pod := range targetPodList.Items {
 		common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
+		events.UnmarkPodUnderChaos(context.Background(), clients, chaosDetails, pod.Name, pod.Namespace)
 	}
 	return nil
 }
@@ -226,6 +262,9 @@ func checkExistenceOfPolicy(experimentsDetails *experimentTypes.ExperimentDetail
 
 // abortWatcher continuously watch for the abort signals
 func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, targetPodList *corev1.PodList, runID string) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	// waiting till the abort signal received
 	<-abort
 
@@ -245,7 +284,7 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients
 			continue
 		}
 
-		if err := deleteNetworkPolicy(experimentsDetails, clients, targetPodList, chaosDetails, 2, 1, runID); err != nil {
+		if err := deleteNetworkPolicy(experimentsDetails, clients, targetPodList, resultDetails.Name, chaosDetails, 2, 1, runID); err != nil {
 			log.Errorf("unable to delete network policy, err: %v", err)
 		}
 		retry--
@@ -256,5 +295,6 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients
 	types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
 	result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
 	log.Info("Chaos Revert Completed")
-	os.Exit(0)
+	revertDone()
+	common.Exit(0)
 }
@@ -3,7 +3,6 @@ package lib
 import (
 	"context"
 	"fmt"
-	"time"
 
 	redfishLib "github.com/litmuschaos/litmus-go/pkg/baremetal/redfish"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/baremetal/redfish-node-restart/types"
@@ -24,7 +23,7 @@ func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.Experi
 	defer span.End()
 
 	URL := fmt.Sprintf("https://%v/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset", experimentsDetails.IPMIIP)
-	return redfishLib.RebootNode(URL, experimentsDetails.User, experimentsDetails.Password)
+	return redfishLib.RebootNode(ctx, URL, experimentsDetails.User, experimentsDetails.Password)
 }
 
 // experimentExecution function orchestrates the experiment by calling the injectChaos function
@@ -48,7 +47,9 @@ func experimentExecution(ctx context.Context, experimentsDetails *experimentType
 	}
 
 	log.Infof("[Chaos]: Waiting for: %vs", experimentsDetails.ChaosDuration)
-	time.Sleep(time.Duration(experimentsDetails.ChaosDuration) * time.Second)
+	if err := common.WaitForDuration(experimentsDetails.ChaosDuration); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -60,7 +61,9 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	//Starting the Redfish node restart experiment
 	if err := experimentExecution(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
@@ -70,7 +73,9 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,81 @@
+package lib
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/scenario/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/scenario"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// PrepareScenario contains the preparation and injection steps of the scenario
+// the faults of the scenario are read from the registry and injected concurrently, as per their timeline
+func PrepareScenario(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, registry scenario.Registry, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "PrepareScenario")
+	defer span.End()
+
+	scenarioDetails, err := scenario.GetScenario(experimentsDetails.ChaosDuration, registry)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get the scenario")
+	}
+	faults, err := scenarioDetails.Prepare(registry, resultDetails, chaosDetails)
+	if err != nil {
+		return stacktrace.Propagate(err, "could not prepare the faults")
+	}
+	for _, fault := range scenarioDetails.Faults {
+		log.InfoWithValues("[Info]: The fault of the scenario is as follows", logrus.Fields{
+			"Name":         fault.Name,
+			"Fault":        fault.Fault,
+			"Start Offset": fault.StartOffset,
+			"Duration":     fault.Duration,
+		})
+	}
+
+	// the onchaos probes are run for the whole timeline of the scenario
+	chaosDetails.ChaosDuration = scenarioDetails.Duration()
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
+	}
+
+	if experimentsDetails.EngineName != "" {
+		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos with " + strconv.Itoa(len(faults)) + " faults"
+		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	// run the probes during chaos
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return err
+		}
+	}
+
+	if err := scenario.Run(ctx, faults, clients, eventsDetails, chaosDetails); err != nil {
+		return stacktrace.Propagate(err, "could not run the scenario")
+	}
+	log.Infof("[Completion]: %v chaos is done, all the %d faults are completed", experimentsDetails.ExperimentName, len(faults))
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
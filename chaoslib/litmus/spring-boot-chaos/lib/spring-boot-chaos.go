@@ -7,9 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
@@ -20,8 +18,10 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/spring-boot/spring-boot-chaos/types"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -37,6 +37,13 @@ var revertAssault = experimentTypes.ChaosMonkeyAssaultRevert{
 	ExceptionsActive:      false,
 }
 
+// getTargetDetails returns a copy of the experiment details, containing the given target pods only
+func getTargetDetails(experimentsDetails *experimentTypes.ExperimentDetails, pods []corev1.Pod) *experimentTypes.ExperimentDetails {
+	details := *experimentsDetails
+	details.TargetPodList = corev1.PodList{Items: pods}
+	return &details
+}
+
 // SetTargetPodList selects the targeted pod and add them to the experimentDetails
 func SetTargetPodList(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	// Get the target pod details for the chaos execution
@@ -61,7 +68,9 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 	// Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	log.InfoWithValues("[Info]: Chaos monkeys watchers will be injected to the target pods as follows", logrus.Fields{
@@ -73,23 +82,20 @@ func PrepareChaos(ctx context.Context, experimentsDetails *experimentTypes.Exper
 		"RestController": experimentsDetails.ChaosMonkeyWatchers.RestController,
 	})
 
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err := injectChaosInSerialMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err := injectChaosInParallelMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	if err := common.InjectInSequence(ctx, experimentsDetails.Sequence, experimentsDetails.TargetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInSerialMode(ctx, getTargetDetails(experimentsDetails, targets), clients, chaosDetails, eventsDetails, resultDetails)
+	}, func(ctx context.Context, targets []corev1.Pod) error {
+		return injectChaosInParallelMode(ctx, getTargetDetails(experimentsDetails, targets), clients, chaosDetails, eventsDetails, resultDetails)
+	}); err != nil {
+		return err
 	}
 
 	// Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -192,8 +198,8 @@ func setChaosMonkeyAssault(chaosMonkeyPort string, chaosMonkeyPath string, assau
 	return nil
 }
 
-// disableChaosMonkey disables chaos monkey on selected pods
-func disableChaosMonkey(ctx context.Context, chaosMonkeyPort string, chaosMonkeyPath string, pod corev1.Pod) error {
+// DisableChaosMonkey disables chaos monkey on selected pods
+func DisableChaosMonkey(ctx context.Context, chaosMonkeyPort string, chaosMonkeyPath string, pod corev1.Pod) error {
 	log.Infof("[Chaos]: disabling assaults on pod %s", pod.Name)
 	jsonValue, err := json.Marshal(revertAssault)
 	if err != nil {
@@ -216,6 +222,32 @@ func disableChaosMonkey(ctx context.Context, chaosMonkeyPort string, chaosMonkey
 	return nil
 }
 
+// getJournalEntry returns the journal entry of the pod, containing the revert details
+func getJournalEntry(experimentsDetails *experimentTypes.ExperimentDetails, pod corev1.Pod) *journal.Entry {
+	return journal.NewEntry(experimentsDetails.ExperimentName, "pod", pod.Name, pod.Namespace).
+		WithRevertAction(journal.ActionDisableChaosMonkey, map[string]string{
+			"pod":       pod.Name,
+			"namespace": pod.Namespace,
+			"port":      experimentsDetails.ChaosMonkeyPort,
+			"path":      experimentsDetails.ChaosMonkeyPath,
+		})
+}
+
+// recordJournalEntry records the revert details of the pod inside the journal
+func recordJournalEntry(experimentsDetails *experimentTypes.ExperimentDetails, pod corev1.Pod, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	return journal.Record(getJournalEntry(experimentsDetails, pod), chaosDetails, clients)
+}
+
+// markJournalEntryReverted marks the journal entry and the record of the pod as reverted, and removes its under chaos marks
+func markJournalEntryReverted(ctx context.Context, pod corev1.Pod, resultName string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	id := journal.EntryID("pod", pod.Namespace, pod.Name, "")
+	records.Reverted(id, resultName, nil, chaosDetails, clients)
+	events.UnmarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
+	if err := journal.MarkReverted(id, chaosDetails, clients); err != nil {
+		log.Errorf("Unable to update the journal for %v pod, err: %v", pod.Name, err)
+	}
+}
+
 // injectChaosInSerialMode injects chaos monkey assault on pods in serial mode(one by one)
 func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectSpringBootFaultInSerialMode")
@@ -230,8 +262,11 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -240,7 +275,8 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	case <-signChan:
 		// stopping the chaos execution, if abort signal received
 		time.Sleep(10 * time.Second)
-		os.Exit(0)
+		revertDone()
+		common.Exit(0)
 	default:
 		for _, pod := range experimentsDetails.TargetPodList.Items {
 			if experimentsDetails.EngineName != "" {
@@ -253,6 +289,12 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				"Target Pod": pod.Name,
 			})
 
+			// recording the revert details inside the journal before injecting the chaos
+			if err := recordJournalEntry(experimentsDetails, pod, chaosDetails, clients); err != nil {
+				return stacktrace.Propagate(err, "could not record the journal entry")
+			}
+
+			injectStart := time.Now()
 			if err := setChaosMonkeyWatchers(experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, experimentsDetails.ChaosMonkeyWatchers, pod); err != nil {
 				log.Errorf("[Chaos]: Failed to set watchers, err: %v ", err)
 				return err
@@ -267,6 +309,9 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				log.Errorf("[Chaos]: Failed to enable chaos, err: %v ", err)
 				return err
 			}
+			telemetry.RecordInjectionLatency(ctx, "pod", injectStart)
+			records.Injected(records.FromJournalEntry(getJournalEntry(experimentsDetails, pod)), resultDetails.Name, chaosDetails, clients)
+			events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
 
 			log.Infof("[Chaos]: Waiting for: %vs", experimentsDetails.ChaosDuration)
@@ -277,9 +322,10 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				select {
 				case <-signChan:
 					log.Info("[Chaos]: Revert Started")
-					if err := disableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
+					if err := DisableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
 						log.Errorf("Error in disabling chaos monkey, err: %v", err)
 					} else {
+						markJournalEntryReverted(ctx, pod, resultDetails.Name, chaosDetails, clients)
 						common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
 					}
 					// updating the chaosresult after stopped
@@ -287,7 +333,8 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 					types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, cerrors.ErrorTypeExperimentAborted)
 					result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
 					log.Info("[Chaos]: Revert Completed")
-					os.Exit(1)
+					revertDone()
+					common.Exit(1)
 				case <-endTime:
 					log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 					endTime = nil
@@ -295,10 +342,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 				}
 			}
 
-			if err := disableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
+			revertStart := time.Now()
+			if err := DisableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
 				return err
 			}
+			telemetry.RecordRevertLatency(ctx, "pod", revertStart)
 
+			markJournalEntryReverted(ctx, pod, resultDetails.Name, chaosDetails, clients)
 			common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
 		}
 	}
@@ -320,8 +370,11 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to signChan channel.
+	common.NotifyAbort(signChan)
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -330,9 +383,13 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	case <-signChan:
 		// stopping the chaos execution, if abort signal received
 		time.Sleep(10 * time.Second)
-		os.Exit(0)
+		revertDone()
+		common.Exit(0)
 	default:
 		for _, pod := range experimentsDetails.TargetPodList.Items {
+			if err := common.WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
 			if experimentsDetails.EngineName != "" {
 				msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + pod.Name + " pod"
 				types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -343,6 +400,12 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 				"Target Pod": pod.Name,
 			})
 
+			// recording the revert details inside the journal before injecting the chaos
+			if err := recordJournalEntry(experimentsDetails, pod, chaosDetails, clients); err != nil {
+				return stacktrace.Propagate(err, "could not record the journal entry")
+			}
+
+			injectStart := time.Now()
 			if err := setChaosMonkeyWatchers(experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, experimentsDetails.ChaosMonkeyWatchers, pod); err != nil {
 				log.Errorf("[Chaos]: Failed to set watchers, err: %v", err)
 				return err
@@ -357,6 +420,9 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 				log.Errorf("[Chaos]: Failed to enable chaos, err: %v", err)
 				return err
 			}
+			telemetry.RecordInjectionLatency(ctx, "pod", injectStart)
+			records.Injected(records.FromJournalEntry(getJournalEntry(experimentsDetails, pod)), resultDetails.Name, chaosDetails, clients)
+			events.MarkPodUnderChaos(ctx, clients, chaosDetails, pod.Name, pod.Namespace)
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
 		}
 		log.Infof("[Chaos]: Waiting for: %vs", experimentsDetails.ChaosDuration)
@@ -368,9 +434,10 @@ loop:
 		case <-signChan:
 			log.Info("[Chaos]: Revert Started")
 			for _, pod := range experimentsDetails.TargetPodList.Items {
-				if err := disableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
+				if err := DisableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
 					log.Errorf("Error in disabling chaos monkey, err: %v", err)
 				} else {
+					markJournalEntryReverted(ctx, pod, resultDetails.Name, chaosDetails, clients)
 					common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
 				}
 			}
@@ -379,7 +446,8 @@ loop:
 			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, cerrors.ErrorTypeExperimentAborted)
 			result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
 			log.Info("[Chaos]: Revert Completed")
-			os.Exit(1)
+			revertDone()
+			common.Exit(1)
 		case <-endTime:
 			log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 			endTime = nil
@@ -389,10 +457,13 @@ loop:
 
 	var errorList []string
 	for _, pod := range experimentsDetails.TargetPodList.Items {
-		if err := disableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
+		revertStart := time.Now()
+		if err := DisableChaosMonkey(ctx, experimentsDetails.ChaosMonkeyPort, experimentsDetails.ChaosMonkeyPath, pod); err != nil {
 			errorList = append(errorList, err.Error())
 			continue
 		}
+		telemetry.RecordRevertLatency(ctx, "pod", revertStart)
+		markJournalEntryReverted(ctx, pod, resultDetails.Name, chaosDetails, clients)
 		common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
 	}
 
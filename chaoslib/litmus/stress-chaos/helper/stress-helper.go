@@ -9,10 +9,10 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/palantir/stacktrace"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"io"
 	"os"
 	"os/exec"
-	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -24,7 +24,9 @@ import (
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/records"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -65,13 +67,13 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Fetching all the ENV passed for the helper pod
 	log.Info("[PreReq]: Getting the ENV variables")
@@ -79,7 +81,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 
 	// Intialise the chaos attributes
 	types.InitialiseChaosVariables(&chaosDetails)
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	// Intialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -87,7 +89,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	if err := prepareStressChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+	if err := prepareStressChaos(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
 		// update failstep inside chaosresult
 		if resultErr := result.UpdateFailedStepFromHelper(&resultDetails, &chaosDetails, clients, err); resultErr != nil {
 			log.Fatalf("helper pod failed, err: %v, resultErr: %v", err, resultErr)
@@ -97,7 +99,7 @@ func Helper(ctx context.Context, clients clients.ClientSets) {
 }
 
 // prepareStressChaos contains the chaos preparation and injection steps
-func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func prepareStressChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 	// get stressors in list format
 	stressorList := prepareStressor(experimentsDetails)
 	if len(stressorList) == 0 {
@@ -137,29 +139,45 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		if err != nil {
 			return stacktrace.Propagate(err, "could not get cgroup manager")
 		}
+
+		// deriving the owners and node of the target, to mark them as under chaos
+		if td.References, err = events.GetPodReferences(ctx, clients, td.Name, td.Namespace); err != nil {
+			log.Warnf("unable to get the references of the target: {name: %s, namespace: %v}, err: %v", td.Name, td.Namespace, err)
+		}
 		targets = append(targets, td)
 	}
 
 	// watching for the abort signal and revert the chaos if an abort signal is received
-	go abortWatcher(targets, resultDetails.Name, chaosDetails.ChaosNamespace)
+	go abortWatcher(ctx, targets, resultDetails.Name, chaosDetails, clients)
 
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(1)
+		common.Exit(1)
 	default:
 	}
 
 	done := make(chan error, 1)
 
 	for index, t := range targets {
-		targets[index].Cmd, err = injectChaos(t, stressors, experimentsDetails.StressType)
+		// recording the revert details inside the journal before injecting the chaos
+		if err = journal.Record(getJournalEntry(t, experimentsDetails, stressors), chaosDetails, clients); err != nil {
+			return stacktrace.Propagate(err, "could not record the journal entry")
+		}
+		injectStart := time.Now()
+		targets[index].Cmd, err = injectChaos(ctx, t, stressors, experimentsDetails.StressType)
 		if err != nil {
 			return stacktrace.Propagate(err, "could not inject chaos")
 		}
+		telemetry.RecordInjectionLatency(ctx, "pod", injectStart)
+		events.MarkUnderChaos(ctx, clients, chaosDetails, t.References)
+		records.Injected(records.NewRecord(experimentsDetails.ExperimentName, "pod", t.Name, t.Namespace).
+			WithContainer(t.TargetContainer).
+			WithParam("stressType", experimentsDetails.StressType).
+			WithParam("stressors", stressors), resultDetails.Name, chaosDetails, clients)
 		log.Infof("successfully injected chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name); err != nil {
-			if revertErr := terminateProcess(t); revertErr != nil {
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", t.Name, clients); err != nil {
+			if revertErr := terminateProcess(ctx, t); revertErr != nil {
 				return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s,%s]", stacktrace.RootCause(err).Error(), stacktrace.RootCause(revertErr).Error())}
 			}
 			return stacktrace.Propagate(err, "could not annotate chaosresult")
@@ -207,11 +225,19 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		log.Info("[Timeout]: Killing the stress process")
 		var errList []string
 		for _, t := range targets {
-			if err = terminateProcess(t); err != nil {
+			revertStart := time.Now()
+			err = terminateProcess(ctx, t)
+			records.Reverted(t.recordID(), resultDetails.Name, err, chaosDetails, clients)
+			if err != nil {
 				errList = append(errList, err.Error())
 				continue
 			}
-			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+			telemetry.RecordRevertLatency(ctx, "pod", revertStart)
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+			if err := journal.MarkReverted(t.recordID(), chaosDetails, clients); err != nil {
+				errList = append(errList, err.Error())
+			}
+			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				errList = append(errList, err.Error())
 			}
 		}
@@ -234,12 +260,20 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		log.Info("[Info]: Reverting Chaos")
 		var errList []string
 		for _, t := range targets {
-			if err := terminateProcess(t); err != nil {
+			revertStart := time.Now()
+			err := terminateProcess(ctx, t)
+			records.Reverted(t.recordID(), resultDetails.Name, err, chaosDetails, clients)
+			if err != nil {
 				errList = append(errList, err.Error())
 				continue
 			}
+			telemetry.RecordRevertLatency(ctx, "pod", revertStart)
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+			if err := journal.MarkReverted(t.recordID(), chaosDetails, clients); err != nil {
+				errList = append(errList, err.Error())
+			}
 			log.Infof("successfully reverted chaos on target: {name: %s, namespace: %v, container: %v}", t.Name, t.Namespace, t.TargetContainer)
-			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name); err != nil {
+			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				errList = append(errList, err.Error())
 			}
 		}
@@ -252,7 +286,10 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 }
 
 // terminateProcess will remove the stress process from the target container after chaos completion
-func terminateProcess(t targetDetails) error {
+func terminateProcess(ctx context.Context, t targetDetails) (err error) {
+	_, span := telemetry.StartSpan(ctx, "RevertPodStressFaultOnTarget", t.attributes()...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	if err := syscall.Kill(-t.Cmd.Process.Pid, syscall.SIGKILL); err != nil {
 		if strings.Contains(err.Error(), ProcessAlreadyKilled) || strings.Contains(err.Error(), ProcessAlreadyFinished) {
 			return nil
@@ -475,7 +512,9 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 }
 
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targets []targetDetails, resultName, chaosNS string) {
+func abortWatcher(ctx context.Context, targets []targetDetails, resultName string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
 
 	<-abort
 
@@ -485,11 +524,16 @@ func abortWatcher(targets []targetDetails, resultName, chaosNS string) {
 	retry := 3
 	for retry > 0 {
 		for _, t := range targets {
-			if err = terminateProcess(t); err != nil {
+			if err = terminateProcess(ctx, t); err != nil {
 				log.Errorf("[Abort]: unable to revert for %v pod, err :%v", t.Name, err)
 				continue
 			}
-			if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", t.Name); err != nil {
+			events.UnmarkUnderChaos(ctx, clients, chaosDetails, t.References)
+			records.Reverted(t.recordID(), resultName, nil, chaosDetails, clients)
+			if err := journal.MarkReverted(t.recordID(), chaosDetails, clients); err != nil {
+				log.Errorf("[Abort]: Unable to update the journal, err :%v", err)
+			}
+			if err = result.AnnotateChaosResult(resultName, chaosDetails.ChaosNamespace, "reverted", "pod", t.Name, clients); err != nil {
 				log.Errorf("[Abort]: Unable to annotate the chaosresult for %v pod, err :%v", t.Name, err)
 			}
 		}
@@ -497,7 +541,8 @@ func abortWatcher(targets []targetDetails, resultName, chaosNS string) {
 		time.Sleep(1 * time.Second)
 	}
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
 
 // getCGroupManager will return the cgroup for the given pid of the process
@@ -555,7 +600,10 @@ func addProcessToCgroup(pid int, control interface{}, groupPath string) error {
 	return cgroup1.Add(cgroups.Process{Pid: pid})
 }
 
-func injectChaos(t targetDetails, stressors, stressType string) (*exec.Cmd, error) {
+func injectChaos(ctx context.Context, t targetDetails, stressors, stressType string) (_ *exec.Cmd, err error) {
+	_, span := telemetry.StartSpan(ctx, "InjectPodStressFaultOnTarget", t.attributes()...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	stressCommand := fmt.Sprintf("pause nsutil -t %v -p -- %v", strconv.Itoa(t.Pid), stressors)
 	// for io stress,we need to enter into mount ns of the target container
 	// enabling it by passing -m flag
@@ -564,6 +612,7 @@ func injectChaos(t targetDetails, stressors, stressType string) (*exec.Cmd, erro
 	}
 
 	log.Infof("[Info]: starting process: %v", stressCommand)
+	span.SetAttributes(attribute.String("process.command_line", stressCommand))
 
 	// launch the stress-ng process on the target container in paused mode
 	cmd := exec.Command("/bin/bash", "-c", stressCommand)
@@ -605,4 +654,29 @@ type targetDetails struct {
 	Cmd             *exec.Cmd
 	Source          string
 	GroupPath       string
+	References      []events.TargetReference
+}
+
+// getJournalEntry returns the journal entry of the target, containing the revert details
+// the stress process is started in its own process group, which is killed by the revert command
+func getJournalEntry(t targetDetails, experimentsDetails *experimentTypes.ExperimentDetails, stressors string) *journal.Entry {
+	return journal.NewEntry(experimentsDetails.ExperimentName, "pod", t.Name, t.Namespace).
+		WithContainer(t.TargetContainer, t.ContainerId, t.Pid).
+		WithParam("stressType", experimentsDetails.StressType).
+		WithParam("stressors", stressors).
+		WithRevertCommands(fmt.Sprintf("sudo kill -9 -- -$(pgrep -o -f 'nsutil -t %d -p')", t.Pid))
+}
+
+// recordID returns the id of the target record
+func (t targetDetails) recordID() string {
+	return journal.EntryID("pod", t.Namespace, t.Name, t.TargetContainer)
+}
+
+// attributes returns the span attributes identifying the target
+func (t targetDetails) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("k8s.pod.name", t.Name),
+		attribute.String("k8s.namespace.name", t.Namespace),
+		attribute.String("k8s.container.name", t.TargetContainer),
+	}
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/intensity"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/status"
@@ -31,33 +32,12 @@ func PrepareAndInjectStressChaos(ctx context.Context, experimentsDetails *experi
 	defer span.End()
 	var err error
 	//Set up the tunables if provided in range
-	SetChaosTunables(experimentsDetails)
+	SetChaosTunables(experimentsDetails, chaosDetails)
+	logExperimentFields(experimentsDetails)
 
-	switch experimentsDetails.StressType {
-	case "pod-cpu-stress":
-		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
-			"CPU Core":            experimentsDetails.CPUcores,
-			"CPU Load Percentage": experimentsDetails.CPULoad,
-			"Sequence":            experimentsDetails.Sequence,
-			"PodsAffectedPerc":    experimentsDetails.PodsAffectedPerc,
-		})
-
-	case "pod-memory-stress":
-		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
-			"Number of Workers":  experimentsDetails.NumberOfWorkers,
-			"Memory Consumption": experimentsDetails.MemoryConsumption,
-			"Sequence":           experimentsDetails.Sequence,
-			"PodsAffectedPerc":   experimentsDetails.PodsAffectedPerc,
-		})
-
-	case "pod-io-stress":
-		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
-			"FilesystemUtilizationPercentage": experimentsDetails.FilesystemUtilizationPercentage,
-			"FilesystemUtilizationBytes":      experimentsDetails.FilesystemUtilizationBytes,
-			"NumberOfWorkers":                 experimentsDetails.NumberOfWorkers,
-			"Sequence":                        experimentsDetails.Sequence,
-			"PodsAffectedPerc":                experimentsDetails.PodsAffectedPerc,
-		})
+	profile, err := intensity.ParseProfile(experimentsDetails.IntensitySteps, experimentsDetails.StepDuration, experimentsDetails.ChaosDuration, getSteppedParameters(experimentsDetails))
+	if err != nil {
+		return stacktrace.Propagate(err, "could not get intensity steps")
 	}
 
 	// Get the target pod details for the chaos execution
@@ -65,15 +45,13 @@ func PrepareAndInjectStressChaos(ctx context.Context, experimentsDetails *experi
 	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail == nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: "provide one of the appLabel or TARGET_PODS"}
 	}
-	targetPodList, err := common.GetTargetPods(experimentsDetails.NodeLabel, experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
-	if err != nil {
-		return stacktrace.Propagate(err, "could not get target pods")
-	}
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	// Getting the serviceAccountName, need permission inside helper pod to create the events
@@ -91,20 +69,59 @@ func PrepareAndInjectStressChaos(ctx context.Context, experimentsDetails *experi
 	}
 
 	experimentsDetails.IsTargetContainerProvided = experimentsDetails.TargetContainer != ""
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
+
+	// the probes during chaos are started once, only the step probes are evaluated in every step
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			return err
 		}
-	case "parallel":
-		if err = injectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
+	}
+
+	// the target pods are selected once, so that all the steps are injected in the same pods
+	// unless the pods affected percentage is stepped, then the pods are selected again in every step
+	var targetPodList apiv1.PodList
+	if profile == nil || profile.Parameter != "PODS_AFFECTED_PERC" {
+		if targetPodList, err = getTargetPods(experimentsDetails, clients, chaosDetails); err != nil {
+			return err
 		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
 	}
 
-	return nil
+	if profile == nil {
+		return injectChaos(ctx, experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails)
+	}
+
+	// the chaos is injected for every step, with the parameter set to the value of the step
+	chaosDuration := experimentsDetails.ChaosDuration
+	defer func() { experimentsDetails.ChaosDuration = chaosDuration }()
+	return profile.Run(ctx, chaosDetails, clients, resultDetails, func(ctx context.Context, step intensity.Step) error {
+		experimentsDetails.ChaosDuration = step.Duration
+		logExperimentFields(experimentsDetails)
+		stepTargetPodList := targetPodList
+		if step.Parameter == "PODS_AFFECTED_PERC" {
+			if stepTargetPodList, err = getTargetPods(experimentsDetails, clients, chaosDetails); err != nil {
+				return err
+			}
+		}
+		return injectChaos(ctx, experimentsDetails, stepTargetPodList, clients, chaosDetails, resultDetails, eventsDetails)
+	})
+}
+
+// getTargetPods selects the target pods of the stress chaos
+func getTargetPods(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, error) {
+	targetPodList, err := common.GetTargetPods(experimentsDetails.NodeLabel, experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
+	if err != nil {
+		return apiv1.PodList{}, stacktrace.Propagate(err, "could not get target pods")
+	}
+	return targetPodList, nil
+}
+
+// injectChaos injects the stress chaos in the target pods, as per the sequence
+func injectChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targetPodList apiv1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails) error {
+	return common.InjectInSequence(ctx, experimentsDetails.Sequence, targetPodList.Items, clients, chaosDetails, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, resultDetails, eventsDetails)
+	}, func(ctx context.Context, targets []apiv1.Pod) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, apiv1.PodList{Items: targets}, clients, chaosDetails, resultDetails, eventsDetails)
+	})
 }
 
 // injectChaosInSerialMode inject the stress chaos in all target application serially (one by one)
@@ -112,13 +129,6 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "InjectPodStressFaultInSerialMode")
 	defer span.End()
 
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
-			return err
-		}
-	}
-
 	// creating the helper pod to perform the stress chaos
 	for _, pod := range targetPodList.Items {
 
@@ -141,7 +151,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 		//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 		log.Info("[Status]: Checking the status of the helper pods")
-		if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return stacktrace.Propagate(err, "could not check helper status")
 		}
@@ -149,7 +159,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 		// Wait till the completion of the helper pod
 		// set an upper limit for the waiting time
 		log.Info("[Wait]: waiting till the completion of the helper pod")
-		podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+		podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
 		if err != nil || podStatus == "Failed" {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -171,17 +181,13 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	defer span.End()
 
 	var err error
-	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
-			return err
-		}
-	}
-
 	runID := stringutils.GetRunID()
 	targets := common.FilterPodsForNodes(targetPodList, experimentsDetails.TargetContainer)
 
 	for node, tar := range targets {
+		if err := common.WaitForStaggeredStart(ctx); err != nil {
+			return err
+		}
 		var targetsPerNode []string
 		for _, k := range tar.Target {
 			targetsPerNode = append(targetsPerNode, fmt.Sprintf("%s:%s:%s", k.Name, k.Namespace, k.TargetContainer))
@@ -196,7 +202,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 	//checking the status of the helper pods, wait till the pod comes to running state else fail the experiment
 	log.Info("[Status]: Checking the status of the helper pods")
-	if err := status.CheckHelperStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+	if err := status.CheckHelperStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return stacktrace.Propagate(err, "could not check helper status")
 	}
@@ -204,7 +210,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	// Wait till the completion of the helper pod
 	// set an upper limit for the waiting time
 	log.Info("[Wait]: waiting till the completion of the helper pod")
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+experimentsDetails.Timeout, common.GetContainerNames(chaosDetails)...)
 	if err != nil || podStatus == "Failed" {
 		common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 		return common.HelperFailedError(err, appLabel, chaosDetails.ChaosNamespace, true)
@@ -305,7 +311,7 @@ func createHelperPod(ctx context.Context, experimentsDetails *experimentTypes.Ex
 		helperPod.Spec.Volumes = append(helperPod.Spec.Volumes, common.GetSidecarVolumes(chaosDetails)...)
 	}
 
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(context.Background(), helperPod, v1.CreateOptions{})
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(ctx, helperPod, v1.CreateOptions{})
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to create helper pod: %s", err.Error())}
 	}
@@ -334,6 +340,7 @@ func getPodEnv(ctx context.Context, experimentsDetails *experimentTypes.Experime
 		SetEnv("STRESS_TYPE", experimentsDetails.StressType).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
 		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPEndpoint)).
+		SetEnv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", os.Getenv(telemetry.OTELExporterOTLPLogsEndpoint)).
 		SetEnv("TRACE_PARENT", telemetry.GetMarshalledSpanFromContext(ctx)).
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
@@ -346,13 +353,55 @@ func ptrint64(p int64) *int64 {
 
 // SetChaosTunables will set up a random value within a given range of values
 // If the value is not provided in range it'll set up the initial provided value.
-func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails) {
-	experimentsDetails.CPUcores = common.ValidateRange(experimentsDetails.CPUcores)
-	experimentsDetails.CPULoad = common.ValidateRange(experimentsDetails.CPULoad)
-	experimentsDetails.MemoryConsumption = common.ValidateRange(experimentsDetails.MemoryConsumption)
-	experimentsDetails.NumberOfWorkers = common.ValidateRange(experimentsDetails.NumberOfWorkers)
-	experimentsDetails.FilesystemUtilizationPercentage = common.ValidateRange(experimentsDetails.FilesystemUtilizationPercentage)
-	experimentsDetails.FilesystemUtilizationBytes = common.ValidateRange(experimentsDetails.FilesystemUtilizationBytes)
-	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc)
-	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence)
+func SetChaosTunables(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) {
+	experimentsDetails.CPUcores = common.ValidateRange(experimentsDetails.CPUcores, chaosDetails)
+	experimentsDetails.CPULoad = common.ValidateRange(experimentsDetails.CPULoad, chaosDetails)
+	experimentsDetails.MemoryConsumption = common.ValidateRange(experimentsDetails.MemoryConsumption, chaosDetails)
+	experimentsDetails.NumberOfWorkers = common.ValidateRange(experimentsDetails.NumberOfWorkers, chaosDetails)
+	experimentsDetails.FilesystemUtilizationPercentage = common.ValidateRange(experimentsDetails.FilesystemUtilizationPercentage, chaosDetails)
+	experimentsDetails.FilesystemUtilizationBytes = common.ValidateRange(experimentsDetails.FilesystemUtilizationBytes, chaosDetails)
+	experimentsDetails.PodsAffectedPerc = common.ValidateRange(experimentsDetails.PodsAffectedPerc, chaosDetails)
+	experimentsDetails.Sequence = common.GetRandomSequence(experimentsDetails.Sequence, chaosDetails)
+}
+
+// getSteppedParameters returns the parameters of the stress chaos, which can be stepped
+func getSteppedParameters(experimentsDetails *experimentTypes.ExperimentDetails) intensity.Parameters {
+	return intensity.Parameters{
+		"CPU_CORES":                         intensity.StringParameter(&experimentsDetails.CPUcores),
+		"CPU_LOAD":                          intensity.StringParameter(&experimentsDetails.CPULoad),
+		"MEMORY_CONSUMPTION":                intensity.StringParameter(&experimentsDetails.MemoryConsumption),
+		"NUMBER_OF_WORKERS":                 intensity.StringParameter(&experimentsDetails.NumberOfWorkers),
+		"FILESYSTEM_UTILIZATION_PERCENTAGE": intensity.StringParameter(&experimentsDetails.FilesystemUtilizationPercentage),
+		"PODS_AFFECTED_PERC":                intensity.StringParameter(&experimentsDetails.PodsAffectedPerc),
+	}
+}
+
+// logExperimentFields logs the chaos tunables of the stress type
+func logExperimentFields(experimentsDetails *experimentTypes.ExperimentDetails) {
+	switch experimentsDetails.StressType {
+	case "pod-cpu-stress":
+		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
+			"CPU Core":            experimentsDetails.CPUcores,
+			"CPU Load Percentage": experimentsDetails.CPULoad,
+			"Sequence":            experimentsDetails.Sequence,
+			"PodsAffectedPerc":    experimentsDetails.PodsAffectedPerc,
+		})
+
+	case "pod-memory-stress":
+		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
+			"Number of Workers":  experimentsDetails.NumberOfWorkers,
+			"Memory Consumption": experimentsDetails.MemoryConsumption,
+			"Sequence":           experimentsDetails.Sequence,
+			"PodsAffectedPerc":   experimentsDetails.PodsAffectedPerc,
+		})
+
+	case "pod-io-stress":
+		log.InfoWithValues("[Info]: The chaos tunables are:", logrus.Fields{
+			"FilesystemUtilizationPercentage": experimentsDetails.FilesystemUtilizationPercentage,
+			"FilesystemUtilizationBytes":      experimentsDetails.FilesystemUtilizationBytes,
+			"NumberOfWorkers":                 experimentsDetails.NumberOfWorkers,
+			"Sequence":                        experimentsDetails.Sequence,
+			"PodsAffectedPerc":                experimentsDetails.PodsAffectedPerc,
+		})
+	}
 }
@@ -4,12 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
-	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/vmware"
 	"github.com/litmuschaos/litmus-go/pkg/events"
@@ -31,43 +28,42 @@ func InjectVMPowerOffChaos(ctx context.Context, experimentsDetails *experimentTy
 	defer span.End()
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to inject channel.
+	common.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// Relay the abort of the run to abort channel.
+	common.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//Fetching the target VM Ids
 	vmIdList := strings.Split(experimentsDetails.VMIds, ",")
 
 	// Calling AbortWatcher go routine, it will continuously watch for the abort signal and generate the required events and result
-	go abortWatcher(experimentsDetails, vmIdList, clients, resultDetails, chaosDetails, eventsDetails, cookie)
-
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err := injectChaosInSerialMode(ctx, experimentsDetails, vmIdList, cookie, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in serial mode")
-		}
-	case "parallel":
-		if err := injectChaosInParallelMode(ctx, experimentsDetails, vmIdList, cookie, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
-			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
-		}
-	default:
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", experimentsDetails.Sequence)}
+	go abortWatcher(ctx, experimentsDetails, vmIdList, clients, resultDetails, chaosDetails, eventsDetails, cookie)
+
+	if err := common.InjectInSequence(ctx, experimentsDetails.Sequence, vmIdList, clients, chaosDetails, func(ctx context.Context, targets []string) error {
+		return injectChaosInSerialMode(ctx, experimentsDetails, targets, cookie, clients, resultDetails, eventsDetails, chaosDetails)
+	}, func(ctx context.Context, targets []string) error {
+		return injectChaosInParallelMode(ctx, experimentsDetails, targets, cookie, clients, resultDetails, eventsDetails, chaosDetails)
+	}); err != nil {
+		return err
 	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDuration(experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -81,7 +77,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -101,7 +97,7 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Stopping the VM
 				log.Infof("[Chaos]: Stopping %s VM", vmId)
-				if err := vmware.StopVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := vmware.StopVM(ctx, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
 					return stacktrace.Propagate(err, fmt.Sprintf("failed to stop %s vm", vmId))
 				}
 
@@ -123,11 +119,13 @@ func injectChaosInSerialMode(ctx context.Context, experimentsDetails *experiment
 
 				//Wait for chaos interval
 				log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-				time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+				if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+					return err
+				}
 
 				//Starting the VM
 				log.Infof("[Chaos]: Starting back %s VM", vmId)
-				if err := vmware.StartVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := vmware.StartVM(ctx, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
 					return stacktrace.Propagate(err, "failed to start back vm")
 				}
 
@@ -155,7 +153,7 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
-		os.Exit(0)
+		common.Exit(0)
 	default:
 		//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 		ChaosStartTimeStamp := time.Now()
@@ -172,10 +170,13 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 			}
 
 			for _, vmId := range vmIdList {
+				if err := common.WaitForStaggeredStart(ctx); err != nil {
+					return err
+				}
 
 				//Stopping the VM
 				log.Infof("[Chaos]: Stopping %s VM", vmId)
-				if err := vmware.StopVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := vmware.StopVM(ctx, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
 					return stacktrace.Propagate(err, fmt.Sprintf("failed to stop %s vm", vmId))
 				}
 
@@ -200,13 +201,15 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 
 			//Waiting for chaos interval
 			log.Infof("[Wait]: Waiting for chaos interval of %vs", experimentsDetails.ChaosInterval)
-			common.WaitForDuration(experimentsDetails.ChaosInterval)
+			if err := common.WaitForDuration(experimentsDetails.ChaosInterval); err != nil {
+				return err
+			}
 
 			for _, vmId := range vmIdList {
 
 				//Starting the VM
 				log.Infof("[Chaos]: Starting back %s VM", vmId)
-				if err := vmware.StartVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+				if err := vmware.StartVM(ctx, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
 					return stacktrace.Propagate(err, fmt.Sprintf("failed to start back %s vm", vmId))
 				}
 			}
@@ -232,7 +235,10 @@ func injectChaosInParallelMode(ctx context.Context, experimentsDetails *experime
 }
 
 // abortWatcher watches for the abort signal and reverts the chaos
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, cookie string) {
+func abortWatcher(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, vmIdList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, cookie string) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+
 	<-abort
 
 	log.Info("[Abort]: Chaos Revert Started")
@@ -251,7 +257,7 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, vmIdLis
 			}
 
 			log.Infof("[Abort]: Starting %s VM as abort signal has been received", vmId)
-			if err := vmware.StartVM(experimentsDetails.VcenterServer, vmId, cookie); err != nil {
+			if err := vmware.StartVM(ctx, experimentsDetails.VcenterServer, vmId, cookie); err != nil {
 				log.Errorf("vm %s failed to start when an abort signal was received: %s", vmId, err.Error())
 			}
 		}
@@ -260,5 +266,6 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, vmIdLis
 	}
 
 	log.Info("[Abort]: Chaos Revert Completed")
-	os.Exit(1)
+	revertDone()
+	common.Exit(1)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/aws-ssm-chaos/lib/ssm"
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	experimentEnv "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/environment"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/aws-ssm/aws-ssm-chaos/types"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
@@ -117,7 +118,12 @@ func AWSSSMChaosByID(ctx context.Context, clients clients.ClientSets) {
 		log.Info("[Status]: EC2 instance is in running state")
 	}
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	// validating the approval gates and waiting for the approval before injecting the chaos, if the inject gate is enabled
+	if !approval.ProceedWithInjection(ctx, clients, &resultDetails, &eventsDetails, &chaosDetails) {
+		return
+	}
+
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err := litmusLIB.PrepareAWSSSMChaosByID(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed: %v", err)
@@ -135,7 +141,7 @@ func AWSSSMChaosByID(ctx context.Context, clients clients.ClientSets) {
 	log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentsDetails.ExperimentName)
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetExperimentPhase(&chaosDetails, types.PostChaosPhase)
 
 	if chaosDetails.DefaultHealthCheck {
 		//Verify the aws ec2 instance is running (post chaos)
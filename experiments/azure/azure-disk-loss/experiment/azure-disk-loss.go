@@ -6,6 +6,7 @@ import (
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/azure-disk-loss/lib"
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	experimentEnv "github.com/litmuschaos/litmus-go/pkg/azure/disk-loss/environment"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/disk-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
@@ -119,7 +120,12 @@ func AzureDiskLoss(ctx context.Context, clients clients.ClientSets) {
 		}
 	}
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	// validating the approval gates and waiting for the approval before injecting the chaos, if the inject gate is enabled
+	if !approval.ProceedWithInjection(ctx, clients, &resultDetails, &eventsDetails, &chaosDetails) {
+		return
+	}
+
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err = litmusLIB.PrepareChaos(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
@@ -130,7 +136,7 @@ func AzureDiskLoss(ctx context.Context, clients clients.ClientSets) {
 	log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentsDetails.ExperimentName)
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetExperimentPhase(&chaosDetails, types.PostChaosPhase)
 
 	// POST-CHAOS VIRTUAL DISK STATUS CHECK
 	if chaosDetails.DefaultHealthCheck {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/azure-instance-stop/lib"
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	experimentEnv "github.com/litmuschaos/litmus-go/pkg/azure/instance-stop/environment"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/azure/instance-stop/types"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
@@ -121,7 +122,12 @@ func AzureInstanceStop(ctx context.Context, clients clients.ClientSets) {
 		log.Info("[Status]: Azure instance(s) is in running state (pre-chaos)")
 	}
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	// validating the approval gates and waiting for the approval before injecting the chaos, if the inject gate is enabled
+	if !approval.ProceedWithInjection(ctx, clients, &resultDetails, &eventsDetails, &chaosDetails) {
+		return
+	}
+
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err = litmusLIB.PrepareAzureStop(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed: %v", err)
@@ -132,7 +138,7 @@ func AzureInstanceStop(ctx context.Context, clients clients.ClientSets) {
 	log.Info("[Confirmation]: Azure instance stop chaos has been injected successfully")
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetExperimentPhase(&chaosDetails, types.PostChaosPhase)
 
 	//Verify the azure instance is running (post chaos)
 	if chaosDetails.DefaultHealthCheck {
@@ -0,0 +1,215 @@
+package experiment
+
+import (
+	"context"
+
+	containerKillLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/container-kill/lib"
+	diskFillLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/disk-fill/lib"
+	httpLatencyLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/latency"
+	httpResetLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/reset"
+	httpStatusCodeLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/http-chaos/lib/statuscode"
+	networkChaosLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib"
+	networkCorruptionLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/corruption"
+	networkDuplicationLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/duplication"
+	networkLatencyLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/latency"
+	networkLossLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/loss"
+	networkRateLimitLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/ratelimit"
+	nodeCPUHogLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/node-cpu-hog/lib"
+	nodeIOStressLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/node-io-stress/lib"
+	nodeMemoryHogLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/node-memory-hog/lib"
+	podCPUHogExecLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-cpu-hog-exec/lib"
+	podDeleteLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-delete/lib"
+	podDNSChaosLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-dns-chaos/lib"
+	podMemoryHogExecLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/pod-memory-hog-exec/lib"
+	stressChaosLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/stress-chaos/lib"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	containerKillEnv "github.com/litmuschaos/litmus-go/pkg/generic/container-kill/environment"
+	containerKillTypes "github.com/litmuschaos/litmus-go/pkg/generic/container-kill/types"
+	diskFillEnv "github.com/litmuschaos/litmus-go/pkg/generic/disk-fill/environment"
+	diskFillTypes "github.com/litmuschaos/litmus-go/pkg/generic/disk-fill/types"
+	httpChaosEnv "github.com/litmuschaos/litmus-go/pkg/generic/http-chaos/environment"
+	httpChaosTypes "github.com/litmuschaos/litmus-go/pkg/generic/http-chaos/types"
+	networkChaosEnv "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/environment"
+	networkChaosTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	nodeCPUHogEnv "github.com/litmuschaos/litmus-go/pkg/generic/node-cpu-hog/environment"
+	nodeCPUHogTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-cpu-hog/types"
+	nodeIOStressEnv "github.com/litmuschaos/litmus-go/pkg/generic/node-io-stress/environment"
+	nodeIOStressTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-io-stress/types"
+	nodeMemoryHogEnv "github.com/litmuschaos/litmus-go/pkg/generic/node-memory-hog/environment"
+	nodeMemoryHogTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-memory-hog/types"
+	podCPUHogExecEnv "github.com/litmuschaos/litmus-go/pkg/generic/pod-cpu-hog-exec/environment"
+	podCPUHogExecTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-cpu-hog-exec/types"
+	podDeleteEnv "github.com/litmuschaos/litmus-go/pkg/generic/pod-delete/environment"
+	podDeleteTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-delete/types"
+	podDNSChaosEnv "github.com/litmuschaos/litmus-go/pkg/generic/pod-dns-chaos/environment"
+	podDNSChaosTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-dns-chaos/types"
+	podMemoryHogExecEnv "github.com/litmuschaos/litmus-go/pkg/generic/pod-memory-hog-exec/environment"
+	podMemoryHogExecTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-memory-hog-exec/types"
+	stressChaosEnv "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/environment"
+	stressChaosTypes "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/scenario"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// faults contains the faults, which can be composed in a scenario
+// each entry reads the tunables of the fault in the same way as its experiment, and injects it through its chaoslib
+var faults = scenario.Registry{
+	"container-kill": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := containerKillTypes.ExperimentDetails{}
+		containerKillEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return containerKillLIB.PrepareContainerKill(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"disk-fill": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := diskFillTypes.ExperimentDetails{}
+		diskFillEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return diskFillLIB.PrepareDiskFill(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"node-cpu-hog": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := nodeCPUHogTypes.ExperimentDetails{}
+		nodeCPUHogEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return nodeCPUHogLIB.PrepareNodeCPUHog(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"node-io-stress": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := nodeIOStressTypes.ExperimentDetails{}
+		nodeIOStressEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return nodeIOStressLIB.PrepareNodeIOStress(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"node-memory-hog": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := nodeMemoryHogTypes.ExperimentDetails{}
+		nodeMemoryHogEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return nodeMemoryHogLIB.PrepareNodeMemoryHog(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-cpu-hog":    stressChaosFault("pod-cpu-hog"),
+	"pod-memory-hog": stressChaosFault("pod-memory-hog"),
+	"pod-io-stress":  stressChaosFault("pod-io-stress"),
+	"pod-cpu-hog-exec": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := podCPUHogExecTypes.ExperimentDetails{}
+		podCPUHogExecEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return podCPUHogExecLIB.PrepareCPUExecStress(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-memory-hog-exec": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := podMemoryHogExecTypes.ExperimentDetails{}
+		podMemoryHogExecEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return podMemoryHogExecLIB.PrepareMemoryExecStress(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-delete": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := podDeleteTypes.ExperimentDetails{}
+		podDeleteEnv.GetENV(&experimentsDetails)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return podDeleteLIB.PreparePodDelete(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-dns-error": podDNSChaosFault(podDNSChaosEnv.Error),
+	"pod-dns-spoof": podDNSChaosFault(podDNSChaosEnv.Spoof),
+	"pod-http-latency": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := httpChaosTypes.ExperimentDetails{}
+		httpChaosEnv.GetENV(&experimentsDetails, "pod-http-latency")
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return httpLatencyLIB.PodHttpLatencyChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-http-reset-peer": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := httpChaosTypes.ExperimentDetails{}
+		httpChaosEnv.GetENV(&experimentsDetails, "pod-http-reset-peer")
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return httpResetLIB.PodHttpResetPeerChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-http-status-code": func(chaosDetails *types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := httpChaosTypes.ExperimentDetails{}
+		httpChaosEnv.GetENV(&experimentsDetails, "pod-http-status-code")
+		var err error
+		if experimentsDetails.StatusCode, err = httpStatusCodeLIB.GetStatusCode(experimentsDetails.StatusCode, chaosDetails); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return httpStatusCodeLIB.PodHttpStatusCodeChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-network-corruption": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := networkChaosTypes.ExperimentDetails{}
+		networkChaosEnv.GetENV(&experimentsDetails, "pod-network-corruption")
+		if err := networkChaosLIB.ValidateNetemTunables(&experimentsDetails); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return networkCorruptionLIB.PodNetworkCorruptionChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-network-duplication": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := networkChaosTypes.ExperimentDetails{}
+		networkChaosEnv.GetENV(&experimentsDetails, "pod-network-duplication")
+		if err := networkChaosLIB.ValidateNetemTunables(&experimentsDetails); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return networkDuplicationLIB.PodNetworkDuplicationChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-network-latency": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := networkChaosTypes.ExperimentDetails{}
+		networkChaosEnv.GetENV(&experimentsDetails, "pod-network-latency")
+		if err := networkChaosLIB.ValidateNetemTunables(&experimentsDetails); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return networkLatencyLIB.PodNetworkLatencyChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-network-loss": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := networkChaosTypes.ExperimentDetails{}
+		networkChaosEnv.GetENV(&experimentsDetails, "pod-network-loss")
+		if err := networkChaosLIB.ValidateNetemTunables(&experimentsDetails); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return networkLossLIB.PodNetworkLossChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+	"pod-network-rate-limit": func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := networkChaosTypes.ExperimentDetails{}
+		networkChaosEnv.GetENV(&experimentsDetails, "pod-network-rate-limit")
+		if err := networkRateLimitLIB.ValidateTunables(&experimentsDetails); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return networkRateLimitLIB.PodNetworkRateLimitChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	},
+}
+
+// stressChaosFault returns the stress-chaos fault of the given experiment
+func stressChaosFault(expName string) func(*types.ChaosDetails) (scenario.Injector, error) {
+	return func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := stressChaosTypes.ExperimentDetails{}
+		stressChaosEnv.GetENV(&experimentsDetails, expName)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return stressChaosLIB.PrepareAndInjectStressChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	}
+}
+
+// podDNSChaosFault returns the dns-chaos fault of the given type
+func podDNSChaosFault(chaosType podDNSChaosEnv.DNSChaosType) func(*types.ChaosDetails) (scenario.Injector, error) {
+	return func(*types.ChaosDetails) (scenario.Injector, error) {
+		experimentsDetails := podDNSChaosTypes.ExperimentDetails{}
+		podDNSChaosEnv.GetENV(&experimentsDetails, chaosType)
+		return func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			return podDNSChaosLIB.PrepareAndInjectChaos(ctx, &experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+		}, nil
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	kafkaPodDelete "github.com/litmuschaos/litmus-go/chaoslib/litmus/kafka-broker-pod-failure/lib"
+	"github.com/litmuschaos/litmus-go/pkg/approval"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/kafka"
@@ -31,7 +32,7 @@ func KafkaBrokerPodFailure(ctx context.Context, clients clients.ClientSets) {
 
 	//Fetching all the ENV passed from the runner pod
 	log.Infof("[PreReq]: Getting the ENV for the %v experiment", os.Getenv("EXPERIMENT_NAME"))
-	experimentEnv.GetENV(&experimentsDetails)
+	envErr := experimentEnv.GetENV(&experimentsDetails)
 
 	// Initialize the chaos attributes
 	types.InitialiseChaosVariables(&chaosDetails)
@@ -58,6 +59,13 @@ func KafkaBrokerPodFailure(ctx context.Context, clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
+	// the malformed targets fail the experiment, rather than being ignored
+	if envErr != nil {
+		log.Errorf("Unable to get the target details, err: %v", envErr)
+		result.RecordAfterFailure(&chaosDetails, &resultDetails, envErr, clients, &eventsDetails)
+		return
+	}
+
 	// generating the event in chaosresult to marked the verdict as awaited
 	msg := "experiment: " + experimentsDetails.ChaoslibDetail.ExperimentName + ", Result: Awaited"
 	types.SetResultEventAttributes(&eventsDetails, types.AwaitedVerdict, msg, "Normal", &resultDetails)
@@ -74,7 +82,7 @@ func KafkaBrokerPodFailure(ctx context.Context, clients clients.ClientSets) {
 	// KAFKA CLUSTER HEALTH CHECK
 	if chaosDetails.DefaultHealthCheck {
 		log.Info("[Status]: Verify that the Kafka cluster is healthy(pre-chaos)")
-		if err := kafka.ClusterHealthCheck(&experimentsDetails, clients); err != nil {
+		if err := kafka.ClusterHealthCheck(ctx, &experimentsDetails, clients); err != nil {
 			log.Errorf("Cluster health check failed, err: %v", err)
 			types.SetEngineEventAttributes(&eventsDetails, types.PreChaosCheck, "AUT: Not Running", "Warning", &chaosDetails)
 			events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosEngine")
@@ -108,7 +116,7 @@ func KafkaBrokerPodFailure(ctx context.Context, clients clients.ClientSets) {
 	// PRE-CHAOS KAFKA APPLICATION LIVENESS CHECK
 	switch strings.ToLower(experimentsDetails.KafkaLivenessStream) {
 	case "enable":
-		livenessTopicLeader, err := kafka.LivenessStream(&experimentsDetails, clients)
+		livenessTopicLeader, err := kafka.LivenessStream(ctx, &experimentsDetails, clients)
 		if err != nil {
 			log.Errorf("Liveness check failed, err: %v", err)
 			result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
@@ -124,7 +132,12 @@ func KafkaBrokerPodFailure(ctx context.Context, clients clients.ClientSets) {
 
 	kafka.DisplayKafkaBroker(&experimentsDetails)
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	// validating the approval gates and waiting for the approval before injecting the chaos, if the inject gate is enabled
+	if !approval.ProceedWithInjection(ctx, clients, &resultDetails, &eventsDetails, &chaosDetails) {
+		return
+	}
+
+	types.SetExperimentPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err := kafkaPodDelete.PreparePodDelete(ctx, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed, err: %v", err)
@@ -135,12 +148,12 @@ func KafkaBrokerPodFailure(ctx context.Context, clients clients.ClientSets) {
 	log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentsDetails.ExperimentName)
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetExperimentPhase(&chaosDetails, types.PostChaosPhase)
 
 	// POST-CHAOS KAFKA CLUSTER HEALTH CHECK
 	if chaosDetails.DefaultHealthCheck {
 		log.Info("[Status]: Verify that the Kafka cluster is healthy(post-chaos)")
-		if err := kafka.ClusterHealthCheck(&experimentsDetails, clients); err != nil {
+		if err := kafka.ClusterHealthCheck(ctx, &experimentsDetails, clients); err != nil {
 			log.Errorf("Cluster health check failed, err: %v", err)
 			types.SetEngineEventAttributes(&eventsDetails, types.PostChaosCheck, "AUT: Not Running", "Warning", &chaosDetails)
 			events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosEngine")
@@ -175,7 +188,7 @@ func KafkaBrokerPodFailure(ctx context.Context, clients clients.ClientSets) {
 	switch strings.ToLower(experimentsDetails.KafkaLivenessStream) {
 	case "enable":
 		log.Info("[Status]: Verify that the Kafka liveness pod is running(post-chaos)")
-		if err := status.CheckApplicationStatusesByLabels(experimentsDetails.ChaoslibDetail.AppNS, "name=kafka-liveness-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
+		if err := status.CheckApplicationStatusesByLabels(ctx, experimentsDetails.ChaoslibDetail.AppNS, "name=kafka-liveness-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
 			log.Errorf("Application liveness status check failed, err: %v", err)
 			result.RecordAfterFailure(&chaosDetails, &resultDetails, err, clients, &eventsDetails)
 			return
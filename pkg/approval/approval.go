@@ -0,0 +1,206 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProceedKey is the annotation or label, which approves the injection once it is set to true
+	ProceedKey = "litmuschaos.io/proceed"
+	// ProceedRevertKey is the annotation or label, which approves the revert once it is set to true
+	ProceedRevertKey = "litmuschaos.io/proceed-revert"
+	// ResultPhaseAwaitingApproval is the phase of the chaosresult, while the run waits for the approval
+	ResultPhaseAwaitingApproval v1alpha1.ResultPhase = "Awaiting_Approval"
+)
+
+// pollInterval is the interval between the checks of the approval, it is replaced in the tests
+var pollInterval = 5 * time.Second
+
+// revertGateExperiments are the experiments, which wait for the approval of the revert gate before reverting the chaos
+var revertGateExperiments = map[string]bool{
+	"disk-fill":               true,
+	"node-drain":              true,
+	"node-taint":              true,
+	"pod-http-latency":        true,
+	"pod-http-modify-body":    true,
+	"pod-http-modify-header":  true,
+	"pod-http-reset-peer":     true,
+	"pod-http-status-code":    true,
+	"pod-network-corruption":  true,
+	"pod-network-duplication": true,
+	"pod-network-latency":     true,
+	"pod-network-loss":        true,
+	"pod-network-partition":   true,
+	"pod-network-rate-limit":  true,
+}
+
+// ProceedWithInjection validates the gates and waits for the approval before injecting the chaos, if the inject gate is enabled
+// The failure is recorded in the chaosresult, it returns false if the experiment should not move ahead
+func ProceedWithInjection(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) bool {
+	err := ValidateGates(chaosDetails)
+	if err == nil {
+		err = WaitForApproval(ctx, types.ApprovalGateInject, clients, resultDetails, eventsDetails, chaosDetails)
+	}
+	if err != nil {
+		log.Errorf("Approval to inject the chaos failed, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, resultDetails, err, clients, eventsDetails)
+		return false
+	}
+	return true
+}
+
+// ValidateGates checks if the enabled gates are supported by the experiment
+// The revert gate is only honoured by the experiments, which can hold the chaos till the approval,
+// so the experiment fails before the injection, rather than reverting the chaos without the approval
+func ValidateGates(chaosDetails *types.ChaosDetails) error {
+	if chaosDetails.Approval.IsEnabled(types.ApprovalGateRevert) && !revertGateExperiments[chaosDetails.ExperimentName] {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("the %s gate isn't supported by the %s experiment", types.ApprovalGateRevert, chaosDetails.ExperimentName)}
+	}
+	return nil
+}
+
+// WaitForApproval waits for the approval of the gate, if the gate is enabled through the APPROVAL_GATES env
+// The approval is granted by setting the key of the gate to true, in the annotations or labels of the chaosengine,
+// or of the APPROVAL_CONFIGMAP if provided. The chaosresult is in the Awaiting_Approval phase during the wait,
+// the resultDetails is nil for the helpers, as the chaosresult is owned by the experiment pod.
+// The injection fails if it isn't approved within the timeout, whereas the revert moves ahead,
+// so that the chaos is never left behind on the targets
+func WaitForApproval(ctx context.Context, gate string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	if !chaosDetails.Approval.IsEnabled(gate) {
+		return nil
+	}
+
+	key, kind, name, err := getApprovalSource(gate, chaosDetails)
+	if err != nil {
+		return err
+	}
+	source := kind + "/" + name
+	log.Infof("[Approval]: Waiting up to %vs for %s=true on the %s, before the %s", chaosDetails.Approval.Timeout, key, source, gate)
+	generateEvent(fmt.Sprintf("Waiting for %s=true on the %s, before the %s", key, source, gate), types.AwaitingApproval, "Normal", clients, eventsDetails, chaosDetails)
+
+	if resultDetails != nil {
+		phase := resultDetails.Phase
+		setResultPhase(ResultPhaseAwaitingApproval, clients, resultDetails, chaosDetails)
+		defer setResultPhase(phase, clients, resultDetails, chaosDetails)
+	}
+
+	err = waitForKey(ctx, time.Duration(chaosDetails.Approval.Timeout)*time.Second, func() (bool, error) {
+		return isApproved(ctx, key, clients, chaosDetails)
+	})
+	switch {
+	case err == nil:
+		log.Infof("[Approval]: The %s is approved", gate)
+		generateEvent(fmt.Sprintf("The %s is approved through the %s", gate, source), types.Approved, "Normal", clients, eventsDetails, chaosDetails)
+		return nil
+	case ctx.Err() != nil:
+		if gate == types.ApprovalGateRevert {
+			// the aborted run reverts the chaos, without the approval
+			return nil
+		}
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: fmt.Sprintf("experiment aborted while waiting for the approval, err: %v", context.Cause(ctx))}
+	case gate == types.ApprovalGateRevert:
+		log.Warnf("[Approval]: The revert isn't approved within %vs, reverting the chaos", chaosDetails.Approval.Timeout)
+		generateEvent(fmt.Sprintf("The revert isn't approved within %vs, reverting the chaos", chaosDetails.Approval.Timeout), types.AwaitingApproval, "Warning", clients, eventsDetails, chaosDetails)
+		return nil
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeApprovalTimeout, Target: fmt.Sprintf("{kind: %s, name: %s, namespace: %s}", kind, name, chaosDetails.ChaosNamespace), Reason: fmt.Sprintf("the %s isn't approved within %vs", gate, chaosDetails.Approval.Timeout)}
+	}
+}
+
+// getApprovalSource returns the key, which approves the gate, along with the kind and name of the resource, which should contain it
+func getApprovalSource(gate string, chaosDetails *types.ChaosDetails) (string, string, string, error) {
+	key := ProceedKey
+	if gate == types.ApprovalGateRevert {
+		key = ProceedRevertKey
+	}
+
+	switch {
+	case chaosDetails.Approval.ConfigMap != "":
+		return key, "configmap", chaosDetails.Approval.ConfigMap, nil
+	case chaosDetails.EngineName != "":
+		return key, "chaosengine", chaosDetails.EngineName, nil
+	default:
+		return "", "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("the %s gate needs either the chaosengine or the APPROVAL_CONFIGMAP env, to look for the approval", gate)}
+	}
+}
+
+// isApproved returns true if the key is set to true, in the annotations or labels of the approval source
+func isApproved(ctx context.Context, key string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (bool, error) {
+	var meta v1.ObjectMeta
+	if chaosDetails.Approval.ConfigMap != "" {
+		configMap, err := clients.KubeClient.CoreV1().ConfigMaps(chaosDetails.ChaosNamespace).Get(ctx, chaosDetails.Approval.ConfigMap, v1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		meta = configMap.ObjectMeta
+	} else {
+		engine, err := clients.LitmusClient.ChaosEngines(chaosDetails.ChaosNamespace).Get(ctx, chaosDetails.EngineName, v1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		meta = engine.ObjectMeta
+	}
+	return strings.EqualFold(meta.Annotations[key], "true") || strings.EqualFold(meta.Labels[key], "true"), nil
+}
+
+// waitForKey checks the approval until it is granted, the timeout expires or the context is cancelled
+// the errors while checking the approval are logged and retried, till the timeout
+func waitForKey(ctx context.Context, timeout time.Duration, approved func() (bool, error)) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := approved()
+		if err != nil {
+			log.Warnf("[Approval]: Unable to check the approval, err: %v", err)
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-timer.C:
+			return context.DeadlineExceeded
+		case <-ticker.C:
+		}
+	}
+}
+
+// setResultPhase updates the phase of the chaosresult, the failures are only logged as the phase is informational
+func setResultPhase(phase v1alpha1.ResultPhase, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	resultDetails.Phase = phase
+	if err := result.UpdateResultPhase(resultDetails, chaosDetails, clients); err != nil {
+		log.Errorf("Unable to update the phase of the chaosresult to %s, err: %v", phase, err)
+	}
+}
+
+// generateEvent generates the approval event inside the chaosengine
+func generateEvent(msg, reason, eventType string, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) {
+	if chaosDetails.EngineName == "" {
+		return
+	}
+	types.SetEngineEventAttributes(eventsDetails, reason, msg, eventType, chaosDetails)
+	if err := events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine"); err != nil {
+		log.Errorf("failed to create %v event inside chaosengine", reason)
+	}
+}
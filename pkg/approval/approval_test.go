@@ -0,0 +1,138 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/fake"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newClients(objects ...*corev1.ConfigMap) (clients.ClientSets, *fake.Clientset) {
+	litmusClient := fake.NewSimpleClientset(
+		&v1alpha1.ChaosEngine{ObjectMeta: v1.ObjectMeta{Name: "nginx-chaos", Namespace: "litmus"}},
+		&v1alpha1.ChaosResult{ObjectMeta: v1.ObjectMeta{Name: "nginx-chaos-pod-delete", Namespace: "litmus"}},
+	)
+	kubeClient := k8sfake.NewSimpleClientset()
+	for _, object := range objects {
+		kubeClient.Tracker().Add(object)
+	}
+	return clients.ClientSets{KubeClient: kubeClient, LitmusClient: litmusClient.LitmuschaosV1alpha1()}, litmusClient
+}
+
+func newChaosDetails(gates ...string) *types.ChaosDetails {
+	return &types.ChaosDetails{
+		ChaosNamespace: "litmus",
+		EngineName:     "nginx-chaos",
+		ExperimentName: "pod-delete",
+		Approval:       types.Approval{Gates: gates, Timeout: 1},
+	}
+}
+
+func TestWaitForApprovalIsDisabled(t *testing.T) {
+	err := WaitForApproval(context.Background(), types.ApprovalGateInject, clients.ClientSets{}, nil, &types.EventDetails{}, newChaosDetails(types.ApprovalGateRevert))
+	assert.NoError(t, err)
+}
+
+func TestWaitForApproval(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	clientSets, litmusClient := newClients()
+	chaosDetails := newChaosDetails(types.ApprovalGateInject)
+	chaosDetails.Approval.Timeout = 10
+	resultDetails := &types.ResultDetails{Name: "nginx-chaos-pod-delete", Phase: v1alpha1.ResultPhaseRunning}
+
+	done := make(chan error)
+	go func() {
+		done <- WaitForApproval(context.Background(), types.ApprovalGateInject, clientSets, resultDetails, &types.EventDetails{}, chaosDetails)
+	}()
+
+	getPhase := func() v1alpha1.ResultPhase {
+		result, err := litmusClient.LitmuschaosV1alpha1().ChaosResults("litmus").Get(context.Background(), "nginx-chaos-pod-delete", v1.GetOptions{})
+		require.NoError(t, err)
+		return result.Status.ExperimentStatus.Phase
+	}
+	require.Eventually(t, func() bool { return getPhase() == ResultPhaseAwaitingApproval }, 5*time.Second, 10*time.Millisecond)
+
+	// the approval of the revert doesn't approve the injection
+	engine, err := litmusClient.LitmuschaosV1alpha1().ChaosEngines("litmus").Get(context.Background(), "nginx-chaos", v1.GetOptions{})
+	require.NoError(t, err)
+	engine.Annotations = map[string]string{ProceedRevertKey: "true"}
+	engine, err = litmusClient.LitmuschaosV1alpha1().ChaosEngines("litmus").Update(context.Background(), engine, v1.UpdateOptions{})
+	require.NoError(t, err)
+	select {
+	case err := <-done:
+		t.Fatalf("injection shouldn't be approved, err: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	engine.Annotations[ProceedKey] = "true"
+	_, err = litmusClient.LitmuschaosV1alpha1().ChaosEngines("litmus").Update(context.Background(), engine, v1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, <-done)
+	assert.Equal(t, v1alpha1.ResultPhaseRunning, getPhase())
+	assert.Equal(t, v1alpha1.ResultPhaseRunning, resultDetails.Phase)
+}
+
+func TestWaitForApprovalFromConfigMap(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	clientSets, _ := newClients(&corev1.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: "gameday", Namespace: "litmus", Labels: map[string]string{ProceedRevertKey: "True"}}})
+	chaosDetails := newChaosDetails(types.ApprovalGateRevert)
+	chaosDetails.Approval.ConfigMap = "gameday"
+
+	assert.NoError(t, WaitForApproval(context.Background(), types.ApprovalGateRevert, clientSets, nil, &types.EventDetails{}, chaosDetails))
+}
+
+func TestWaitForApprovalTimeout(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	clientSets, _ := newClients()
+
+	err := WaitForApproval(context.Background(), types.ApprovalGateInject, clientSets, nil, &types.EventDetails{}, newChaosDetails(types.ApprovalGateInject))
+	assert.Equal(t, cerrors.ErrorTypeApprovalTimeout, cerrors.GetErrorType(err))
+	assert.ErrorContains(t, err, "the inject isn't approved within 1s")
+
+	// the revert moves ahead, so that the chaos isn't left behind
+	assert.NoError(t, WaitForApproval(context.Background(), types.ApprovalGateRevert, clientSets, nil, &types.EventDetails{}, newChaosDetails(types.ApprovalGateRevert)))
+}
+
+func TestWaitForApprovalIsAborted(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+	clientSets, _ := newClients()
+	chaosDetails := newChaosDetails(types.ApprovalGateInject, types.ApprovalGateRevert)
+	chaosDetails.Approval.Timeout = 60
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := WaitForApproval(ctx, types.ApprovalGateInject, clientSets, nil, &types.EventDetails{}, chaosDetails)
+	assert.Equal(t, cerrors.ErrorTypeExperimentAborted, cerrors.GetErrorType(err))
+	assert.NoError(t, WaitForApproval(ctx, types.ApprovalGateRevert, clientSets, nil, &types.EventDetails{}, chaosDetails))
+}
+
+func TestWaitForApprovalWithoutSource(t *testing.T) {
+	chaosDetails := newChaosDetails(types.ApprovalGateInject)
+	chaosDetails.EngineName = ""
+
+	err := WaitForApproval(context.Background(), types.ApprovalGateInject, clients.ClientSets{}, nil, &types.EventDetails{}, chaosDetails)
+	assert.ErrorContains(t, err, "needs either the chaosengine or the APPROVAL_CONFIGMAP env")
+}
+
+func TestValidateGates(t *testing.T) {
+	chaosDetails := newChaosDetails(types.ApprovalGateInject)
+	assert.NoError(t, ValidateGates(chaosDetails))
+
+	chaosDetails = newChaosDetails(types.ApprovalGateRevert)
+	err := ValidateGates(chaosDetails)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "isn't supported by the pod-delete experiment")
+
+	chaosDetails.ExperimentName = "pod-network-loss"
+	assert.NoError(t, ValidateGates(chaosDetails))
+}
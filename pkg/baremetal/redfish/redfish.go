@@ -2,6 +2,7 @@ package redfish
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // State helps get the power state of the node
@@ -52,7 +55,10 @@ func GetNodeStatus(IP, user, password string) (string, error) {
 }
 
 // RebootNode triggers hard reset on the target baremetal node
-func RebootNode(URL, user, password string) error {
+func RebootNode(ctx context.Context, URL, user, password string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "redfish.ComputerSystem.Reset", attribute.String("cloud.provider", "redfish"), attribute.String("url.full", URL))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	data := map[string]string{"ResetType": "ForceRestart"}
 	json_data, err := json.Marshal(data)
 	auth := user + ":" + password
@@ -61,7 +67,7 @@ func RebootNode(URL, user, password string) error {
 		log.Error(err.Error())
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Reason: fmt.Sprintf("unable to encode the authentication credentials, err: %v", err)}
 	}
-	req, err := http.NewRequest("POST", URL, bytes.NewBuffer(json_data))
+	req, err := http.NewRequestWithContext(ctx, "POST", URL, bytes.NewBuffer(json_data))
 	if err != nil {
 		log.Errorf("Error creating HTTP post request, err: %v", err)
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Reason: fmt.Sprintf("error creating http post request, err: %v", err)}
@@ -25,7 +25,7 @@ import (
 )
 
 // LivenessCheck will create an external liveness pod which will continuously check for the liveness of cassandra statefulset
-func LivenessCheck(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
+func LivenessCheck(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
 
 	// Generate the run_id for the liveness pod
 	experimentsDetails.RunID = stringutils.GetRunID()
@@ -42,7 +42,7 @@ func LivenessCheck(experimentsDetails *experimentTypes.ExperimentDetails, client
 
 	// Checking the status of liveness deployment pod
 	log.Info("[Status]: Checking the status of the cassandra liveness pod")
-	if err := status.CheckApplicationStatusesByLabels(experimentsDetails.ChaoslibDetail.AppNS, "name=cassandra-liveness-deploy-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
+	if err := status.CheckApplicationStatusesByLabels(ctx, experimentsDetails.ChaoslibDetail.AppNS, "name=cassandra-liveness-deploy-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
 		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Reason: fmt.Sprintf("liveness pod is not in running state, %s", err.Error())}
 	}
 
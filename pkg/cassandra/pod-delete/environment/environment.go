@@ -1,16 +1,18 @@
 package environment
 
 import (
+	"fmt"
 	"strconv"
 
 	cassandraTypes "github.com/litmuschaos/litmus-go/pkg/cassandra/pod-delete/types"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	exp "github.com/litmuschaos/litmus-go/pkg/generic/pod-delete/types"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	clientTypes "k8s.io/apimachinery/pkg/types"
 )
 
-// GetENV fetches all the env variables from the runner pod
-func GetENV(cassandraDetails *cassandraTypes.ExperimentDetails) {
+// GetENV fetches all the env variables from the runner pod, it returns the error if the targets are malformed
+func GetENV(cassandraDetails *cassandraTypes.ExperimentDetails) error {
 
 	var ChaoslibDetail exp.ExperimentDetails
 
@@ -39,14 +41,20 @@ func GetENV(cassandraDetails *cassandraTypes.ExperimentDetails) {
 	cassandraDetails.CassandraLivenessCheck = types.Getenv("CASSANDRA_LIVENESS_CHECK", "")
 	cassandraDetails.RunID = types.Getenv("RunID", "")
 
-	ChaoslibDetail.AppNS, ChaoslibDetail.AppKind, ChaoslibDetail.AppLabel = getAppDetails()
+	var err error
+	ChaoslibDetail.AppNS, ChaoslibDetail.AppKind, ChaoslibDetail.AppLabel, err = getAppDetails()
+	return err
 }
 
-func getAppDetails() (string, string, string) {
+// getAppDetails returns the namespace, kind and label of the first target, it returns the error if the targets are malformed
+func getAppDetails() (string, string, string, error) {
 	targets := types.Getenv("TARGETS", "")
-	app := types.GetTargets(targets)
-	if len(app) != 0 {
-		return app[0].Namespace, app[0].Kind, app[0].Labels[0]
+	app, err := types.ParseTargets(targets)
+	if err != nil {
+		return "", "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("unable to parse the TARGETS env: %v", err)}
 	}
-	return "", "", ""
+	if len(app) != 0 && len(app[0].Labels) != 0 {
+		return app[0].Namespace, app[0].Kind, app[0].Labels[0], nil
+	}
+	return "", "", "", nil
 }
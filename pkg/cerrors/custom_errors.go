@@ -29,6 +29,8 @@ const (
 	ErrorTypePromProbe         ErrorType = "PROM_PROBE_ERROR"
 	FailureTypePromProbe       ErrorType = "PROM_PROBE_FAILURE"
 	ErrorTypeTimeout           ErrorType = "TIMEOUT"
+	ErrorTypeApprovalTimeout   ErrorType = "APPROVAL_TIMEOUT"
+	ErrorTypeSteadyState       ErrorType = "STEADY_STATE_ERROR"
 	FailureTypeProbeTimeout    ErrorType = "PROBE_TIMEOUT"
 )
 
@@ -65,7 +67,8 @@ func GetRootCauseAndErrorCode(err error, phase string) (string, ErrorType) {
 		if error.Phase == "" {
 			error.Phase = phase
 		}
-		return error.Error(), errorType
+		// the classification is added to the fail step, so that the automation can decide whether to re-run
+		return error.Classified().Error(), errorType
 	}
 	return rootCause.Error(), errorType
 }
@@ -76,6 +79,10 @@ type Error struct {
 	Phase     string    `json:"phase,omitempty"`
 	Reason    string    `json:"reason,omitempty"`
 	Target    string    `json:"target,omitempty"`
+	// Category, Retryable and Remediation are derived from the error code and reason, if not set explicitly
+	Category    Category `json:"category,omitempty"`
+	Retryable   bool     `json:"retryable,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
 }
 
 func (e Error) Error() string {
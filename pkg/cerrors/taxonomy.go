@@ -0,0 +1,171 @@
+package cerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/palantir/stacktrace"
+)
+
+// Category groups the errors by their cause, so that the automation can decide how to react on them
+type Category string
+
+const (
+	CategoryUserConfig     Category = "USER_CONFIG"
+	CategoryPermission     Category = "PERMISSION"
+	CategoryInfraTransient Category = "INFRA_TRANSIENT"
+	CategoryTargetNotFound Category = "TARGET_NOT_FOUND"
+	CategoryRevertFailure  Category = "REVERT_FAILURE"
+	CategoryProbeFailure   Category = "PROBE_FAILURE"
+	CategoryUnknown        Category = "UNKNOWN"
+)
+
+// Classification contains the category, retryability and remediation hint of an error
+type Classification struct {
+	Category    Category
+	Retryable   bool
+	Remediation string
+}
+
+// classifications contains the default classification of the error types
+var classifications = map[ErrorType]Classification{
+	ErrorTypeGeneric:           {Category: CategoryUnknown},
+	ErrorTypeNonUserFriendly:   {Category: CategoryUnknown},
+	ErrorTypeChaosResultCRUD:   {Category: CategoryInfraTransient, Retryable: true, Remediation: "check the connectivity with the kubernetes api server and re-run the experiment"},
+	ErrorTypeStatusChecks:      {Category: CategoryInfraTransient, Retryable: true, Remediation: "ensure the application and auxiliary applications are healthy before the chaos, or increase the status check timeout"},
+	ErrorTypeTargetSelection:   {Category: CategoryTargetNotFound, Remediation: "verify the target details (appinfo, TARGET_PODS, TARGET_NODES, labels) and ensure the targets are present"},
+	ErrorTypeExperimentAborted: {Category: CategoryUnknown, Retryable: true, Remediation: "the experiment was aborted, re-run it if the abort was not intended"},
+	ErrorTypeHelper:            {Category: CategoryUserConfig, Remediation: "verify the experiment tunables and the helper pod logs"},
+	ErrorTypeHelperPodFailed:   {Category: CategoryInfraTransient, Retryable: true, Remediation: "check the helper pod logs and the status of the node on which it was scheduled"},
+	ErrorTypeContainerRuntime:  {Category: CategoryUserConfig, Remediation: "verify the CONTAINER_RUNTIME and SOCKET_PATH tunables for the target nodes"},
+	ErrorTypeChaosInject:       {Category: CategoryUnknown, Remediation: "check the experiment logs for the failed injection"},
+	ErrorTypeChaosRevert:       {Category: CategoryRevertFailure, Remediation: "the chaos may still be present on the targets, run the cleanup experiment or revert it manually"},
+	ErrorTypeK8sProbe:          {Category: CategoryUserConfig, Remediation: "verify the k8s probe inputs"},
+	ErrorTypeCmdProbe:          {Category: CategoryUserConfig, Remediation: "verify the cmd probe inputs"},
+	ErrorTypeHttpProbe:         {Category: CategoryUserConfig, Remediation: "verify the http probe inputs"},
+	ErrorTypePromProbe:         {Category: CategoryUserConfig, Remediation: "verify the prometheus probe inputs"},
+	FailureTypeK8sProbe:        {Category: CategoryProbeFailure, Remediation: "the k8s probe criteria were not met during the chaos"},
+	FailureTypeCmdProbe:        {Category: CategoryProbeFailure, Remediation: "the cmd probe criteria were not met during the chaos"},
+	FailureTypeHttpProbe:       {Category: CategoryProbeFailure, Remediation: "the http probe criteria were not met during the chaos"},
+	FailureTypePromProbe:       {Category: CategoryProbeFailure, Remediation: "the prometheus probe criteria were not met during the chaos"},
+	FailureTypeProbeTimeout:    {Category: CategoryProbeFailure, Remediation: "the probe timed out, increase the probe timeout if the target is expected to be slow"},
+	ErrorTypeTimeout:           {Category: CategoryInfraTransient, Retryable: true, Remediation: "the operation timed out, increase the timeout or re-run the experiment"},
+	ErrorTypeApprovalTimeout:   {Category: CategoryUserConfig, Retryable: true, Remediation: "the injection wasn't approved in time, approve it through the chaosengine or the APPROVAL_CONFIGMAP, or increase the APPROVAL_TIMEOUT"},
+	ErrorTypeSteadyState:       {Category: CategoryProbeFailure, Retryable: true, Remediation: "the probes were flaky before the chaos, stabilise the application or raise the BASELINE_MAX_FLAKINESS"},
+}
+
+// permissionMarkers identify the authorization failures of the kubernetes and cloud apis inside the error reasons
+var permissionMarkers = []string{"forbidden", "unauthorized", "accessdenied", "access denied", "authorizationfailed", "permission denied"}
+
+// transientMarkers identify the transient failures inside the error reasons
+var transientMarkers = []string{"connection refused", "connection reset", "i/o timeout", "tls handshake timeout", "too many requests", "the object has been modified", "etcdserver", "context deadline exceeded", "service unavailable"}
+
+// Classify returns the classification of the error
+// The explicit classification of the error takes precedence over the one derived from its error code and reason
+func Classify(err error) Classification {
+	e, ok := AsError(err)
+	if !ok {
+		classification := classifications[GetErrorType(err)]
+		if err != nil {
+			deriveFromReason(&classification, err.Error())
+		}
+		return classification
+	}
+
+	classification, ok := classifications[e.ErrorCode]
+	if !ok {
+		classification = Classification{Category: CategoryUnknown}
+	}
+	deriveFromReason(&classification, e.Reason)
+	if e.Category != "" {
+		classification.Category = e.Category
+	}
+	if e.Retryable {
+		classification.Retryable = true
+	}
+	if e.Remediation != "" {
+		classification.Remediation = e.Remediation
+	}
+	return classification
+}
+
+// deriveFromReason overrides the classification, if the reason indicates a permission or transient failure
+func deriveFromReason(classification *Classification, reason string) {
+	reason = strings.ToLower(reason)
+	switch {
+	case containsAny(reason, permissionMarkers):
+		classification.Category = CategoryPermission
+		classification.Retryable = false
+		classification.Remediation = "grant the missing permissions to the chaos service account or cloud credentials"
+	case classification.Category == CategoryUnknown && containsAny(reason, transientMarkers):
+		classification.Category = CategoryInfraTransient
+		classification.Retryable = true
+	}
+}
+
+// Classified returns a copy of the error, with the category, retryable flag and remediation hint filled in
+func (e Error) Classified() Error {
+	classification := Classify(e)
+	e.Category = classification.Category
+	e.Retryable = classification.Retryable
+	e.Remediation = classification.Remediation
+	return e
+}
+
+// AsError finds the first Error in the chain of the given error, including the root cause of the stacktrace
+func AsError(err error) (Error, bool) {
+	var e Error
+	if err == nil {
+		return e, false
+	}
+	if errors.As(err, &e) {
+		return e, true
+	}
+	if errors.As(stacktrace.RootCause(err), &e) {
+		return e, true
+	}
+	return e, false
+}
+
+// GetCategory returns the category of the error
+func GetCategory(err error) Category {
+	return Classify(err).Category
+}
+
+// IsRetryable returns true if the error is transient and the experiment can be re-run
+func IsRetryable(err error) bool {
+	return err != nil && Classify(err).Retryable
+}
+
+// GetRemediation returns the remediation hint of the error
+func GetRemediation(err error) string {
+	return Classify(err).Remediation
+}
+
+// IsProbeFailure returns true if the error type denotes the failure of a probe
+func (t ErrorType) IsProbeFailure() bool {
+	switch t {
+	case FailureTypeK8sProbe, FailureTypeCmdProbe, FailureTypeHttpProbe, FailureTypePromProbe, FailureTypeProbeTimeout:
+		return true
+	}
+	return false
+}
+
+// ParseError parses the json encoded Error, like the fail step of the chaosresult
+func ParseError(s string) (Error, bool) {
+	var e Error
+	if err := json.Unmarshal([]byte(s), &e); err != nil || e.ErrorCode == "" {
+		return Error{}, false
+	}
+	return e, true
+}
+
+func containsAny(s string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package cerrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+)
+
+const permissionRemediation = "grant the missing permissions to the chaos service account or cloud credentials"
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		err            error
+		category       Category
+		retryable      bool
+		remediation    string
+		hasRemediation bool
+	}{
+		"error code": {
+			err:            Error{ErrorCode: ErrorTypeChaosRevert, Reason: "failed to delete the network policy"},
+			category:       CategoryRevertFailure,
+			hasRemediation: true,
+		},
+		"forbidden reason": {
+			err:         Error{ErrorCode: ErrorTypeStatusChecks, Reason: `pods is forbidden: User "system:serviceaccount:litmus:pod-delete-sa" cannot list resource "pods"`},
+			category:    CategoryPermission,
+			remediation: permissionRemediation,
+		},
+		"cloud access denied": {
+			err:         Error{ErrorCode: ErrorTypeChaosInject, Reason: "AccessDenied: User is not authorized to perform: ec2:StopInstances"},
+			category:    CategoryPermission,
+			remediation: permissionRemediation,
+		},
+		"unauthorized plain error": {
+			err:         errors.New("Unauthorized"),
+			category:    CategoryPermission,
+			remediation: permissionRemediation,
+		},
+		"transient reason of the unknown category": {
+			err:            Error{ErrorCode: ErrorTypeChaosInject, Reason: "dial tcp 10.0.0.1:443: i/o timeout"},
+			category:       CategoryInfraTransient,
+			retryable:      true,
+			hasRemediation: true,
+		},
+		"transient reason of the known category": {
+			err:            Error{ErrorCode: ErrorTypeTargetSelection, Reason: "connection refused"},
+			category:       CategoryTargetNotFound,
+			hasRemediation: true,
+		},
+		"timeout code": {
+			err:            Error{ErrorCode: ErrorTypeTimeout, Reason: "timeout while waiting for the helper pod"},
+			category:       CategoryInfraTransient,
+			retryable:      true,
+			hasRemediation: true,
+		},
+		"deadline exceeded plain error": {
+			err:       errors.New("context deadline exceeded"),
+			category:  CategoryInfraTransient,
+			retryable: true,
+		},
+		"unknown code": {
+			err:      Error{ErrorCode: ErrorType("SOMETHING_ELSE"), Reason: "unexpected failure"},
+			category: CategoryUnknown,
+		},
+		"unknown code with the permission reason": {
+			err:         Error{ErrorCode: ErrorType("SOMETHING_ELSE"), Reason: "permission denied"},
+			category:    CategoryPermission,
+			remediation: permissionRemediation,
+		},
+		"propagated error": {
+			err:            stacktrace.Propagate(Error{ErrorCode: ErrorTypeHelperPodFailed, Reason: "helper pod failed"}, "could not check helper status"),
+			category:       CategoryInfraTransient,
+			retryable:      true,
+			hasRemediation: true,
+		},
+		"explicit classification": {
+			err:         Error{ErrorCode: ErrorTypeChaosInject, Reason: "forbidden", Category: CategoryUserConfig, Retryable: true, Remediation: "fix the tunables"},
+			category:    CategoryUserConfig,
+			retryable:   true,
+			remediation: "fix the tunables",
+		},
+		"nil error": {
+			err:      nil,
+			category: CategoryUnknown,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			classification := Classify(tc.err)
+			assert.Equal(t, tc.category, classification.Category)
+			assert.Equal(t, tc.retryable, classification.Retryable)
+			assert.Equal(t, tc.retryable, IsRetryable(tc.err))
+			switch {
+			case tc.remediation != "":
+				assert.Equal(t, tc.remediation, classification.Remediation)
+			case tc.hasRemediation:
+				assert.NotEmpty(t, classification.Remediation)
+			default:
+				assert.Empty(t, classification.Remediation)
+			}
+		})
+	}
+}
+
+func TestDeriveFromReason(t *testing.T) {
+	testCases := map[string]struct {
+		classification Classification
+		reason         string
+		expected       Classification
+	}{
+		"permission overrides the retryable category": {
+			classification: Classification{Category: CategoryInfraTransient, Retryable: true, Remediation: "re-run"},
+			reason:         "nodes is Forbidden",
+			expected:       Classification{Category: CategoryPermission, Remediation: permissionRemediation},
+		},
+		"authorization failure of the cloud api": {
+			classification: Classification{Category: CategoryUnknown},
+			reason:         "AuthorizationFailed: the client does not have the authorization",
+			expected:       Classification{Category: CategoryPermission, Remediation: permissionRemediation},
+		},
+		"transient failure of the unknown category": {
+			classification: Classification{Category: CategoryUnknown},
+			reason:         "Operation cannot be fulfilled: the object has been modified",
+			expected:       Classification{Category: CategoryInfraTransient, Retryable: true},
+		},
+		"transient failure of the known category": {
+			classification: Classification{Category: CategoryUserConfig, Remediation: "verify the tunables"},
+			reason:         "too many requests",
+			expected:       Classification{Category: CategoryUserConfig, Remediation: "verify the tunables"},
+		},
+		"unrelated reason": {
+			classification: Classification{Category: CategoryUnknown},
+			reason:         "no pod found with matching labels",
+			expected:       Classification{Category: CategoryUnknown},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			classification := tc.classification
+			deriveFromReason(&classification, tc.reason)
+			assert.Equal(t, tc.expected, classification)
+		})
+	}
+}
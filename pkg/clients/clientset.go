@@ -16,8 +16,8 @@ import (
 
 // ClientSets is a collection of clientSets and kubeConfig needed
 type ClientSets struct {
-	KubeClient    *kubernetes.Clientset
-	LitmusClient  *chaosClient.LitmuschaosV1alpha1Client
+	KubeClient    kubernetes.Interface
+	LitmusClient  chaosClient.LitmuschaosV1alpha1Interface
 	KubeConfig    *rest.Config
 	DynamicClient dynamic.Interface
 }
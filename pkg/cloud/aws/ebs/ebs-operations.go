@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -10,11 +11,15 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ebs-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // EBSVolumeDetach will detach the ebs volume from ec2 instance
-func EBSVolumeDetach(ebsVolumeID, region string) error {
+func EBSVolumeDetach(ctx context.Context, ebsVolumeID, region string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "aws.ec2.DetachVolume", attribute.String("cloud.provider", "aws"), attribute.String("cloud.region", region), attribute.String("cloud.resource_id", ebsVolumeID))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	// Load session from shared config
 	sess := common.GetAWSSession(region)
@@ -25,7 +30,7 @@ func EBSVolumeDetach(ebsVolumeID, region string) error {
 		VolumeId: aws.String(ebsVolumeID),
 	}
 
-	result, err := ec2Svc.DetachVolume(input)
+	result, err := ec2Svc.DetachVolumeWithContext(ctx, input)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -45,7 +50,9 @@ func EBSVolumeDetach(ebsVolumeID, region string) error {
 }
 
 // EBSVolumeAttach will attach the ebs volume to the instance
-func EBSVolumeAttach(ebsVolumeID, ec2InstanceID, deviceName, region string) error {
+func EBSVolumeAttach(ctx context.Context, ebsVolumeID, ec2InstanceID, deviceName, region string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "aws.ec2.AttachVolume", attribute.String("cloud.provider", "aws"), attribute.String("cloud.region", region), attribute.String("cloud.resource_id", ebsVolumeID))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	// Load session from shared config
 	sess := common.GetAWSSession(region)
@@ -60,7 +67,7 @@ func EBSVolumeAttach(ebsVolumeID, ec2InstanceID, deviceName, region string) erro
 		VolumeId:   aws.String(ebsVolumeID),
 	}
 
-	result, err := ec2Svc.AttachVolume(input)
+	result, err := ec2Svc.AttachVolumeWithContext(ctx, input)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosRevert,
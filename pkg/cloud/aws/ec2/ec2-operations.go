@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -10,13 +11,17 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // EC2Stop will stop an aws ec2 instance
-func EC2Stop(instanceID, region string) error {
+func EC2Stop(ctx context.Context, instanceID, region string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "aws.ec2.StopInstances", attribute.String("cloud.provider", "aws"), attribute.String("cloud.region", region), attribute.String("cloud.resource_id", instanceID))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	// Load session from shared config
 	sess := common.GetAWSSession(region)
@@ -29,7 +34,7 @@ func EC2Stop(instanceID, region string) error {
 			aws.String(instanceID),
 		},
 	}
-	result, err := ec2Svc.StopInstances(input)
+	result, err := ec2Svc.StopInstancesWithContext(ctx, input)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -48,7 +53,9 @@ func EC2Stop(instanceID, region string) error {
 }
 
 // EC2Start will stop an aws ec2 instance
-func EC2Start(instanceID, region string) error {
+func EC2Start(ctx context.Context, instanceID, region string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "aws.ec2.StartInstances", attribute.String("cloud.provider", "aws"), attribute.String("cloud.region", region), attribute.String("cloud.resource_id", instanceID))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	sess := common.GetAWSSession(region)
 
@@ -61,7 +68,7 @@ func EC2Start(instanceID, region string) error {
 		},
 	}
 
-	result, err := ec2Svc.StartInstances(input)
+	result, err := ec2Svc.StartInstancesWithContext(ctx, input)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosRevert,
@@ -1,6 +1,7 @@
 package ssm
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -13,9 +14,11 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cloud/aws/common"
 	ec2 "github.com/litmuschaos/litmus-go/pkg/cloud/aws/ec2"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -24,12 +27,14 @@ const (
 )
 
 // SendSSMCommand will create and add the ssm document in aws service monitoring docs.
-func SendSSMCommand(experimentsDetails *experimentTypes.ExperimentDetails, ec2InstanceID []string) (string, error) {
+func SendSSMCommand(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, ec2InstanceID []string) (_ string, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "aws.ssm.SendCommand", attribute.String("cloud.provider", "aws"), attribute.String("cloud.region", experimentsDetails.Region), attribute.StringSlice("cloud.resource_ids", ec2InstanceID))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	sesh := common.GetAWSSession(experimentsDetails.Region)
 	ssmClient := ssm.New(sesh)
 	timeout := int64(experimentsDetails.ChaosDuration + 30)
-	res, err := ssmClient.SendCommand(&ssm.SendCommandInput{
+	res, err := ssmClient.SendCommandWithContext(ctx, &ssm.SendCommandInput{
 		DocumentName: aws.String(experimentsDetails.DocumentName),
 
 		Targets: []*ssm.Target{
@@ -173,10 +178,12 @@ func CheckInstanceInformation(experimentsDetails *experimentTypes.ExperimentDeta
 }
 
 // CancelCommand will cancel the ssm command
-func CancelCommand(commandIDs, region string) error {
+func CancelCommand(ctx context.Context, commandIDs, region string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "aws.ssm.CancelCommand", attribute.String("cloud.provider", "aws"), attribute.String("cloud.region", region), attribute.String("cloud.resource_id", commandIDs))
+	defer func() { telemetry.EndSpan(span, err) }()
 	sesh := common.GetAWSSession(region)
 	ssmClient := ssm.New(sesh)
-	_, err := ssmClient.CancelCommand(&ssm.CancelCommandInput{
+	_, err = ssmClient.CancelCommandWithContext(ctx, &ssm.CancelCommandInput{
 		CommandId: aws.String(commandIDs),
 	})
 	if err != nil {
@@ -12,12 +12,16 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/azure/common"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DetachDisks will detach the list of disk provided for the specific VM instance or scale set vm instance
-func DetachDisks(subscriptionID, resourceGroup, azureInstanceName, scaleSet string, diskNameList []string) error {
+func DetachDisks(ctx context.Context, subscriptionID, resourceGroup, azureInstanceName, scaleSet string, diskNameList []string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "azure.compute.DetachDisks", attribute.String("cloud.provider", "azure"), attribute.String("cloud.resource_group", resourceGroup), attribute.String("cloud.resource_id", azureInstanceName), attribute.StringSlice("disk.names", diskNameList))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
 	if err != nil {
@@ -36,7 +40,7 @@ func DetachDisks(subscriptionID, resourceGroup, azureInstanceName, scaleSet stri
 
 		// Fetch the vm instance
 		scaleSetName, vmId := common.GetScaleSetNameAndInstanceId(azureInstanceName)
-		vm, err := vmssClient.Get(context.TODO(), resourceGroup, scaleSetName, vmId, compute.InstanceViewTypes("instanceView"))
+		vm, err := vmssClient.Get(ctx, resourceGroup, scaleSetName, vmId, compute.InstanceViewTypes("instanceView"))
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -63,7 +67,7 @@ func DetachDisks(subscriptionID, resourceGroup, azureInstanceName, scaleSet stri
 		vm.VirtualMachineScaleSetVMProperties.StorageProfile.ImageReference = nil
 
 		// Update the VM with the keepAttachedList to detach the specified disks
-		_, err = vmssClient.Update(context.TODO(), resourceGroup, scaleSetName, vmId, vm)
+		_, err = vmssClient.Update(ctx, resourceGroup, scaleSetName, vmId, vm)
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -78,7 +82,7 @@ func DetachDisks(subscriptionID, resourceGroup, azureInstanceName, scaleSet stri
 		vmClient.Authorizer = authorizer
 
 		// Fetch the vm instance
-		vm, err := vmClient.Get(context.TODO(), resourceGroup, azureInstanceName, compute.InstanceViewTypes("instanceView"))
+		vm, err := vmClient.Get(ctx, resourceGroup, azureInstanceName, compute.InstanceViewTypes("instanceView"))
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -103,7 +107,7 @@ func DetachDisks(subscriptionID, resourceGroup, azureInstanceName, scaleSet stri
 		}
 
 		// Update the VM with the keepAttachedList to detach the specified disks
-		_, err = vmClient.CreateOrUpdate(context.TODO(), resourceGroup, azureInstanceName, vm)
+		_, err = vmClient.CreateOrUpdate(ctx, resourceGroup, azureInstanceName, vm)
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -116,7 +120,9 @@ func DetachDisks(subscriptionID, resourceGroup, azureInstanceName, scaleSet stri
 }
 
 // AttachDisk will attach the list of disk provided for the specific VM instance
-func AttachDisk(subscriptionID, resourceGroup, azureInstanceName, scaleSet string, diskList *[]compute.DataDisk) error {
+func AttachDisk(ctx context.Context, subscriptionID, resourceGroup, azureInstanceName, scaleSet string, diskList *[]compute.DataDisk) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "azure.compute.AttachDisks", attribute.String("cloud.provider", "azure"), attribute.String("cloud.resource_group", resourceGroup), attribute.String("cloud.resource_id", azureInstanceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
 	if err != nil {
@@ -135,7 +141,7 @@ func AttachDisk(subscriptionID, resourceGroup, azureInstanceName, scaleSet strin
 
 		// Fetch the vm instance
 		scaleSetName, vmId := common.GetScaleSetNameAndInstanceId(azureInstanceName)
-		vm, err := vmClient.Get(context.TODO(), resourceGroup, scaleSetName, vmId, compute.InstanceViewTypes("instanceView"))
+		vm, err := vmClient.Get(ctx, resourceGroup, scaleSetName, vmId, compute.InstanceViewTypes("instanceView"))
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosRevert,
@@ -149,7 +155,7 @@ func AttachDisk(subscriptionID, resourceGroup, azureInstanceName, scaleSet strin
 		vm.VirtualMachineScaleSetVMProperties.StorageProfile.ImageReference = nil
 
 		// Update the VM properties
-		_, err = vmClient.Update(context.TODO(), resourceGroup, scaleSetName, vmId, vm)
+		_, err = vmClient.Update(ctx, resourceGroup, scaleSetName, vmId, vm)
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosRevert,
@@ -162,7 +168,7 @@ func AttachDisk(subscriptionID, resourceGroup, azureInstanceName, scaleSet strin
 		vmClient.Authorizer = authorizer
 
 		// Fetch the vm instance
-		vm, err := vmClient.Get(context.TODO(), resourceGroup, azureInstanceName, compute.InstanceViewTypes("instanceView"))
+		vm, err := vmClient.Get(ctx, resourceGroup, azureInstanceName, compute.InstanceViewTypes("instanceView"))
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosRevert,
@@ -174,7 +180,7 @@ func AttachDisk(subscriptionID, resourceGroup, azureInstanceName, scaleSet strin
 		vm.VirtualMachineProperties.StorageProfile.DataDisks = diskList
 
 		// Update the VM properties
-		_, err = vmClient.CreateOrUpdate(context.TODO(), resourceGroup, azureInstanceName, vm)
+		_, err = vmClient.CreateOrUpdate(ctx, resourceGroup, azureInstanceName, vm)
 		if err != nil {
 			return cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeChaosRevert,
@@ -13,11 +13,15 @@ import (
 	"github.com/palantir/stacktrace"
 
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // AzureInstanceStop stops the target instance
-func AzureInstanceStop(timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) error {
+func AzureInstanceStop(ctx context.Context, timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "azure.compute.PowerOffInstance", attribute.String("cloud.provider", "azure"), attribute.String("cloud.resource_group", resourceGroup), attribute.String("cloud.resource_id", azureInstanceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 	vmClient := compute.NewVirtualMachinesClient(subscriptionID)
 
 	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
@@ -32,7 +36,7 @@ func AzureInstanceStop(timeout, delay int, subscriptionID, resourceGroup, azureI
 	vmClient.Authorizer = authorizer
 
 	log.Info("[Info]: Stopping the instance")
-	_, err = vmClient.PowerOff(context.TODO(), resourceGroup, azureInstanceName, &vmClient.SkipResourceProviderRegistration)
+	_, err = vmClient.PowerOff(ctx, resourceGroup, azureInstanceName, &vmClient.SkipResourceProviderRegistration)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -45,7 +49,9 @@ func AzureInstanceStop(timeout, delay int, subscriptionID, resourceGroup, azureI
 }
 
 // AzureInstanceStart starts the target instance
-func AzureInstanceStart(timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) error {
+func AzureInstanceStart(ctx context.Context, timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "azure.compute.StartInstance", attribute.String("cloud.provider", "azure"), attribute.String("cloud.resource_group", resourceGroup), attribute.String("cloud.resource_id", azureInstanceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	vmClient := compute.NewVirtualMachinesClient(subscriptionID)
 
@@ -61,7 +67,7 @@ func AzureInstanceStart(timeout, delay int, subscriptionID, resourceGroup, azure
 	vmClient.Authorizer = authorizer
 
 	log.Info("[Info]: Starting back the instance to running state")
-	_, err = vmClient.Start(context.TODO(), resourceGroup, azureInstanceName)
+	_, err = vmClient.Start(ctx, resourceGroup, azureInstanceName)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosRevert,
@@ -74,7 +80,9 @@ func AzureInstanceStart(timeout, delay int, subscriptionID, resourceGroup, azure
 }
 
 // AzureScaleSetInstanceStop stops the target instance in the scale set
-func AzureScaleSetInstanceStop(timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) error {
+func AzureScaleSetInstanceStop(ctx context.Context, timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "azure.compute.PowerOffScaleSetInstance", attribute.String("cloud.provider", "azure"), attribute.String("cloud.resource_group", resourceGroup), attribute.String("cloud.resource_id", azureInstanceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 	vmssClient := compute.NewVirtualMachineScaleSetVMsClient(subscriptionID)
 
 	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
@@ -91,7 +99,7 @@ func AzureScaleSetInstanceStop(timeout, delay int, subscriptionID, resourceGroup
 	virtualMachineScaleSetName, virtualMachineId := common.GetScaleSetNameAndInstanceId(azureInstanceName)
 
 	log.Info("[Info]: Stopping the instance")
-	_, err = vmssClient.PowerOff(context.TODO(), resourceGroup, virtualMachineScaleSetName, virtualMachineId, &vmssClient.SkipResourceProviderRegistration)
+	_, err = vmssClient.PowerOff(ctx, resourceGroup, virtualMachineScaleSetName, virtualMachineId, &vmssClient.SkipResourceProviderRegistration)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
@@ -104,7 +112,9 @@ func AzureScaleSetInstanceStop(timeout, delay int, subscriptionID, resourceGroup
 }
 
 // AzureScaleSetInstanceStart starts the target instance in the scale set
-func AzureScaleSetInstanceStart(timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) error {
+func AzureScaleSetInstanceStart(ctx context.Context, timeout, delay int, subscriptionID, resourceGroup, azureInstanceName string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "azure.compute.StartScaleSetInstance", attribute.String("cloud.provider", "azure"), attribute.String("cloud.resource_group", resourceGroup), attribute.String("cloud.resource_id", azureInstanceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 	vmssClient := compute.NewVirtualMachineScaleSetVMsClient(subscriptionID)
 
 	authorizer, err := auth.NewAuthorizerFromFile(azure.PublicCloud.ResourceManagerEndpoint)
@@ -120,7 +130,7 @@ func AzureScaleSetInstanceStart(timeout, delay int, subscriptionID, resourceGrou
 	virtualMachineScaleSetName, virtualMachineId := common.GetScaleSetNameAndInstanceId(azureInstanceName)
 
 	log.Info("[Info]: Starting back the instance to running state")
-	_, err = vmssClient.Start(context.TODO(), resourceGroup, virtualMachineScaleSetName, virtualMachineId)
+	_, err = vmssClient.Start(ctx, resourceGroup, virtualMachineScaleSetName, virtualMachineId)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosRevert,
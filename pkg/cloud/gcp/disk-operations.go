@@ -1,20 +1,25 @@
 package gcp
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-disk-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/api/compute/v1"
 )
 
 // DiskVolumeDetach will detach a disk volume from a VM instance
-func DiskVolumeDetach(computeService *compute.Service, instanceName string, gcpProjectID string, zone string, deviceName string) error {
+func DiskVolumeDetach(ctx context.Context, computeService *compute.Service, instanceName string, gcpProjectID string, zone string, deviceName string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "gcp.compute.DetachDisk", attribute.String("cloud.provider", "gcp"), attribute.String("cloud.availability_zone", zone), attribute.String("cloud.resource_id", instanceName), attribute.String("disk.device_name", deviceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 
-	response, err := computeService.Instances.DetachDisk(gcpProjectID, zone, instanceName, deviceName).Do()
+	response, err := computeService.Instances.DetachDisk(gcpProjectID, zone, instanceName, deviceName).Context(ctx).Do()
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{deviceName: %s, zone: %s}", deviceName, zone), Reason: err.Error()}
 	}
@@ -29,9 +34,11 @@ func DiskVolumeDetach(computeService *compute.Service, instanceName string, gcpP
 }
 
 // DiskVolumeAttach will attach a disk volume to a VM instance
-func DiskVolumeAttach(computeService *compute.Service, instanceName string, gcpProjectID string, zone string, deviceName string, diskName string) error {
+func DiskVolumeAttach(ctx context.Context, computeService *compute.Service, instanceName string, gcpProjectID string, zone string, deviceName string, diskName string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "gcp.compute.AttachDisk", attribute.String("cloud.provider", "gcp"), attribute.String("cloud.availability_zone", zone), attribute.String("cloud.resource_id", instanceName), attribute.String("disk.name", diskName))
+	defer func() { telemetry.EndSpan(span, err) }()
 
-	diskDetails, err := computeService.Disks.Get(gcpProjectID, zone, diskName).Do()
+	diskDetails, err := computeService.Disks.Get(gcpProjectID, zone, diskName).Context(ctx).Do()
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{diskName: %s, zone: %s}", diskName, zone), Reason: err.Error()}
 	}
@@ -41,7 +48,7 @@ func DiskVolumeAttach(computeService *compute.Service, instanceName string, gcpP
 		Source:     diskDetails.SelfLink,
 	}
 
-	response, err := computeService.Instances.AttachDisk(gcpProjectID, zone, instanceName, requestBody).Do()
+	response, err := computeService.Instances.AttachDisk(gcpProjectID, zone, instanceName, requestBody).Context(ctx).Do()
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{diskName: %s, zone: %s}", diskName, zone), Reason: err.Error()}
 	}
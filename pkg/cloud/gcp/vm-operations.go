@@ -1,6 +1,7 @@
 package gcp
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -8,17 +9,21 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-instance-stop/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/api/compute/v1"
 )
 
 // VMInstanceStop stops a VM Instance
-func VMInstanceStop(computeService *compute.Service, instanceName string, gcpProjectID string, instanceZone string) error {
+func VMInstanceStop(ctx context.Context, computeService *compute.Service, instanceName string, gcpProjectID string, instanceZone string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "gcp.compute.StopInstance", attribute.String("cloud.provider", "gcp"), attribute.String("cloud.availability_zone", instanceZone), attribute.String("cloud.resource_id", instanceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	// stop the requisite VM instance
-	_, err := computeService.Instances.Stop(gcpProjectID, instanceZone, instanceName).Do()
+	_, err = computeService.Instances.Stop(gcpProjectID, instanceZone, instanceName).Context(ctx).Do()
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: fmt.Sprintf("{vmName: %s, zone: %s}", instanceName, instanceZone), Reason: err.Error()}
 	}
@@ -31,10 +36,12 @@ func VMInstanceStop(computeService *compute.Service, instanceName string, gcpPro
 }
 
 // VMInstanceStart starts a VM instance
-func VMInstanceStart(computeService *compute.Service, instanceName string, gcpProjectID string, instanceZone string) error {
+func VMInstanceStart(ctx context.Context, computeService *compute.Service, instanceName string, gcpProjectID string, instanceZone string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "gcp.compute.StartInstance", attribute.String("cloud.provider", "gcp"), attribute.String("cloud.availability_zone", instanceZone), attribute.String("cloud.resource_id", instanceName))
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	// start the requisite VM instance
-	_, err := computeService.Instances.Start(gcpProjectID, instanceZone, instanceName).Do()
+	_, err = computeService.Instances.Start(gcpProjectID, instanceZone, instanceName).Context(ctx).Do()
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosRevert, Target: fmt.Sprintf("{vmName: %s, zone: %s}", instanceName, instanceZone), Reason: err.Error()}
 	}
@@ -1,6 +1,7 @@
 package vmware
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -10,14 +11,18 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // StartVM starts a given powered-off VM
-func StartVM(vcenterServer, vmId, cookie string) error {
+func StartVM(ctx context.Context, vcenterServer, vmId, cookie string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "vcenter.vm.PowerStart", attribute.String("cloud.provider", "vcenter"), attribute.String("server.address", vcenterServer), attribute.String("cloud.resource_id", vmId))
+	defer func() { telemetry.EndSpan(span, err) }()
 
-	req, err := http.NewRequest("POST", "https://"+vcenterServer+"/rest/vcenter/vm/"+vmId+"/power/start", nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://"+vcenterServer+"/rest/vcenter/vm/"+vmId+"/power/start", nil)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosRevert,
@@ -76,9 +81,11 @@ func StartVM(vcenterServer, vmId, cookie string) error {
 }
 
 // StopVM stops a given powered-on VM
-func StopVM(vcenterServer, vmId, cookie string) error {
+func StopVM(ctx context.Context, vcenterServer, vmId, cookie string) (err error) {
+	ctx, span := telemetry.StartSpan(ctx, "vcenter.vm.PowerStop", attribute.String("cloud.provider", "vcenter"), attribute.String("server.address", vcenterServer), attribute.String("cloud.resource_id", vmId))
+	defer func() { telemetry.EndSpan(span, err) }()
 
-	req, err := http.NewRequest("POST", "https://"+vcenterServer+"/rest/vcenter/vm/"+vmId+"/power/stop", nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://"+vcenterServer+"/rest/vcenter/vm/"+vmId+"/power/stop", nil)
 	if err != nil {
 		return cerrors.Error{
 			ErrorCode: cerrors.ErrorTypeChaosInject,
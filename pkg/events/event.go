@@ -5,6 +5,7 @@ import (
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/notify"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -44,6 +45,9 @@ func CreateEvents(eventsDetails *types.EventDetails, clients clients.ClientSets,
 // else it will create a new event
 func GenerateEvents(eventsDetails *types.EventDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, kind string) error {
 
+	// notify the external subscribers about the lifecycle event
+	notify.Send(eventsDetails, chaosDetails, kind)
+
 	switch kind {
 	case "ChaosResult":
 		eventName := eventsDetails.Reason + chaosDetails.ChaosPodName
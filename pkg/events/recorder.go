@@ -25,7 +25,7 @@ type Recorder struct {
 	EventResource runtime.Object
 }
 
-func generateEventRecorder(kubeClient *kubernetes.Clientset, componentName string) (record.EventRecorder, error) {
+func generateEventRecorder(kubeClient kubernetes.Interface, componentName string) (record.EventRecorder, error) {
 	err := litmuschaosScheme.AddToScheme(scheme.Scheme)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,344 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/workloads"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// UnderChaosAnnotation is added to the targets, owners and nodes during the chaos, it contains the chaosengine name
+	UnderChaosAnnotation = "litmuschaos.io/under-chaos"
+	// ChaosUIDAnnotation contains the uid of the chaos, which added the under-chaos annotation
+	ChaosUIDAnnotation = "litmuschaos.io/chaos-uid"
+	// AnnotateTargetsEnv enables the under-chaos annotations, the events are generated irrespective of it
+	AnnotateTargetsEnv = "ANNOTATE_TARGETS"
+
+	// ChaosInjectedReason is the reason of the event generated on the targets at the time of injection
+	ChaosInjectedReason = "ChaosInjected"
+	// ChaosRevertedReason is the reason of the event generated on the targets at the time of revert
+	ChaosRevertedReason = "ChaosReverted"
+)
+
+// TargetReference identifies a kubernetes object affected by the chaos
+type TargetReference struct {
+	Resource   schema.GroupVersionResource
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	UID        k8stypes.UID
+}
+
+// String returns the kind/namespace/name of the reference
+func (ref TargetReference) String() string {
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", ref.Kind, ref.Namespace, ref.Name)
+}
+
+// ownerResources contains the resources of the common owner kinds, which are scanned for the leftover under-chaos annotations
+var ownerResources = map[string]schema.GroupVersionResource{
+	"ReplicaSet":            {Group: "apps", Version: "v1", Resource: "replicasets"},
+	"Deployment":            {Group: "apps", Version: "v1", Resource: "deployments"},
+	"StatefulSet":           {Group: "apps", Version: "v1", Resource: "statefulsets"},
+	"DaemonSet":             {Group: "apps", Version: "v1", Resource: "daemonsets"},
+	"Job":                   {Group: "batch", Version: "v1", Resource: "jobs"},
+	"CronJob":               {Group: "batch", Version: "v1", Resource: "cronjobs"},
+	"ReplicationController": {Group: "", Version: "v1", Resource: "replicationcontrollers"},
+}
+
+var (
+	podResource  = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	nodeResource = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+)
+
+var (
+	marksLock sync.Mutex
+	// marks counts the targets marking every reference, the shared references (i.e, the owners and nodes)
+	// are marked by the first target and unmarked by the last one
+	marks = map[string]int{}
+	// podReferences contains the references marked for the pods, so that they can be unmarked even if the pods are deleted
+	podReferences = map[string][]TargetReference{}
+	// skipNodes is set once the nodes can't be marked, as the cluster scoped permissions aren't granted to the experiment
+	skipNodes atomic.Bool
+)
+
+// UnderChaosResources returns the resources, which can carry the under-chaos annotation
+func UnderChaosResources() map[string]schema.GroupVersionResource {
+	resources := map[string]schema.GroupVersionResource{"Pod": podResource, "Node": nodeResource}
+	for kind, gvr := range ownerResources {
+		resources[kind] = gvr
+	}
+	return resources
+}
+
+// MarkPodUnderChaos generates the inject events and adds the under-chaos annotations on the pod, its owners and its node
+// It returns the affected references, which should be passed to UnmarkUnderChaos at the time of revert
+func MarkPodUnderChaos(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, podName, namespace string) []TargetReference {
+	refs, err := GetPodReferences(ctx, clients, podName, namespace)
+	if err != nil {
+		log.WarnfWithContext(ctx, "[Events]: Unable to get the references of %v pod, err: %v", podName, err)
+		return nil
+	}
+	MarkUnderChaos(ctx, clients, chaosDetails, refs)
+
+	marksLock.Lock()
+	podReferences[namespace+"/"+podName] = refs
+	marksLock.Unlock()
+	return refs
+}
+
+// UnmarkPodUnderChaos generates the revert events and removes the under-chaos annotations from the pod, its owners and its node
+// it unmarks the references marked by MarkPodUnderChaos
+func UnmarkPodUnderChaos(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, podName, namespace string) {
+	marksLock.Lock()
+	refs := podReferences[namespace+"/"+podName]
+	delete(podReferences, namespace+"/"+podName)
+	marksLock.Unlock()
+	UnmarkUnderChaos(ctx, clients, chaosDetails, refs)
+}
+
+// MarkNodeUnderChaos generates the inject event and adds the under-chaos annotation on the node
+func MarkNodeUnderChaos(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, nodeName string) []TargetReference {
+	refs := []TargetReference{GetNodeReference(ctx, clients, nodeName)}
+	MarkUnderChaos(ctx, clients, chaosDetails, refs)
+	return refs
+}
+
+// UnmarkNodeUnderChaos generates the revert event and removes the under-chaos annotation from the node
+func UnmarkNodeUnderChaos(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, nodeName string) {
+	UnmarkUnderChaos(ctx, clients, chaosDetails, []TargetReference{GetNodeReference(ctx, clients, nodeName)})
+}
+
+// MarkUnderChaos generates the inject events and adds the under-chaos annotations on the given references
+// The failures are only logged, as these are informational and should not affect the experiment
+// The references shared by the targets are marked only once, till all of them are unmarked
+func MarkUnderChaos(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, refs []TargetReference) {
+	for _, ref := range uniqueReferences(refs) {
+		if isSkipped(ref) || !mark(ref) {
+			continue
+		}
+		msg := fmt.Sprintf("%s chaos injected by %s", chaosDetails.ExperimentName, getChaosOwner(chaosDetails))
+		if err := createTargetEvent(ctx, clients, chaosDetails, ref, ChaosInjectedReason, msg, apiv1.EventTypeWarning); err != nil {
+			if checkForbidden(ctx, ref, err) {
+				continue
+			}
+			log.WarnfWithContext(ctx, "[Events]: Unable to create the %v event on %v, err: %v", ChaosInjectedReason, ref, err)
+		}
+		if !annotateTargets() {
+			continue
+		}
+		annotations := map[string]interface{}{
+			UnderChaosAnnotation: getChaosOwner(chaosDetails),
+			ChaosUIDAnnotation:   string(chaosDetails.ChaosUID),
+		}
+		if err := patchAnnotations(ctx, clients, ref, annotations); err != nil && !checkForbidden(ctx, ref, err) {
+			log.WarnfWithContext(ctx, "[Events]: Unable to add the %v annotation on %v, err: %v", UnderChaosAnnotation, ref, err)
+		}
+	}
+}
+
+// UnmarkUnderChaos generates the revert events and removes the under-chaos annotations from the given references
+// The references shared by the targets are unmarked only by the last of them.
+// It isn't cancelled along with the context, as it is a part of the revert
+func UnmarkUnderChaos(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, refs []TargetReference) {
+	ctx = context.WithoutCancel(ctx)
+	for _, ref := range uniqueReferences(refs) {
+		if isSkipped(ref) || !unmark(ref) {
+			continue
+		}
+		msg := fmt.Sprintf("%s chaos reverted by %s", chaosDetails.ExperimentName, getChaosOwner(chaosDetails))
+		if err := createTargetEvent(ctx, clients, chaosDetails, ref, ChaosRevertedReason, msg, apiv1.EventTypeNormal); err != nil {
+			if checkForbidden(ctx, ref, err) {
+				continue
+			}
+			log.WarnfWithContext(ctx, "[Events]: Unable to create the %v event on %v, err: %v", ChaosRevertedReason, ref, err)
+		}
+		if !annotateTargets() {
+			continue
+		}
+		if err := RemoveUnderChaosAnnotations(ctx, clients, ref); err != nil && !checkForbidden(ctx, ref, err) {
+			log.WarnfWithContext(ctx, "[Events]: Unable to remove the %v annotation from %v, err: %v", UnderChaosAnnotation, ref, err)
+		}
+	}
+}
+
+// mark counts the mark of the reference, it returns true if the reference isn't already marked by another target
+func mark(ref TargetReference) bool {
+	marksLock.Lock()
+	defer marksLock.Unlock()
+	marks[ref.String()]++
+	return marks[ref.String()] == 1
+}
+
+// unmark discounts the mark of the reference, it returns true if the reference isn't marked by any other target
+// it returns false if the reference isn't marked, so that the repeated reverts (e.g, the retries of the abort) are ignored
+func unmark(ref TargetReference) bool {
+	marksLock.Lock()
+	defer marksLock.Unlock()
+	switch marks[ref.String()] {
+	case 0:
+		return false
+	case 1:
+		delete(marks, ref.String())
+		return true
+	default:
+		marks[ref.String()]--
+		return false
+	}
+}
+
+// isSkipped checks if the reference is a node, which can't be marked without the cluster scoped permissions
+func isSkipped(ref TargetReference) bool {
+	return ref.Kind == "Node" && skipNodes.Load()
+}
+
+// checkForbidden skips the subsequent marks of the nodes, if the node can't be marked due to the missing permissions
+// the events of the nodes are created inside the default namespace, and the annotations need the patch access on the nodes
+// it returns true if the error is handled
+func checkForbidden(ctx context.Context, ref TargetReference, err error) bool {
+	if ref.Kind != "Node" || !k8serrors.IsForbidden(err) {
+		return false
+	}
+	if !skipNodes.Swap(true) {
+		log.WarnfWithContext(ctx, "[Events]: Skipping the events and annotations on the nodes, as the cluster scoped permissions aren't granted, err: %v", err)
+	}
+	return true
+}
+
+// RemoveUnderChaosAnnotations removes the under-chaos annotations from the given reference
+// it is used by the revert path as well as the cleanup of the interrupted chaos
+func RemoveUnderChaosAnnotations(ctx context.Context, clients clients.ClientSets, ref TargetReference) error {
+	annotations := map[string]interface{}{
+		UnderChaosAnnotation: nil,
+		ChaosUIDAnnotation:   nil,
+	}
+	return patchAnnotations(ctx, clients, ref, annotations)
+}
+
+// GetPodReferences returns the references of the pod, its owners (following the controller references) and its node
+func GetPodReferences(ctx context.Context, clients clients.ClientSets, podName, namespace string) ([]TargetReference, error) {
+	pod, err := clients.KubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []TargetReference{{Resource: podResource, APIVersion: "v1", Kind: "Pod", Name: pod.Name, Namespace: pod.Namespace, UID: pod.UID}}
+	owners, err := workloads.GetOwnerResolver(clients).GetOwnerChain(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	for _, owner := range owners {
+		refs = append(refs, TargetReference{Resource: owner.Resource, APIVersion: owner.APIVersion, Kind: owner.Kind, Name: owner.Name, Namespace: owner.Namespace, UID: owner.UID})
+	}
+
+	if pod.Spec.NodeName != "" {
+		refs = append(refs, GetNodeReference(ctx, clients, pod.Spec.NodeName))
+	}
+	return refs, nil
+}
+
+// GetNodeReference returns the reference of the node
+func GetNodeReference(ctx context.Context, clients clients.ClientSets, nodeName string) TargetReference {
+	ref := TargetReference{Resource: nodeResource, APIVersion: "v1", Kind: "Node", Name: nodeName}
+	// the uid is only used to link the event with the node, the event is created even if it is not available
+	if node, err := clients.KubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err == nil {
+		ref.UID = node.UID
+	}
+	return ref
+}
+
+// createTargetEvent creates the event on the referenced object
+// the events of the cluster scoped objects are created inside the default namespace
+func createTargetEvent(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, ref TargetReference, reason, msg, eventType string) error {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	now := metav1.Time{Time: time.Now()}
+	event := &apiv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%v.%x", ref.Name, now.UnixNano()),
+			Namespace: namespace,
+			Labels:    map[string]string{"chaosUID": string(chaosDetails.ChaosUID)},
+		},
+		Source: apiv1.EventSource{
+			Component: chaosDetails.ChaosPodName,
+		},
+		Message:        msg,
+		Reason:         reason,
+		Type:           eventType,
+		Count:          1,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		InvolvedObject: apiv1.ObjectReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			Namespace:  ref.Namespace,
+			UID:        ref.UID,
+		},
+	}
+	_, err := clients.KubeClient.CoreV1().Events(namespace).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
+
+// patchAnnotations merge patches the annotations of the referenced object, the nil values remove the annotations
+func patchAnnotations(ctx context.Context, clients clients.ClientSets, ref TargetReference, annotations map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = clients.DynamicClient.Resource(ref.Resource).Namespace(ref.Namespace).Patch(ctx, ref.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// uniqueReferences removes the duplicate references, as the targets may share the owners and nodes
+func uniqueReferences(refs []TargetReference) []TargetReference {
+	seen := map[string]bool{}
+	var unique []TargetReference
+	for _, ref := range refs {
+		if seen[ref.String()] {
+			continue
+		}
+		seen[ref.String()] = true
+		unique = append(unique, ref)
+	}
+	return unique
+}
+
+// getChaosOwner returns the name of the chaosengine, it falls back to the experiment name if the engine is not present
+func getChaosOwner(chaosDetails *types.ChaosDetails) string {
+	if chaosDetails.EngineName != "" {
+		return chaosDetails.EngineName
+	}
+	return chaosDetails.ExperimentName
+}
+
+// annotateTargets checks if the under-chaos annotations are enabled
+func annotateTargets() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(AnnotateTargetsEnv))
+	return enabled
+}
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// resetMarks removes the marks of the test, once it is completed
+func resetMarks(t *testing.T) {
+	t.Cleanup(func() {
+		marksLock.Lock()
+		defer marksLock.Unlock()
+		marks = map[string]int{}
+		podReferences = map[string][]TargetReference{}
+		skipNodes.Store(false)
+	})
+}
+
+func testReferences(podName string) []TargetReference {
+	return []TargetReference{
+		{Resource: podResource, APIVersion: "v1", Kind: "Pod", Name: podName, Namespace: "default"},
+		{Resource: ownerResources["Deployment"], APIVersion: "apps/v1", Kind: "Deployment", Name: "nginx", Namespace: "default"},
+		{Resource: nodeResource, APIVersion: "v1", Kind: "Node", Name: "node-1"},
+	}
+}
+
+// countEvents returns the number of events with the given reason, generated on the named object
+func countEvents(t *testing.T, kubeClient *fake.Clientset, name, reason string) int {
+	events, err := kubeClient.CoreV1().Events("").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	count := 0
+	for _, event := range events.Items {
+		if event.InvolvedObject.Name == name && event.Reason == reason {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSharedReferencesAreUnmarkedByTheLastTarget(t *testing.T) {
+	resetMarks(t)
+	kubeClient := fake.NewSimpleClientset()
+	clientSets := clients.ClientSets{KubeClient: kubeClient}
+	chaosDetails := &types.ChaosDetails{ExperimentName: "pod-cpu-hog", EngineName: "nginx-chaos"}
+
+	MarkUnderChaos(context.Background(), clientSets, chaosDetails, testReferences("pod-1"))
+	MarkUnderChaos(context.Background(), clientSets, chaosDetails, testReferences("pod-2"))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "nginx", ChaosInjectedReason))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "node-1", ChaosInjectedReason))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "pod-2", ChaosInjectedReason))
+
+	UnmarkUnderChaos(context.Background(), clientSets, chaosDetails, testReferences("pod-1"))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "pod-1", ChaosRevertedReason))
+	assert.Equal(t, 0, countEvents(t, kubeClient, "nginx", ChaosRevertedReason))
+	assert.Equal(t, 0, countEvents(t, kubeClient, "node-1", ChaosRevertedReason))
+
+	UnmarkUnderChaos(context.Background(), clientSets, chaosDetails, testReferences("pod-2"))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "nginx", ChaosRevertedReason))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "node-1", ChaosRevertedReason))
+
+	// the repeated reverts are ignored
+	UnmarkUnderChaos(context.Background(), clientSets, chaosDetails, testReferences("pod-2"))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "pod-2", ChaosRevertedReason))
+	assert.Equal(t, 1, countEvents(t, kubeClient, "nginx", ChaosRevertedReason))
+}
+
+func TestNodesAreSkippedIfForbidden(t *testing.T) {
+	resetMarks(t)
+	kubeClient := fake.NewSimpleClientset()
+	attempts := 0
+	kubeClient.PrependReactor("create", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() != metav1.NamespaceDefault {
+			return false, nil, nil
+		}
+		event := action.(k8stesting.CreateAction).GetObject().(*apiv1.Event)
+		if event.InvolvedObject.Kind != "Node" {
+			return false, nil, nil
+		}
+		attempts++
+		return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: "events"}, event.Name, nil)
+	})
+	clientSets := clients.ClientSets{KubeClient: kubeClient}
+	chaosDetails := &types.ChaosDetails{ExperimentName: "pod-cpu-hog", EngineName: "nginx-chaos"}
+
+	MarkUnderChaos(context.Background(), clientSets, chaosDetails, []TargetReference{
+		{Resource: podResource, APIVersion: "v1", Kind: "Pod", Name: "pod-1", Namespace: "apps"},
+		{Resource: nodeResource, APIVersion: "v1", Kind: "Node", Name: "node-1"},
+	})
+	MarkUnderChaos(context.Background(), clientSets, chaosDetails, []TargetReference{
+		{Resource: nodeResource, APIVersion: "v1", Kind: "Node", Name: "node-2"},
+	})
+	UnmarkUnderChaos(context.Background(), clientSets, chaosDetails, []TargetReference{
+		{Resource: nodeResource, APIVersion: "v1", Kind: "Node", Name: "node-1"},
+	})
+
+	assert.Equal(t, 1, attempts)
+	assert.True(t, skipNodes.Load())
+	assert.Equal(t, 1, countEvents(t, kubeClient, "pod-1", ChaosInjectedReason))
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// ExperimentDetails is for collecting all the experiment-related details
+type ExperimentDetails struct {
+	ExperimentName                string
+	EngineName                    string
+	ChaosUID                      clientTypes.UID
+	InstanceID                    string
+	ChaosNamespace                string
+	ChaosPodName                  string
+	Timeout                       int
+	Delay                         int
+	LIBImage                      string
+	LIBImagePullPolicy            string
+	ChaosServiceAccount           string
+	TerminationGracePeriodSeconds int
+	SetHelperData                 string
+	RunID                         string
+	AppNS                         string
+	TargetNodes                   string
+	NodeName                      string
+	NetworkInterface              string
+	ContainerRuntime              string
+	SocketPath                    string
+	ProxyPort                     int
+	Taints                        string
+	ChaosMonkeyPort               string
+	ChaosMonkeyPath               string
+	DryRun                        bool
+}
+
+// Report contains the details of the artifacts found and reverted by the cleanup
+type Report struct {
+	Kind   string
+	Name   string
+	Action string
+	Status string
+}
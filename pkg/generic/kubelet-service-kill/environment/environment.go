@@ -1,16 +1,18 @@
 package environment
 
 import (
+	"fmt"
 	"strconv"
 
 	clientTypes "k8s.io/apimachinery/pkg/types"
 
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/kubelet-service-kill/types"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 )
 
-// GetENV fetches all the env variables from the runner pod
-func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
+// GetENV fetches all the env variables from the runner pod, it returns the error if the targets are malformed
+func GetENV(experimentDetails *experimentTypes.ExperimentDetails) error {
 	experimentDetails.ExperimentName = types.Getenv("EXPERIMENT_NAME", "kubelet-service-kill")
 	experimentDetails.ChaosNamespace = types.Getenv("CHAOS_NAMESPACE", "litmus")
 	experimentDetails.EngineName = types.Getenv("CHAOSENGINE", "")
@@ -30,14 +32,20 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.TerminationGracePeriodSeconds, _ = strconv.Atoi(types.Getenv("TERMINATION_GRACE_PERIOD_SECONDS", ""))
 	experimentDetails.SetHelperData = types.Getenv("SET_HELPER_DATA", "true")
 
-	experimentDetails.AppNS, experimentDetails.AppKind, experimentDetails.AppLabel = getAppDetails()
+	var err error
+	experimentDetails.AppNS, experimentDetails.AppKind, experimentDetails.AppLabel, err = getAppDetails()
+	return err
 }
 
-func getAppDetails() (string, string, string) {
+// getAppDetails returns the namespace, kind and label of the first target, it returns the error if the targets are malformed
+func getAppDetails() (string, string, string, error) {
 	targets := types.Getenv("TARGETS", "")
-	app := types.GetTargets(targets)
-	if len(app) != 0 {
-		return app[0].Namespace, app[0].Kind, app[0].Labels[0]
+	app, err := types.ParseTargets(targets)
+	if err != nil {
+		return "", "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("unable to parse the TARGETS env: %v", err)}
 	}
-	return "", "", ""
+	if len(app) != 0 && len(app[0].Labels) != 0 {
+		return app[0].Namespace, app[0].Kind, app[0].Labels[0], nil
+	}
+	return "", "", "", nil
 }
@@ -33,19 +33,30 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails, expName string
 	experimentDetails.ChaosServiceAccount = types.Getenv("CHAOS_SERVICE_ACCOUNT", "")
 	experimentDetails.SocketPath = types.Getenv("SOCKET_PATH", "/run/containerd/containerd.sock")
 	experimentDetails.Sequence = types.Getenv("SEQUENCE", "parallel")
+	experimentDetails.IntensitySteps = types.Getenv("INTENSITY_STEPS", "")
+	experimentDetails.StepDuration = types.Getenv("STEP_DURATION", "")
 	experimentDetails.TerminationGracePeriodSeconds, _ = strconv.Atoi(types.Getenv("TERMINATION_GRACE_PERIOD_SECONDS", ""))
 	experimentDetails.SetHelperData = types.Getenv("SET_HELPER_DATA", "true")
 	experimentDetails.SourcePorts = types.Getenv("SOURCE_PORTS", "")
 	experimentDetails.DestinationPorts = types.Getenv("DESTINATION_PORTS", "")
+	experimentDetails.Correlation = types.Getenv("CORRELATION", "")
 
 	switch expName {
 	case "pod-network-loss":
 		experimentDetails.NetworkPacketLossPercentage = types.Getenv("NETWORK_PACKET_LOSS_PERCENTAGE", "100")
+		experimentDetails.LossModel = types.Getenv("LOSS_MODEL", "random")
+		experimentDetails.GEModel.R = types.Getenv("GEMODEL_R", "")
+		experimentDetails.GEModel.OneMinusH = types.Getenv("GEMODEL_1_H", "")
+		experimentDetails.GEModel.OneMinusK = types.Getenv("GEMODEL_1_K", "")
 		experimentDetails.NetworkChaosType = "network-loss"
 
 	case "pod-network-latency":
 		experimentDetails.NetworkLatency, _ = strconv.Atoi(types.Getenv("NETWORK_LATENCY", "2000"))
 		experimentDetails.Jitter, _ = strconv.Atoi(types.Getenv("JITTER", "0"))
+		experimentDetails.Distribution = types.Getenv("DISTRIBUTION", "")
+		experimentDetails.ReorderPercentage = types.Getenv("REORDER_PERCENTAGE", "")
+		experimentDetails.ReorderCorrelation = types.Getenv("REORDER_CORRELATION", "")
+		experimentDetails.ReorderGap, _ = strconv.Atoi(types.Getenv("REORDER_GAP", "0"))
 		experimentDetails.NetworkChaosType = "network-latency"
 
 	case "pod-network-corruption":
@@ -55,5 +66,13 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails, expName string
 	case "pod-network-duplication":
 		experimentDetails.NetworkPacketDuplicationPercentage = types.Getenv("NETWORK_PACKET_DUPLICATION_PERCENTAGE", "100")
 		experimentDetails.NetworkChaosType = "network-duplication"
+
+	case "pod-network-rate-limit":
+		experimentDetails.NetworkBandwidth = types.Getenv("NETWORK_BANDWIDTH", "1mbit")
+		experimentDetails.Burst = types.Getenv("BURST", "32kb")
+		experimentDetails.Limit = types.Getenv("LIMIT", "")
+		experimentDetails.QueueLatency = types.Getenv("QUEUE_LATENCY", "50ms")
+		experimentDetails.Qdisc = "tbf"
+		experimentDetails.NetworkChaosType = "network-rate-limit"
 	}
 }
@@ -32,6 +32,8 @@ type ExperimentDetails struct {
 	PodsAffectedPerc                   string
 	DestinationIPs                     string
 	DestinationHosts                   string
+	TargetIPs                          string
+	TargetIPsServiceMesh               string
 	ContainerRuntime                   string
 	ChaosServiceAccount                string
 	SocketPath                         string
@@ -44,4 +46,29 @@ type ExperimentDetails struct {
 	SetHelperData                      string
 	SourcePorts                        string
 	DestinationPorts                   string
+	Qdisc                              string
+	NetworkBandwidth                   string
+	Burst                              string
+	Limit                              string
+	QueueLatency                       string
+	Correlation                        string
+	Distribution                       string
+	ReorderPercentage                  string
+	ReorderCorrelation                 string
+	ReorderGap                         int
+	LossModel                          string
+	GEModel                            GEModel
+	IntensitySteps                     string
+	StepDuration                       string
+}
+
+// GEModel contains the transition probabilities of the Gilbert-Elliott loss model, in percentage
+// the probability of moving to the bad state is the NETWORK_PACKET_LOSS_PERCENTAGE
+type GEModel struct {
+	// R is the probability of moving back to the good state
+	R string
+	// OneMinusH is the probability of the loss in the bad state
+	OneMinusH string
+	// OneMinusK is the probability of the loss in the good state
+	OneMinusK string
 }
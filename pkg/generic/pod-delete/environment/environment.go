@@ -27,6 +27,8 @@ func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.Timeout, _ = strconv.Atoi(types.Getenv("STATUS_CHECK_TIMEOUT", "180"))
 	experimentDetails.TargetPods = types.Getenv("TARGET_PODS", "")
 	experimentDetails.Sequence = types.Getenv("SEQUENCE", "parallel")
+	experimentDetails.IntensitySteps = types.Getenv("INTENSITY_STEPS", "")
+	experimentDetails.StepDuration = types.Getenv("STEP_DURATION", "")
 	experimentDetails.TargetContainer = types.Getenv("TARGET_CONTAINER", "")
 	experimentDetails.NodeLabel = types.Getenv("NODE_LABEL", "")
 }
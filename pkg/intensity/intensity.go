@@ -0,0 +1,184 @@
+package intensity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/palantir/stacktrace"
+)
+
+const (
+	// StepsEnv contains the parameter and its comma separated values, in the order of the steps
+	// for example NETWORK_LATENCY=100,400,1000 or PODS_AFFECTED_PERC=10,30,50
+	StepsEnv = "INTENSITY_STEPS"
+	// StepDurationEnv is the duration of every step in seconds, the chaos duration is split among the steps by default
+	StepDurationEnv = "STEP_DURATION"
+)
+
+// runStepProbes evaluates the probes during a step, it is replaced in the tests
+var runStepProbes = probe.RunStepProbes
+
+// Parameters maps the parameters of a fault, which can be stepped, to their setters
+type Parameters map[string]func(value string) error
+
+// StringParameter returns the setter of a string parameter
+func StringParameter(field *string) func(string) error {
+	return func(value string) error {
+		*field = value
+		return nil
+	}
+}
+
+// IntParameter returns the setter of an integer parameter, the unit suffix (like ms) is ignored
+func IntParameter(field *int, unit string) func(string) error {
+	return func(value string) error {
+		v, err := strconv.Atoi(strings.TrimSuffix(value, unit))
+		if err != nil {
+			return fmt.Errorf("invalid value %q, it should be an integer", value)
+		}
+		*field = v
+		return nil
+	}
+}
+
+// Step is a single step of the intensity profile
+type Step struct {
+	Index     int
+	Parameter string
+	Value     string
+	// Duration is the duration of the step in seconds
+	Duration int
+}
+
+// Profile contains the steps, in which the intensity of the fault is increased
+type Profile struct {
+	Parameter string
+	Steps     []Step
+	set       func(value string) error
+}
+
+// GetProfile returns the intensity profile from the INTENSITY_STEPS and STEP_DURATION env
+// the env are read through types.Getenv, so that the env of the faults of a scenario override them
+func GetProfile(chaosDuration int, parameters Parameters) (*Profile, error) {
+	return ParseProfile(types.Getenv(StepsEnv, ""), types.Getenv(StepDurationEnv, ""), chaosDuration, parameters)
+}
+
+// ParseProfile returns the intensity profile from the given steps and step duration
+// it returns nil if the steps aren't provided, the chaos is injected with the constant parameters in that case
+func ParseProfile(steps, stepDuration string, chaosDuration int, parameters Parameters) (*Profile, error) {
+	steps = strings.TrimSpace(steps)
+	if steps == "" {
+		return nil, nil
+	}
+
+	parameter, values, ok := strings.Cut(steps, "=")
+	parameter = strings.TrimSpace(parameter)
+	if !ok || parameter == "" {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{intensitySteps: %s}", steps), Reason: "intensity steps should be in the PARAMETER=value1,value2 format"}
+	}
+	set, ok := parameters[parameter]
+	if !ok {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{intensitySteps: %s}", steps), Reason: fmt.Sprintf("%s can't be stepped, supported parameters are %v", parameter, parameters.names())}
+	}
+
+	var stepValues []string
+	for _, value := range strings.Split(values, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			stepValues = append(stepValues, value)
+		}
+	}
+	if len(stepValues) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{intensitySteps: %s}", steps), Reason: "no value provided for the intensity steps"}
+	}
+
+	duration := chaosDuration / len(stepValues)
+	if value := strings.TrimSpace(stepDuration); value != "" {
+		d, err := strconv.Atoi(value)
+		if err != nil || d <= 0 {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{stepDuration: %s}", value), Reason: "step duration should be a positive integer"}
+		}
+		duration = d
+	}
+	if duration < 1 {
+		duration = 1
+	}
+
+	profile := &Profile{Parameter: parameter, set: set}
+	for i, value := range stepValues {
+		profile.Steps = append(profile.Steps, Step{Index: i + 1, Parameter: parameter, Value: value, Duration: duration})
+	}
+	return profile, nil
+}
+
+// Run injects the chaos for every step, after setting the parameter to the value of the step
+// the onchaos and continuous probes are evaluated in the middle of every step and the intensity
+// isn't increased any further once a step isn't tolerated. The outcome of the steps is recorded in the chaos details
+func (p *Profile) Run(ctx context.Context, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, inject func(ctx context.Context, step Step) error) error {
+	for _, step := range p.Steps {
+		select {
+		case <-ctx.Done():
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: "experiment aborted before the intensity step " + strconv.Itoa(step.Index)}
+		default:
+		}
+
+		if err := p.set(step.Value); err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{step: %d, %s: %s}", step.Index, step.Parameter, step.Value), Reason: err.Error()}
+		}
+		log.Infof("[Intensity]: Running the step %d of %d with %s=%s for %vs", step.Index, len(p.Steps), step.Parameter, step.Value, step.Duration)
+
+		probeErr := make(chan error, 1)
+		go func(step Step) {
+			if len(resultDetails.ProbeDetails) == 0 {
+				probeErr <- nil
+				return
+			}
+			select {
+			case <-time.After(time.Duration(step.Duration) * time.Second / 2):
+				probeErr <- runStepProbes(ctx, chaosDetails, clients, resultDetails, fmt.Sprintf("step-%d", step.Index))
+			case <-ctx.Done():
+				probeErr <- nil
+			}
+		}(step)
+
+		if err := inject(ctx, step); err != nil {
+			return stacktrace.Propagate(err, "could not inject chaos in the intensity step %d", step.Index)
+		}
+
+		result := types.StepResult{Index: step.Index, Parameter: step.Parameter, Value: step.Value, Tolerated: true}
+		if err := <-probeErr; err != nil {
+			result.Tolerated, result.Reason = false, err.Error()
+		}
+		chaosDetails.StepResults = append(chaosDetails.StepResults, result)
+
+		if !result.Tolerated {
+			log.Warnf("[Intensity]: The step %d with %s=%s isn't tolerated, skipping the remaining steps, reason: %v", step.Index, step.Parameter, step.Value, result.Reason)
+			break
+		}
+	}
+
+	if highest := chaosDetails.GetHighestToleratedStep(); highest != nil {
+		log.Infof("[Intensity]: The highest tolerated step is %d with %s=%s", highest.Index, highest.Parameter, highest.Value)
+	} else {
+		log.Info("[Intensity]: None of the intensity steps were tolerated")
+	}
+	return nil
+}
+
+// names returns the sorted names of the parameters
+func (parameters Parameters) names() []string {
+	var names []string
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,116 @@
+package intensity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProfile(t *testing.T) {
+	var latency int
+	var podsAffectedPerc string
+	parameters := Parameters{
+		"NETWORK_LATENCY":    IntParameter(&latency, "ms"),
+		"PODS_AFFECTED_PERC": StringParameter(&podsAffectedPerc),
+	}
+
+	testCases := map[string]struct {
+		steps, stepDuration string
+		expected            []Step
+		err                 string
+	}{
+		"not provided": {},
+		"split chaos duration": {
+			steps: "NETWORK_LATENCY=100ms, 400ms,1000ms",
+			expected: []Step{
+				{Index: 1, Parameter: "NETWORK_LATENCY", Value: "100ms", Duration: 20},
+				{Index: 2, Parameter: "NETWORK_LATENCY", Value: "400ms", Duration: 20},
+				{Index: 3, Parameter: "NETWORK_LATENCY", Value: "1000ms", Duration: 20},
+			},
+		},
+		"step duration": {
+			steps:        "PODS_AFFECTED_PERC=10,50",
+			stepDuration: "15",
+			expected: []Step{
+				{Index: 1, Parameter: "PODS_AFFECTED_PERC", Value: "10", Duration: 15},
+				{Index: 2, Parameter: "PODS_AFFECTED_PERC", Value: "50", Duration: 15},
+			},
+		},
+		"unsupported parameter": {steps: "CPU_LOAD=10,20", err: "CPU_LOAD can't be stepped"},
+		"invalid format":        {steps: "100,400", err: "PARAMETER=value1,value2 format"},
+		"no values":             {steps: "NETWORK_LATENCY=,", err: "no value provided"},
+		"invalid step duration": {steps: "NETWORK_LATENCY=100", stepDuration: "-1", err: "positive integer"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(StepsEnv, tc.steps)
+			t.Setenv(StepDurationEnv, tc.stepDuration)
+			profile, err := GetProfile(60, parameters)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expected == nil {
+				assert.Nil(t, profile)
+				return
+			}
+			assert.Equal(t, tc.expected, profile.Steps)
+		})
+	}
+}
+
+func TestRunStopsAtFirstUntoleratedStep(t *testing.T) {
+	defer func(f func(context.Context, *types.ChaosDetails, clients.ClientSets, *types.ResultDetails, string) error) {
+		runStepProbes = f
+	}(runStepProbes)
+
+	var latency int
+	runStepProbes = func(_ context.Context, _ *types.ChaosDetails, _ clients.ClientSets, _ *types.ResultDetails, step string) error {
+		if latency >= 400 {
+			return fmt.Errorf("probe failed at %s", step)
+		}
+		return nil
+	}
+
+	t.Setenv(StepsEnv, "NETWORK_LATENCY=100,400,1000")
+	t.Setenv(StepDurationEnv, "")
+	profile, err := GetProfile(3, Parameters{"NETWORK_LATENCY": IntParameter(&latency, "ms")})
+	require.NoError(t, err)
+
+	var injected []int
+	chaosDetails := &types.ChaosDetails{}
+	resultDetails := &types.ResultDetails{ProbeDetails: []*types.ProbeDetails{{Name: "check"}}}
+	err = profile.Run(context.Background(), chaosDetails, clients.ClientSets{}, resultDetails, func(ctx context.Context, step Step) error {
+		injected = append(injected, latency)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{100, 400}, injected)
+	require.Len(t, chaosDetails.StepResults, 2)
+	assert.False(t, chaosDetails.StepResults[1].Tolerated)
+	assert.Contains(t, chaosDetails.StepResults[1].Reason, "step-2")
+	assert.Equal(t, "100", chaosDetails.GetHighestToleratedStep().Value)
+}
+
+func TestGetProfileReadsTheEnvOverrides(t *testing.T) {
+	var latency int
+	t.Setenv(StepsEnv, "NETWORK_LATENCY=100,400")
+	t.Setenv(StepDurationEnv, "")
+
+	var profile *Profile
+	var err error
+	types.WithEnv(map[string]string{StepsEnv: "NETWORK_LATENCY=200,800,1600", StepDurationEnv: "5"}, func() {
+		profile, err = GetProfile(60, Parameters{"NETWORK_LATENCY": IntParameter(&latency, "ms")})
+	})
+	require.NoError(t, err)
+	require.Len(t, profile.Steps, 3)
+	assert.Equal(t, Step{Index: 1, Parameter: "NETWORK_LATENCY", Value: "200", Duration: 5}, profile.Steps[0])
+}
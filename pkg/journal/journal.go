@@ -0,0 +1,280 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	retries "k8s.io/client-go/util/retry"
+)
+
+const (
+	// ComponentLabel is the label which identifies the journal configmaps
+	ComponentLabel = "app.kubernetes.io/component=chaos-journal"
+
+	// StatusInjected marks the entry as injected, it is written before injecting the chaos
+	StatusInjected = "injected"
+	// StatusReverted marks the entry as reverted
+	StatusReverted = "reverted"
+
+	// RevertTypeCommand denotes the revert commands, which should be executed on the node of the target
+	RevertTypeCommand = "command"
+	// RevertTypeAPI denotes the revert action, which should be performed via kubernetes/application api
+	RevertTypeAPI = "api"
+
+	// ActionRemoveTaint removes the taint with the given key, value and effect from the node
+	ActionRemoveTaint = "remove-taint"
+	// ActionUncordonNode marks the node as schedulable
+	ActionUncordonNode = "uncordon-node"
+	// ActionDeleteNetworkPolicy deletes the network policy
+	ActionDeleteNetworkPolicy = "delete-network-policy"
+	// ActionDisableChaosMonkey disables the chaos monkey assaults on the spring-boot pod
+	ActionDisableChaosMonkey = "disable-chaos-monkey"
+)
+
+var invalidKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// Entry contains the details of a chaos injection on a single target
+// it contains all the information required to replay the revert
+type Entry struct {
+	ID          string            `json:"id"`
+	Experiment  string            `json:"experiment"`
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Container   string            `json:"container,omitempty"`
+	ContainerID string            `json:"containerID,omitempty"`
+	Pid         int               `json:"pid,omitempty"`
+	Params      map[string]string `json:"params,omitempty"`
+	Revert      Revert            `json:"revert"`
+	Status      string            `json:"status"`
+	InjectedAt  string            `json:"injectedAt,omitempty"`
+	RevertedAt  string            `json:"revertedAt,omitempty"`
+
+	// Journal, JournalNamespace and ChaosUID identify the journal containing the entry
+	Journal          string `json:"-"`
+	JournalNamespace string `json:"-"`
+	ChaosUID         string `json:"-"`
+}
+
+// Revert contains the details of the revert of the chaos injection
+type Revert struct {
+	Type     string            `json:"type"`
+	Commands []string          `json:"commands,omitempty"`
+	Action   string            `json:"action,omitempty"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
+// NewEntry returns a journal entry for the given target
+func NewEntry(experiment, kind, name, namespace string) *Entry {
+	entry := &Entry{
+		Experiment: experiment,
+		Kind:       kind,
+		Name:       name,
+		Namespace:  namespace,
+		Params:     map[string]string{},
+	}
+	entry.ID = EntryID(kind, namespace, name, "")
+	return entry
+}
+
+// WithContainer sets the container details of the target
+func (entry *Entry) WithContainer(container, containerID string, pid int) *Entry {
+	entry.Container = container
+	entry.ContainerID = containerID
+	entry.Pid = pid
+	entry.ID = EntryID(entry.Kind, entry.Namespace, entry.Name, container)
+	return entry
+}
+
+// WithParam sets the fault parameter of the injection
+func (entry *Entry) WithParam(key, value string) *Entry {
+	entry.Params[key] = value
+	return entry
+}
+
+// WithRevertCommands sets the commands required to revert the chaos
+func (entry *Entry) WithRevertCommands(commands ...string) *Entry {
+	entry.Revert = Revert{Type: RevertTypeCommand, Commands: commands}
+	return entry
+}
+
+// WithRevertAction sets the api action required to revert the chaos
+func (entry *Entry) WithRevertAction(action string, args map[string]string) *Entry {
+	entry.Revert = Revert{Type: RevertTypeAPI, Action: action, Args: args}
+	return entry
+}
+
+// Record writes the entry inside the journal of the chaos pod, before the chaos injection
+func Record(entry *Entry, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	entry.Status = StatusInjected
+	entry.InjectedAt = time.Now().UTC().Format(time.RFC3339)
+	entry.RevertedAt = ""
+	return writeEntry(getJournalName(chaosDetails.ChaosPodName), chaosDetails.ChaosNamespace, entry, chaosDetails, clients)
+}
+
+// MarkReverted marks the entry of the chaos pod journal as reverted
+func MarkReverted(id string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	return MarkEntryReverted(getJournalName(chaosDetails.ChaosPodName), chaosDetails.ChaosNamespace, id, clients)
+}
+
+// MarkEntryReverted marks the entry of the given journal as reverted
+func MarkEntryReverted(journal, namespace, id string, clients clients.ClientSets) error {
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), journal, v1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{journal: %s, namespace: %s}", journal, namespace), Reason: err.Error()}
+		}
+
+		data, ok := cm.Data[id]
+		if !ok {
+			return nil
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{journal: %s, namespace: %s, entry: %s}", journal, namespace, id), Reason: fmt.Sprintf("failed to unmarshal journal entry: %s", err.Error())}
+		}
+		entry.Status = StatusReverted
+		entry.RevertedAt = time.Now().UTC().Format(time.RFC3339)
+
+		value, err := json.Marshal(entry)
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{journal: %s, namespace: %s, entry: %s}", journal, namespace, id), Reason: fmt.Sprintf("failed to marshal journal entry: %s", err.Error())}
+		}
+		cm.Data[id] = string(value)
+		_, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Update(context.Background(), cm, v1.UpdateOptions{})
+		return err
+	})
+}
+
+// GetPendingEntries returns all the entries, which are injected but not reverted yet
+// it looks for the journals inside all the namespaces, if the namespace is empty
+func GetPendingEntries(namespace string, clients clients.ClientSets) ([]Entry, error) {
+	cmList, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).List(context.Background(), v1.ListOptions{LabelSelector: ComponentLabel})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{namespace: %s}", namespace), Reason: fmt.Sprintf("failed to list the journals: %s", err.Error())}
+	}
+
+	var entries []Entry
+	for _, cm := range cmList.Items {
+		for key, data := range cm.Data {
+			var entry Entry
+			if err := json.Unmarshal([]byte(data), &entry); err != nil {
+				log.Warnf("[Journal]: Skipping invalid entry %v of %v journal, err: %v", key, cm.Name, err)
+				continue
+			}
+			if entry.Status == StatusReverted {
+				continue
+			}
+			entry.Journal = cm.Name
+			entry.JournalNamespace = cm.Namespace
+			entry.ChaosUID = cm.Labels["chaosUID"]
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// DeleteRevertedJournals deletes the journals of the inactive chaos, which have all the entries reverted
+func DeleteRevertedJournals(namespace string, activeChaos map[string]bool, clients clients.ClientSets) error {
+	cmList, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).List(context.Background(), v1.ListOptions{LabelSelector: ComponentLabel})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{namespace: %s}", namespace), Reason: fmt.Sprintf("failed to list the journals: %s", err.Error())}
+	}
+
+	for _, cm := range cmList.Items {
+		if activeChaos[cm.Labels["chaosUID"]] || hasPendingEntries(cm) {
+			continue
+		}
+		if err := clients.KubeClient.CoreV1().ConfigMaps(cm.Namespace).Delete(context.Background(), cm.Name, v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{journal: %s, namespace: %s}", cm.Name, cm.Namespace), Reason: fmt.Sprintf("failed to delete the journal: %s", err.Error())}
+		}
+		log.Infof("[Journal]: Deleted the %v journal", cm.Name)
+	}
+	return nil
+}
+
+// hasPendingEntries checks if the journal contains any entry, which is not reverted yet
+func hasPendingEntries(cm apiv1.ConfigMap) bool {
+	for _, data := range cm.Data {
+		var entry Entry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil || entry.Status != StatusReverted {
+			return true
+		}
+	}
+	return false
+}
+
+// writeEntry creates or updates the entry inside the journal
+func writeEntry(journal, namespace string, entry *Entry, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{journal: %s, namespace: %s, entry: %s}", journal, namespace, entry.ID), Reason: fmt.Sprintf("failed to marshal journal entry: %s", err.Error())}
+	}
+
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), journal, v1.GetOptions{})
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{journal: %s, namespace: %s}", journal, namespace), Reason: err.Error()}
+			}
+			cm = &apiv1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      journal,
+					Namespace: namespace,
+					Labels: map[string]string{
+						"name":                        journal,
+						"chaosUID":                    string(chaosDetails.ChaosUID),
+						"app.kubernetes.io/part-of":   "litmus",
+						"app.kubernetes.io/component": "chaos-journal",
+					},
+				},
+				Data: map[string]string{entry.ID: string(value)},
+			}
+			if _, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Create(context.Background(), cm, v1.CreateOptions{}); err != nil {
+				if k8serrors.IsAlreadyExists(err) {
+					return k8serrors.NewConflict(apiv1.Resource("configmaps"), journal, err)
+				}
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{journal: %s, namespace: %s}", journal, namespace), Reason: fmt.Sprintf("failed to create the journal: %s", err.Error())}
+			}
+			return nil
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[entry.ID] = string(value)
+		_, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Update(context.Background(), cm, v1.UpdateOptions{})
+		return err
+	})
+}
+
+// getJournalName returns the name of the journal of the chaos pod
+func getJournalName(podName string) string {
+	return podName + "-journal"
+}
+
+// EntryID returns the id of the entry for the given target, it is used as the key inside the journal
+func EntryID(kind, namespace, name, container string) string {
+	values := []string{kind, namespace, name, container}
+	var parts []string
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, invalidKeyChars.ReplaceAllString(v, "_"))
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,123 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEntry() *Entry {
+	return NewEntry("node-taint", "node", "node-1", "").
+		WithParam("taint", "app=node-taint:NoExecute").
+		WithRevertAction(ActionRemoveTaint, map[string]string{"node": "node-1", "key": "app", "value": "node-taint", "effect": "NoExecute"})
+}
+
+// getEntry returns the entry of the journal, as stored inside the configmap
+func getEntry(t *testing.T, clientSets clients.ClientSets, journal, namespace, id string) Entry {
+	cm, err := clientSets.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), journal, v1.GetOptions{})
+	require.NoError(t, err)
+	var entry Entry
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[id]), &entry))
+	return entry
+}
+
+func TestEntryID(t *testing.T) {
+	assert.Equal(t, "node.node-1", EntryID("node", "", "node-1", ""))
+	assert.Equal(t, "pod.default.nginx-0.nginx", EntryID("pod", "default", "nginx-0", "nginx"))
+	assert.Equal(t, "pod.default.nginx_0.sidecar_proxy", EntryID("pod", "default", "nginx:0", "sidecar/proxy"))
+}
+
+func TestRecordAndMarkReverted(t *testing.T) {
+	clientSets := clients.ClientSets{KubeClient: fake.NewSimpleClientset()}
+	chaosDetails := &types.ChaosDetails{ChaosPodName: "node-taint-abcde", ChaosNamespace: "litmus", ChaosUID: "uid-1"}
+
+	entry := testEntry()
+	require.NoError(t, Record(entry, chaosDetails, clientSets))
+
+	cm, err := clientSets.KubeClient.CoreV1().ConfigMaps("litmus").Get(context.Background(), "node-taint-abcde-journal", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "uid-1", cm.Labels["chaosUID"])
+	assert.Equal(t, "chaos-journal", cm.Labels["app.kubernetes.io/component"])
+
+	recorded := getEntry(t, clientSets, "node-taint-abcde-journal", "litmus", entry.ID)
+	assert.Equal(t, StatusInjected, recorded.Status)
+	assert.NotEmpty(t, recorded.InjectedAt)
+	assert.Equal(t, entry.Revert, recorded.Revert)
+	assert.Equal(t, "app=node-taint:NoExecute", recorded.Params["taint"])
+
+	pending, err := GetPendingEntries("", clientSets)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "node-taint-abcde-journal", pending[0].Journal)
+	assert.Equal(t, "litmus", pending[0].JournalNamespace)
+	assert.Equal(t, "uid-1", pending[0].ChaosUID)
+
+	require.NoError(t, MarkReverted(entry.ID, chaosDetails, clientSets))
+	reverted := getEntry(t, clientSets, "node-taint-abcde-journal", "litmus", entry.ID)
+	assert.Equal(t, StatusReverted, reverted.Status)
+	assert.NotEmpty(t, reverted.RevertedAt)
+
+	pending, err = GetPendingEntries("", clientSets)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// the missing journals and entries are ignored
+	assert.NoError(t, MarkEntryReverted("unknown-journal", "litmus", entry.ID, clientSets))
+	assert.NoError(t, MarkEntryReverted("node-taint-abcde-journal", "litmus", "node.unknown", clientSets))
+}
+
+func TestGetPendingEntriesSkipsInvalidEntries(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "pod-delete-abcde-journal",
+			Namespace: "litmus",
+			Labels:    map[string]string{"app.kubernetes.io/component": "chaos-journal", "chaosUID": "uid-1"},
+		},
+		Data: map[string]string{
+			"pod.default.nginx-0": "{invalid",
+			"pod.default.nginx-1": `{"id":"pod.default.nginx-1","kind":"pod","name":"nginx-1","status":"injected"}`,
+		},
+	})
+
+	entries, err := GetPendingEntries("litmus", clients.ClientSets{KubeClient: kubeClient})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "nginx-1", entries[0].Name)
+}
+
+func TestDeleteRevertedJournals(t *testing.T) {
+	journal := func(name, uid, status string) *apiv1.ConfigMap {
+		return &apiv1.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: "litmus",
+				Labels:    map[string]string{"app.kubernetes.io/component": "chaos-journal", "chaosUID": uid},
+			},
+			Data: map[string]string{"node.node-1": `{"id":"node.node-1","status":"` + status + `"}`},
+		}
+	}
+	kubeClient := fake.NewSimpleClientset(
+		journal("reverted-journal", "uid-1", StatusReverted),
+		journal("pending-journal", "uid-2", StatusInjected),
+		journal("active-journal", "uid-3", StatusReverted),
+	)
+	clientSets := clients.ClientSets{KubeClient: kubeClient}
+
+	require.NoError(t, DeleteRevertedJournals("", map[string]bool{"uid-3": true}, clientSets))
+
+	cmList, err := kubeClient.CoreV1().ConfigMaps("litmus").List(context.Background(), v1.ListOptions{})
+	require.NoError(t, err)
+	var names []string
+	for _, cm := range cmList.Items {
+		names = append(names, cm.Name)
+	}
+	assert.ElementsMatch(t, []string{"pending-journal", "active-journal"}, names)
+}
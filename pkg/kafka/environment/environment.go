@@ -1,16 +1,18 @@
 package environment
 
 import (
+	"fmt"
 	"strconv"
 
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	exp "github.com/litmuschaos/litmus-go/pkg/generic/pod-delete/types"
 	kafkaTypes "github.com/litmuschaos/litmus-go/pkg/kafka/types"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	clientTypes "k8s.io/apimachinery/pkg/types"
 )
 
-// GetENV fetches all the env variables from the runner pod
-func GetENV(kafkaDetails *kafkaTypes.ExperimentDetails) {
+// GetENV fetches all the env variables from the runner pod, it returns the error if the targets are malformed
+func GetENV(kafkaDetails *kafkaTypes.ExperimentDetails) error {
 
 	var ChaoslibDetail exp.ExperimentDetails
 
@@ -31,7 +33,8 @@ func GetENV(kafkaDetails *kafkaTypes.ExperimentDetails) {
 	ChaoslibDetail.Delay, _ = strconv.Atoi(types.Getenv("STATUS_CHECK_DELAY", "2"))
 	ChaoslibDetail.Timeout, _ = strconv.Atoi(types.Getenv("STATUS_CHECK_TIMEOUT", "180"))
 
-	ChaoslibDetail.AppNS, ChaoslibDetail.AppKind, ChaoslibDetail.AppLabel = getAppDetails()
+	var err error
+	ChaoslibDetail.AppNS, ChaoslibDetail.AppKind, ChaoslibDetail.AppLabel, err = getAppDetails()
 
 	kafkaDetails.ChaoslibDetail = &ChaoslibDetail
 	kafkaDetails.KafkaKind = types.Getenv("KAFKA_KIND", "statefulset")
@@ -50,14 +53,18 @@ func GetENV(kafkaDetails *kafkaTypes.ExperimentDetails) {
 	kafkaDetails.ZookeeperService = types.Getenv("ZOOKEEPER_SERVICE", "")
 	kafkaDetails.ZookeeperPort = types.Getenv("ZOOKEEPER_PORT", "")
 	kafkaDetails.RunID = types.Getenv("RunID", "")
-
+	return err
 }
 
-func getAppDetails() (string, string, string) {
+// getAppDetails returns the namespace, kind and label of the first target, it returns the error if the targets are malformed
+func getAppDetails() (string, string, string, error) {
 	targets := types.Getenv("TARGETS", "")
-	app := types.GetTargets(targets)
-	if len(app) != 0 {
-		return app[0].Namespace, app[0].Kind, app[0].Labels[0]
+	app, err := types.ParseTargets(targets)
+	if err != nil {
+		return "", "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("unable to parse the TARGETS env: %v", err)}
+	}
+	if len(app) != 0 && len(app[0].Labels) != 0 {
+		return app[0].Namespace, app[0].Kind, app[0].Labels[0], nil
 	}
-	return "", "", ""
+	return "", "", "", nil
 }
@@ -1,6 +1,8 @@
 package kafka
 
 import (
+	"context"
+
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kafka/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
@@ -8,17 +10,17 @@ import (
 )
 
 // ClusterHealthCheck checks health of the kafka cluster
-func ClusterHealthCheck(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
+func ClusterHealthCheck(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
 
 	// Checking Kafka pods status
 	log.Info("[Status]: Verify that all the kafka pods are running")
-	if err := status.CheckApplicationStatusesByLabels(experimentsDetails.KafkaNamespace, experimentsDetails.KafkaLabel, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
+	if err := status.CheckApplicationStatusesByLabels(ctx, experimentsDetails.KafkaNamespace, experimentsDetails.KafkaLabel, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
 		return err
 	}
 
 	// Checking zookeeper pods status
 	log.Info("[Status]: Verify that all the zookeeper pods are running")
-	return status.CheckApplicationStatusesByLabels(experimentsDetails.ZookeeperNamespace, experimentsDetails.ZookeeperLabel, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients)
+	return status.CheckApplicationStatusesByLabels(ctx, experimentsDetails.ZookeeperNamespace, experimentsDetails.ZookeeperLabel, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients)
 }
 
 // DisplayKafkaBroker displays the kafka broker info
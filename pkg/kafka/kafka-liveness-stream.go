@@ -19,7 +19,7 @@ import (
 
 // LivenessStream generates kafka liveness pod, which continuously validate the liveness of kafka brokers
 // and derive the kafka topic leader(candidate for the deletion)
-func LivenessStream(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
+func LivenessStream(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
 	var ordinality string
 	var err error
 
@@ -34,7 +34,7 @@ func LivenessStream(experimentsDetails *experimentTypes.ExperimentDetails, clien
 	}
 
 	log.Info("[Liveness]: Confirm that the kafka liveness pod is running")
-	if err := status.CheckApplicationStatusesByLabels(experimentsDetails.KafkaNamespace, "name=kafka-liveness-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
+	if err := status.CheckApplicationStatusesByLabels(ctx, experimentsDetails.KafkaNamespace, "name=kafka-liveness-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
 		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Reason: fmt.Sprintf("liveness pod status check failed, err: %v", err)}
 	}
 
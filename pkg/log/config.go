@@ -0,0 +1,221 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/trace"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+const (
+	// LoggerName is the name of the otel logger used to export the log lines
+	LoggerName = "litmuschaos.io/litmus-go"
+	// FormatEnv selects the log format, it supports text (default) and json
+	FormatEnv = "LOG_FORMAT"
+	// LevelEnv selects the minimum log level, it defaults to info
+	LevelEnv = "LOG_LEVEL"
+)
+
+type targetKey struct{}
+
+var (
+	// standard fields added to every log line
+	fields   = logrus.Fields{}
+	fieldsMu sync.RWMutex
+	// defaultCtx is used for the log lines logged without a context
+	defaultCtx context.Context
+)
+
+// Configure sets the format, level and hooks of the logger
+// It derives the format & level from the LOG_FORMAT & LOG_LEVEL envs
+// and the standard fields from the chaos envs, which are present in both experiment & helper pods
+func Configure() {
+	switch strings.ToLower(os.Getenv(FormatEnv)) {
+	case "json":
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		logrus.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp:          true,
+			DisableSorting:         true,
+			DisableLevelTruncation: true,
+		})
+	}
+
+	level := logrus.InfoLevel
+	if value := os.Getenv(LevelEnv); value != "" {
+		parsed, err := logrus.ParseLevel(value)
+		if err != nil {
+			logrus.Warnf("Invalid %v: %v, using the info level", LevelEnv, value)
+		} else {
+			level = parsed
+		}
+	}
+	logrus.SetLevel(level)
+
+	runID := os.Getenv("CHAOS_UID")
+	if value := os.Getenv("RUN_ID"); value != "" {
+		runID = value
+	}
+	for key, value := range map[string]string{
+		"experiment": os.Getenv("EXPERIMENT_NAME"),
+		"engine":     os.Getenv("CHAOSENGINE"),
+		"runID":      runID,
+	} {
+		SetField(key, value)
+	}
+
+	logrus.AddHook(&fieldsHook{})
+	logrus.AddHook(&otelHook{})
+}
+
+// SetField sets a standard field, which is added to all the subsequent log lines
+// an empty value removes the field
+func SetField(key, value string) {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	if value == "" {
+		delete(fields, key)
+		return
+	}
+	fields[key] = value
+}
+
+// SetPhase sets the experiment phase added to all the subsequent log lines
+func SetPhase(phase string) {
+	SetField("phase", phase)
+}
+
+// SetDefaultContext sets the context used for the log lines logged without a context
+// the root span of the experiment is set as default, so that all the log lines are correlated with its trace
+func SetDefaultContext(ctx context.Context) {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	defaultCtx = ctx
+}
+
+// entryContext returns the context of the entry, it falls back to the default context
+func entryContext(entry *logrus.Entry) context.Context {
+	if entry.Context != nil {
+		return entry.Context
+	}
+	fieldsMu.RLock()
+	defer fieldsMu.RUnlock()
+	return defaultCtx
+}
+
+// ContextWithTarget returns a copy of the context, which carries the chaos target
+// the target is added to the log lines logged with this context
+func ContextWithTarget(ctx context.Context, target string) context.Context {
+	return context.WithValue(ctx, targetKey{}, target)
+}
+
+// InfofWithContext log the General operational entries along with the trace & target details of the context
+func InfofWithContext(ctx context.Context, msg string, val ...interface{}) {
+	logrus.WithContext(ctx).Infof(msg, val...)
+}
+
+// WarnfWithContext log the Non-critical entries along with the trace & target details of the context
+func WarnfWithContext(ctx context.Context, msg string, val ...interface{}) {
+	logrus.WithContext(ctx).Warnf(msg, val...)
+}
+
+// ErrorfWithContext log the errors along with the trace & target details of the context
+func ErrorfWithContext(ctx context.Context, msg string, err ...interface{}) {
+	logrus.WithContext(ctx).Errorf(msg, err...)
+}
+
+// fieldsHook adds the standard fields and the details of the entry (or default) context to the log lines
+type fieldsHook struct{}
+
+func (h *fieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *fieldsHook) Fire(entry *logrus.Entry) error {
+	fieldsMu.RLock()
+	for key, value := range fields {
+		if _, ok := entry.Data[key]; !ok {
+			entry.Data[key] = value
+		}
+	}
+	fieldsMu.RUnlock()
+
+	ctx := entryContext(entry)
+	if ctx == nil {
+		return nil
+	}
+	if target, ok := ctx.Value(targetKey{}).(string); ok && target != "" {
+		entry.Data["target"] = target
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		entry.Data["traceID"] = sc.TraceID().String()
+		entry.Data["spanID"] = sc.SpanID().String()
+	}
+	return nil
+}
+
+// otelHook exports the log lines via the global otel logger provider
+// it is a no-op until the logger provider is registered by the telemetry package
+type otelHook struct{}
+
+func (h *otelHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *otelHook) Fire(entry *logrus.Entry) error {
+	ctx := entryContext(entry)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(entry.Time)
+	record.SetSeverity(severity(entry.Level))
+	record.SetSeverityText(entry.Level.String())
+	record.SetBody(otellog.StringValue(entry.Message))
+	for key, value := range entry.Data {
+		// trace details are carried by the record itself
+		if key == "traceID" || key == "spanID" {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			record.AddAttributes(otellog.String(key, v))
+		case error:
+			record.AddAttributes(otellog.String(key, v.Error()))
+		default:
+			record.AddAttributes(otellog.String(key, fmt.Sprint(v)))
+		}
+	}
+
+	global.Logger(LoggerName).Emit(ctx, record)
+	return nil
+}
+
+// severity maps the logrus level to the otel severity
+func severity(level logrus.Level) otellog.Severity {
+	switch level {
+	case logrus.TraceLevel:
+		return otellog.SeverityTrace
+	case logrus.DebugLevel:
+		return otellog.SeverityDebug
+	case logrus.InfoLevel:
+		return otellog.SeverityInfo
+	case logrus.WarnLevel:
+		return otellog.SeverityWarn
+	case logrus.ErrorLevel:
+		return otellog.SeverityError
+	case logrus.FatalLevel:
+		return otellog.SeverityFatal
+	case logrus.PanicLevel:
+		return otellog.SeverityFatal4
+	}
+	return otellog.SeverityUndefined
+}
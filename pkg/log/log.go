@@ -16,6 +16,16 @@ func Fatal(msg string) {
 	logrus.WithFields(logrus.Fields{}).Fatal(msg)
 }
 
+// RegisterExitHandler adds a handler, which is run before the process exits via Fatal, Fatalf or Exit
+func RegisterExitHandler(handler func()) {
+	logrus.RegisterExitHandler(handler)
+}
+
+// Exit runs the registered exit handlers and then terminates the process with the given exit code
+func Exit(code int) {
+	logrus.Exit(code)
+}
+
 // Infof log the General operational entries about what's going on inside the application
 func Infof(msg string, val ...interface{}) {
 	logrus.WithFields(logrus.Fields{}).Infof(msg, val...)
@@ -0,0 +1,168 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// Event contains the details of an experiment lifecycle event
+// it is sent as the data of the cloudevent and is available to the payload templates
+type Event struct {
+	Experiment string    `json:"experiment"`
+	Engine     string    `json:"engine,omitempty"`
+	Namespace  string    `json:"namespace"`
+	RunID      string    `json:"runID"`
+	Kind       string    `json:"kind"`
+	Resource   string    `json:"resource"`
+	Reason     string    `json:"reason"`
+	Message    string    `json:"message"`
+	Type       string    `json:"type"`
+	Phase      string    `json:"phase,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// Notifier delivers the experiment lifecycle events to an external system
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+const (
+	// queueSize is the maximum number of the events waiting for the delivery, the events are dropped once it is full
+	queueSize = 100
+	// notifyTimeout bounds the delivery of an event to a notifier, including its retries
+	notifyTimeout = 30 * time.Second
+	// FlushTimeout bounds the delivery of the queued events, before the process exits
+	FlushTimeout = 10 * time.Second
+)
+
+var (
+	notifiers []Notifier
+	mu        sync.Mutex
+	once      sync.Once
+	started   atomic.Bool
+	queue     = make(chan queued, queueSize)
+)
+
+// queued is an entry of the delivery queue, it is either an event or a flush marker
+// the flush marker is closed once all the events queued before it are delivered
+type queued struct {
+	event   Event
+	flushed chan struct{}
+}
+
+// Register adds a notifier, which receives all the subsequent lifecycle events
+func Register(notifier Notifier) {
+	mu.Lock()
+	defer mu.Unlock()
+	notifiers = append(notifiers, notifier)
+}
+
+// Send queues the lifecycle event for the delivery to all the registered notifiers
+// the webhook notifier is registered and the delivery is started on the first call
+// The events are delivered in the background, so that the notifications don't delay the experiment,
+// and the failures are only logged. The queued events are flushed before the process exits
+func Send(eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, kind string) {
+	once.Do(start)
+
+	mu.Lock()
+	registered := len(notifiers)
+	mu.Unlock()
+	if registered == 0 {
+		return
+	}
+
+	event := NewEvent(eventsDetails, chaosDetails, kind)
+	select {
+	case queue <- queued{event: event}:
+	default:
+		log.Warnf("[Notify]: The notification queue is full, dropping the %v notification", event.Reason)
+	}
+}
+
+// Flush waits till the queued events are delivered or the timeout is elapsed
+func Flush(timeout time.Duration) {
+	if !started.Load() {
+		return
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	flushed := make(chan struct{})
+	select {
+	case queue <- queued{flushed: flushed}:
+	case <-timer.C:
+		log.Warnf("[Notify]: Unable to deliver the queued notifications within %v", timeout)
+		return
+	}
+	select {
+	case <-flushed:
+	case <-timer.C:
+		log.Warnf("[Notify]: Unable to deliver the queued notifications within %v", timeout)
+	}
+}
+
+// start registers the notifiers from the envs and starts the delivery of the queued events
+// the queued events are flushed if the process exits via log.Fatal or common.Exit
+func start() {
+	registerFromEnv()
+	log.RegisterExitHandler(func() { Flush(FlushTimeout) })
+	started.Store(true)
+	go deliver()
+}
+
+// deliver sends the queued events to all the registered notifiers, in the order of the events
+func deliver() {
+	for entry := range queue {
+		if entry.flushed != nil {
+			close(entry.flushed)
+			continue
+		}
+
+		event := entry.event
+		mu.Lock()
+		targets := append([]Notifier{}, notifiers...)
+		mu.Unlock()
+
+		for _, notifier := range targets {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			if err := notifier.Notify(ctx, event); err != nil {
+				log.Errorf("[Notify]: Unable to send the %v notification, err: %v", event.Reason, err)
+			}
+			cancel()
+		}
+	}
+}
+
+// NewEvent builds the lifecycle event from the event & chaos details
+func NewEvent(eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, kind string) Event {
+	return Event{
+		Experiment: chaosDetails.ExperimentName,
+		Engine:     chaosDetails.EngineName,
+		Namespace:  chaosDetails.ChaosNamespace,
+		RunID:      string(chaosDetails.ChaosUID),
+		Kind:       kind,
+		Resource:   eventsDetails.ResourceName,
+		Reason:     eventsDetails.Reason,
+		Message:    eventsDetails.Message,
+		Type:       eventsDetails.Type,
+		Phase:      string(chaosDetails.Phase),
+		Time:       time.Now().UTC(),
+	}
+}
+
+// registerFromEnv registers the webhook notifier, if the webhook urls are provided
+func registerFromEnv() {
+	notifier, err := NewWebhookNotifierFromEnv()
+	if err != nil {
+		log.Errorf("[Notify]: Unable to configure the webhook notifier, err: %v", err)
+		return
+	}
+	if notifier != nil {
+		Register(notifier)
+	}
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type notifierFunc func(ctx context.Context, event Event) error
+
+func (f notifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// registerTestNotifier registers the notifier, which is removed once the test is completed
+func registerTestNotifier(t *testing.T, notifier Notifier) {
+	Register(notifier)
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		notifiers = nil
+	})
+}
+
+func sendTestEvent(reason string) {
+	Send(&types.EventDetails{Reason: reason, ResourceName: "nginx-chaos"}, &types.ChaosDetails{ExperimentName: "pod-delete", ChaosNamespace: "litmus"}, "ChaosEngine")
+}
+
+func TestSendDoesNotWaitForTheDelivery(t *testing.T) {
+	release := make(chan struct{})
+	delivered := make(chan Event, 2)
+	registerTestNotifier(t, notifierFunc(func(ctx context.Context, event Event) error {
+		<-release
+		delivered <- event
+		return nil
+	}))
+
+	start := time.Now()
+	sendTestEvent("ChaosInject")
+	sendTestEvent("PostChaosCheck")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Empty(t, delivered)
+
+	close(release)
+	Flush(5 * time.Second)
+	assert.Len(t, delivered, 2)
+	assert.Equal(t, "ChaosInject", (<-delivered).Reason)
+	assert.Equal(t, "PostChaosCheck", (<-delivered).Reason)
+}
+
+func TestFlushIsBoundedByTheTimeout(t *testing.T) {
+	release := make(chan struct{})
+	registerTestNotifier(t, notifierFunc(func(ctx context.Context, event Event) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}))
+	t.Cleanup(func() {
+		close(release)
+		Flush(5 * time.Second)
+	})
+
+	sendTestEvent("ChaosInject")
+
+	start := time.Now()
+	Flush(100 * time.Millisecond)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestSendWithoutNotifiers(t *testing.T) {
+	sendTestEvent("ChaosInject")
+
+	start := time.Now()
+	Flush(5 * time.Second)
+	assert.Less(t, time.Since(start), time.Second)
+}
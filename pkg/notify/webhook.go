@@ -0,0 +1,221 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+const (
+	// WebhookURLsEnv contains the comma separated urls of the webhook endpoints
+	WebhookURLsEnv = "NOTIFY_WEBHOOK_URLS"
+	// WebhookSecretEnv contains the key used to sign the payloads
+	WebhookSecretEnv = "NOTIFY_WEBHOOK_SECRET"
+	// WebhookTemplateEnv contains the go template of the cloudevent data, it is rendered with the Event
+	WebhookTemplateEnv = "NOTIFY_WEBHOOK_TEMPLATE"
+	// WebhookRetriesEnv is the maximum number of attempts for each endpoint
+	WebhookRetriesEnv = "NOTIFY_WEBHOOK_RETRIES"
+	// WebhookTimeoutEnv is the timeout of each attempt, in seconds
+	WebhookTimeoutEnv = "NOTIFY_WEBHOOK_TIMEOUT"
+
+	// SignatureHeader contains the hex encoded HMAC-SHA256 of the request body, prefixed with sha256=
+	SignatureHeader = "X-Litmus-Signature-256"
+	// ContentType is the content type of the structured mode cloudevents
+	ContentType = "application/cloudevents+json"
+	// EventTypePrefix is the prefix of the cloudevent types, the kind and reason of the event are appended to it
+	EventTypePrefix = "io.litmuschaos.experiment"
+
+	specVersion = "1.0"
+)
+
+// CloudEvent is the structured mode representation of the cloudevent
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// WebhookNotifier posts the lifecycle events, as cloudevents, to the http endpoints
+type WebhookNotifier struct {
+	URLs []string
+	// Secret signs the payloads, the signature is skipped if it is empty
+	Secret []byte
+	// Template renders the data of the cloudevent, the event is sent as is if it is nil
+	Template *template.Template
+	Retries  int
+	Backoff  time.Duration
+	Client   *http.Client
+}
+
+// NewWebhookNotifierFromEnv creates the webhook notifier from the envs
+// It returns nil if the webhook urls are not provided
+func NewWebhookNotifierFromEnv() (*WebhookNotifier, error) {
+	var urls []string
+	for _, url := range strings.Split(os.Getenv(WebhookURLsEnv), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	retries, err := strconv.Atoi(types.Getenv(WebhookRetriesEnv, "3"))
+	if err != nil || retries < 1 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid %s: %s", WebhookRetriesEnv, os.Getenv(WebhookRetriesEnv))}
+	}
+	timeout, err := strconv.Atoi(types.Getenv(WebhookTimeoutEnv, "5"))
+	if err != nil || timeout < 1 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid %s: %s", WebhookTimeoutEnv, os.Getenv(WebhookTimeoutEnv))}
+	}
+
+	notifier := &WebhookNotifier{
+		URLs:    urls,
+		Secret:  []byte(os.Getenv(WebhookSecretEnv)),
+		Retries: retries,
+		Backoff: time.Second,
+		Client:  &http.Client{Timeout: time.Duration(timeout) * time.Second},
+	}
+	if tmpl := os.Getenv(WebhookTemplateEnv); tmpl != "" {
+		if notifier.Template, err = template.New("payload").Parse(tmpl); err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("invalid %s: %s", WebhookTemplateEnv, err.Error())}
+		}
+	}
+	return notifier, nil
+}
+
+// Notify posts the event to all the endpoints
+// it returns the failures of all the endpoints, after exhausting the retries
+func (w *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := w.payload(event)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, url := range w.URLs {
+		if err := w.post(ctx, url, body); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", url, err.Error()))
+		}
+	}
+	if len(failed) != 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{reason: %s}", event.Reason), Reason: fmt.Sprintf("failed to post the notification to [%s]", strings.Join(failed, ", "))}
+	}
+	return nil
+}
+
+// payload returns the json encoded cloudevent of the event
+func (w *WebhookNotifier) payload(event Event) ([]byte, error) {
+	cloudEvent := CloudEvent{
+		SpecVersion:     specVersion,
+		ID:              uuid.NewString(),
+		Source:          fmt.Sprintf("/litmuschaos/%s/%s", event.Namespace, event.Experiment),
+		Type:            fmt.Sprintf("%s.%s.%s", EventTypePrefix, strings.ToLower(event.Kind), strings.ToLower(event.Reason)),
+		Subject:         event.Resource,
+		Time:            event.Time,
+		DataContentType: "application/json",
+	}
+
+	if w.Template == nil {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		cloudEvent.Data = data
+		return json.Marshal(cloudEvent)
+	}
+
+	var rendered bytes.Buffer
+	if err := w.Template.Execute(&rendered, event); err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to render the payload template: %s", err.Error())}
+	}
+	// the rendered payload is sent as text, if it is not a valid json
+	if json.Valid(rendered.Bytes()) {
+		cloudEvent.Data = rendered.Bytes()
+	} else {
+		text, _ := json.Marshal(rendered.String())
+		cloudEvent.DataContentType = "text/plain"
+		cloudEvent.Data = text
+	}
+	return json.Marshal(cloudEvent)
+}
+
+// post sends the payload to the endpoint, it retries on the connection failures, 429 & 5xx responses
+func (w *WebhookNotifier) post(ctx context.Context, url string, body []byte) error {
+	var err error
+	backoff := w.Backoff
+	for attempt := 1; attempt <= w.Retries; attempt++ {
+		var retryable bool
+		if retryable, err = w.send(ctx, url, body); err == nil || !retryable {
+			return err
+		}
+		if attempt == w.Retries {
+			break
+		}
+		log.Warnf("[Notify]: Attempt %v to post the notification to %v failed, err: %v", attempt, url, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// send posts the payload once, it returns whether the failure can be retried
+func (w *WebhookNotifier) send(ctx context.Context, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if len(w.Secret) != 0 {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(w.Secret, body))
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent() Event {
+	return Event{
+		Experiment: "pod-delete",
+		Engine:     "nginx-chaos",
+		Namespace:  "litmus",
+		RunID:      "1234",
+		Kind:       "ChaosEngine",
+		Resource:   "nginx-chaos",
+		Reason:     "ChaosInject",
+		Message:    "Injecting pod-delete chaos on application pod",
+		Type:       "Normal",
+		Time:       time.Now().UTC(),
+	}
+}
+
+func TestWebhookNotifierSendsSignedCloudEvent(t *testing.T) {
+	secret := []byte("secret")
+	var (
+		body   []byte
+		header http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	notifier := &WebhookNotifier{URLs: []string{server.URL}, Secret: secret, Retries: 1}
+	require.NoError(t, notifier.Notify(context.Background(), testEvent()))
+
+	assert.Equal(t, ContentType, header.Get("Content-Type"))
+	assert.Equal(t, "sha256="+Sign(secret, body), header.Get(SignatureHeader))
+
+	var cloudEvent CloudEvent
+	require.NoError(t, json.Unmarshal(body, &cloudEvent))
+	assert.Equal(t, "1.0", cloudEvent.SpecVersion)
+	assert.Equal(t, "io.litmuschaos.experiment.chaosengine.chaosinject", cloudEvent.Type)
+	assert.Equal(t, "/litmuschaos/litmus/pod-delete", cloudEvent.Source)
+	assert.NotEmpty(t, cloudEvent.ID)
+
+	var event Event
+	require.NoError(t, json.Unmarshal(cloudEvent.Data, &event))
+	assert.Equal(t, "ChaosInject", event.Reason)
+	assert.Equal(t, "1234", event.RunID)
+}
+
+func TestWebhookNotifierRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	notifier := &WebhookNotifier{URLs: []string{server.URL}, Retries: 3, Backoff: time.Millisecond}
+	require.NoError(t, notifier.Notify(context.Background(), testEvent()))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestWebhookNotifierDoesNotRetryClientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	notifier := &WebhookNotifier{URLs: []string{server.URL}, Retries: 3, Backoff: time.Millisecond}
+	assert.Error(t, notifier.Notify(context.Background(), testEvent()))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestWebhookNotifierTemplate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		template    string
+		contentType string
+		data        string
+	}{
+		{
+			name:        "json payload",
+			template:    `{"text": "{{ .Experiment }}: {{ .Message }}"}`,
+			contentType: "application/json",
+			data:        `{"text": "pod-delete: Injecting pod-delete chaos on application pod"}`,
+		},
+		{
+			name:        "text payload",
+			template:    `{{ .Reason }} in {{ .Namespace }}`,
+			contentType: "text/plain",
+			data:        `"ChaosInject in litmus"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+			}))
+			defer server.Close()
+
+			notifier := &WebhookNotifier{URLs: []string{server.URL}, Retries: 1, Template: template.Must(template.New("payload").Parse(tc.template))}
+			require.NoError(t, notifier.Notify(context.Background(), testEvent()))
+
+			var cloudEvent CloudEvent
+			require.NoError(t, json.Unmarshal(body, &cloudEvent))
+			assert.Equal(t, tc.contentType, cloudEvent.DataContentType)
+			assert.JSONEq(t, tc.data, string(cloudEvent.Data))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+)
+
+// sampleProbe runs a single evaluation of the probe in the baseline, it is replaced in the tests
+var sampleProbe = triggerStepProbe
+
+// runBaseline establishes the steady state before the chaos, by sampling the probes at every interval
+// The steady state isn't established if the flakiness of any probe, i.e. the percentage of its failed samples,
+// is above the max flakiness. It fails as soon as the threshold is crossed, without waiting for the remaining samples.
+// The verdicts of the probes aren't changed, the statistics of the samples are recorded for the comparison in the report
+func runBaseline(ctx context.Context, probes []v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	baseline := chaosDetails.Baseline
+	if baseline.Samples <= 0 || len(probes) == 0 {
+		return nil
+	}
+
+	types.SetExperimentPhase(chaosDetails, types.BaselinePhase)
+	defer types.SetExperimentPhase(chaosDetails, types.PreChaosPhase)
+
+	log.InfoWithValues("[Baseline]: Establishing the steady state, before the chaos", logrus.Fields{
+		"Samples":       baseline.Samples,
+		"Interval":      baseline.Interval,
+		"Max Flakiness": baseline.MaxFlakiness,
+	})
+
+	results := map[string]*types.BaselineResult{}
+	var sampled []v1alpha1.ProbeAttributes
+	for sample := 1; sample <= baseline.Samples; sample++ {
+		candidates := sampled
+		if sample == 1 {
+			candidates = probes
+		}
+
+		for _, probe := range candidates {
+			probeDetails := getProbeByName(probe.Name, resultDetails.ProbeDetails)
+			if probeDetails != nil {
+				setMeasuredValue(resultDetails, probe.Name, "")
+			}
+			err := sampleProbe(probe, clients, resultDetails)
+			if err == errStepProbeNotSupported {
+				log.Infof("[Baseline]: Skipping the %v probe, as it can't be evaluated once", probe.Name)
+				continue
+			}
+			if sample == 1 {
+				sampled = append(sampled, probe)
+				results[probe.Name] = &types.BaselineResult{Probe: probe.Name}
+			}
+
+			result := results[probe.Name]
+			result.Samples++
+			verdict := v1alpha1.ProbeVerdictPassed
+			if err != nil {
+				verdict = v1alpha1.ProbeVerdictFailed
+				result.Failed++
+			}
+			if probeDetails != nil {
+				evaluation := recordEvaluation(probeDetails, string(types.BaselinePhase), verdict)
+				recordMeasuredValue(result, evaluation.MeasuredValue)
+			}
+
+			// the failures can only grow, so the steady state can't be established once the threshold is crossed
+			if float64(result.Failed)*100 > baseline.MaxFlakiness*float64(baseline.Samples) {
+				chaosDetails.BaselineResults = getBaselineResults(sampled, results)
+				return cerrors.Error{
+					ErrorCode: cerrors.ErrorTypeSteadyState,
+					Target:    fmt.Sprintf("{name: %s}", probe.Name),
+					Reason:    fmt.Sprintf("steady state isn't established, %d of the %d samples failed, which is above the max flakiness of %v%%, err: %v", result.Failed, baseline.Samples, baseline.MaxFlakiness, stacktrace.RootCause(err)),
+				}
+			}
+		}
+
+		if sample < baseline.Samples && baseline.Interval > 0 {
+			timer := time.NewTimer(time.Duration(baseline.Interval) * time.Second)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				chaosDetails.BaselineResults = getBaselineResults(sampled, results)
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: fmt.Sprintf("experiment aborted while establishing the steady state, err: %v", context.Cause(ctx))}
+			}
+		}
+	}
+
+	chaosDetails.BaselineResults = getBaselineResults(sampled, results)
+	for _, result := range chaosDetails.BaselineResults {
+		log.InfoWithValues("[Baseline]: The steady state of the probe is established", logrus.Fields{
+			"Probe":     result.Probe,
+			"Samples":   result.Samples,
+			"Failed":    result.Failed,
+			"Flakiness": result.Flakiness,
+		})
+	}
+	return nil
+}
+
+// recordMeasuredValue adds the measured value of the sample to the statistics, if it is numeric
+func recordMeasuredValue(result *types.BaselineResult, value string) {
+	measured, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(measured) || math.IsInf(measured, 0) {
+		return
+	}
+	if result.Measured == 0 || measured < result.Min {
+		result.Min = measured
+	}
+	if result.Measured == 0 || measured > result.Max {
+		result.Max = measured
+	}
+	result.Mean = (result.Mean*float64(result.Measured) + measured) / float64(result.Measured+1)
+	result.Measured++
+}
+
+// getBaselineResults returns the statistics of the sampled probes, in the order of the probes
+func getBaselineResults(probes []v1alpha1.ProbeAttributes, results map[string]*types.BaselineResult) []types.BaselineResult {
+	var baselineResults []types.BaselineResult
+	for _, probe := range probes {
+		result := *results[probe.Name]
+		result.Flakiness = float64(result.Failed) * 100 / float64(result.Samples)
+		baselineResults = append(baselineResults, result)
+	}
+	return baselineResults
+}
@@ -0,0 +1,105 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSamples replaces the evaluation of the probes with the given outcomes, in the order of the samples
+func fakeSamples(t *testing.T, outcomes map[string][]error, measured map[string][]string) {
+	sampled := map[string]int{}
+	sampleProbe = func(probe v1alpha1.ProbeAttributes, _ clients.ClientSets, resultDetails *types.ResultDetails) error {
+		errs, ok := outcomes[probe.Name]
+		if !ok {
+			return errStepProbeNotSupported
+		}
+		sample := sampled[probe.Name]
+		sampled[probe.Name]++
+		if values := measured[probe.Name]; sample < len(values) {
+			getProbeByName(probe.Name, resultDetails.ProbeDetails).MeasuredValue = values[sample]
+		}
+		return errs[sample]
+	}
+	t.Cleanup(func() { sampleProbe = triggerStepProbe })
+}
+
+func newBaseline(samples int, maxFlakiness float64, probes ...string) ([]v1alpha1.ProbeAttributes, *types.ChaosDetails, *types.ResultDetails) {
+	var attributes []v1alpha1.ProbeAttributes
+	resultDetails := &types.ResultDetails{}
+	for _, name := range probes {
+		attributes = append(attributes, v1alpha1.ProbeAttributes{Name: name})
+		resultDetails.ProbeDetails = append(resultDetails.ProbeDetails, &types.ProbeDetails{Name: name})
+	}
+	chaosDetails := &types.ChaosDetails{Baseline: types.Baseline{Samples: samples, MaxFlakiness: maxFlakiness}}
+	return attributes, chaosDetails, resultDetails
+}
+
+func TestRunBaselineIsDisabled(t *testing.T) {
+	probes, chaosDetails, resultDetails := newBaseline(0, 0, "http")
+	fakeSamples(t, map[string][]error{"http": {errors.New("unreachable")}}, nil)
+
+	require.NoError(t, runBaseline(context.Background(), probes, chaosDetails, clients.ClientSets{}, resultDetails))
+	assert.Empty(t, chaosDetails.BaselineResults)
+	assert.Empty(t, resultDetails.ProbeDetails[0].Timeline)
+}
+
+func TestRunBaseline(t *testing.T) {
+	probes, chaosDetails, resultDetails := newBaseline(3, 0, "latency", "health", "cmd")
+	fakeSamples(t,
+		map[string][]error{"latency": {nil, nil, nil}, "health": {nil, nil, nil}},
+		map[string][]string{"latency": {"120", "80", "100"}},
+	)
+
+	require.NoError(t, runBaseline(context.Background(), probes, chaosDetails, clients.ClientSets{}, resultDetails))
+	assert.Equal(t, []types.BaselineResult{
+		{Probe: "latency", Samples: 3, Measured: 3, Min: 80, Max: 120, Mean: 100},
+		{Probe: "health", Samples: 3},
+	}, chaosDetails.BaselineResults)
+	assert.Equal(t, types.PreChaosPhase, chaosDetails.Phase)
+
+	require.Len(t, resultDetails.ProbeDetails[0].Timeline, 3)
+	assert.Equal(t, string(types.BaselinePhase), resultDetails.ProbeDetails[0].Timeline[0].Phase)
+	assert.Equal(t, "80", resultDetails.ProbeDetails[0].Timeline[1].MeasuredValue)
+	assert.Empty(t, resultDetails.ProbeDetails[2].Timeline, "unsupported probe shouldn't be sampled")
+}
+
+func TestRunBaselineToleratesFlakiness(t *testing.T) {
+	probes, chaosDetails, resultDetails := newBaseline(4, 25, "http")
+	fakeSamples(t, map[string][]error{"http": {nil, errors.New("timeout"), nil, nil}}, nil)
+
+	require.NoError(t, runBaseline(context.Background(), probes, chaosDetails, clients.ClientSets{}, resultDetails))
+	assert.Equal(t, []types.BaselineResult{{Probe: "http", Samples: 4, Failed: 1, Flakiness: 25}}, chaosDetails.BaselineResults)
+	assert.Equal(t, v1alpha1.ProbeVerdictFailed, resultDetails.ProbeDetails[0].Timeline[1].Verdict)
+}
+
+func TestRunBaselineIsFlaky(t *testing.T) {
+	probes, chaosDetails, resultDetails := newBaseline(4, 25, "http")
+	fakeSamples(t, map[string][]error{"http": {errors.New("timeout"), errors.New("timeout"), nil, nil}}, nil)
+
+	err := runBaseline(context.Background(), probes, chaosDetails, clients.ClientSets{}, resultDetails)
+	assert.Equal(t, cerrors.ErrorTypeSteadyState, cerrors.GetErrorType(err))
+	assert.ErrorContains(t, err, "2 of the 4 samples failed")
+
+	// it fails without waiting for the remaining samples
+	assert.Equal(t, []types.BaselineResult{{Probe: "http", Samples: 2, Failed: 2, Flakiness: 100}}, chaosDetails.BaselineResults)
+}
+
+func TestRunBaselineIsAborted(t *testing.T) {
+	probes, chaosDetails, resultDetails := newBaseline(3, 0, "http")
+	chaosDetails.Baseline.Interval = 60
+	fakeSamples(t, map[string][]error{"http": {nil, nil, nil}}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := runBaseline(ctx, probes, chaosDetails, clients.ClientSets{}, resultDetails)
+	assert.Equal(t, cerrors.ErrorTypeExperimentAborted, cerrors.GetErrorType(err))
+	assert.Equal(t, []types.BaselineResult{{Probe: "http", Samples: 1}}, chaosDetails.BaselineResults)
+}
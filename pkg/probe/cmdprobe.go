@@ -30,19 +30,19 @@ import (
 // cmd probe can be used to add the command probes
 // it can be of two types one: which need a source(an external image)
 // another: any inline command which can be run without source image, directly via go-runner image
-func prepareCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+func prepareCmdProbe(ctx context.Context, probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
 
 	switch strings.ToLower(phase) {
 	case "prechaos":
-		if err := preChaosCmdProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := preChaosCmdProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "postchaos":
-		if err := postChaosCmdProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := postChaosCmdProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "duringchaos":
-		if err := onChaosCmdProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := onChaosCmdProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	default:
@@ -81,6 +81,7 @@ func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.
 			}
 
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			setMeasuredValue(resultDetails, probe.Name, strings.TrimSpace(out.String()))
 			description, err = validateResult(probe.CmdProbeInputs.Comparator, probe.Name, probe.RunProperties.Verbosity, strings.TrimSpace(out.String()), rc)
 			if err != nil {
 				if strings.TrimSpace(stdErr.String()) != "" {
@@ -133,6 +134,7 @@ func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails li
 			}
 
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			setMeasuredValue(resultDetails, probe.Name, strings.TrimSpace(output))
 			if description, err = validateResult(probe.CmdProbeInputs.Comparator, probe.Name, probe.RunProperties.Verbosity, strings.TrimSpace(output), rc); err != nil {
 				if strings.TrimSpace(stdErr) != "" {
 					return cerrors.Error{
@@ -603,7 +605,7 @@ func validateResult(comparator v1alpha1.ComparatorInfo, probeName, probeVerbosit
 }
 
 // preChaosCmdProbe trigger the cmd probe for prechaos phase
-func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func preChaosCmdProbe(ctx context.Context, probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
 
 	switch probe.Mode {
@@ -639,7 +641,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			}
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := createHelperPod(ctx, probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -680,7 +682,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			go triggerInlineContinuousCmdProbe(probe, clients, resultDetails, chaosDetails)
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := createHelperPod(ctx, probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -694,7 +696,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 }
 
 // postChaosCmdProbe trigger cmd probe for post chaos phase
-func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func postChaosCmdProbe(ctx context.Context, probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
 
 	switch probe.Mode {
@@ -730,7 +732,7 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			}
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := createHelperPod(ctx, probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -787,7 +789,7 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 }
 
 // onChaosCmdProbe trigger the cmd probe for DuringChaos phase
-func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func onChaosCmdProbe(ctx context.Context, probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	switch probe.Mode {
 	case "OnChaos":
@@ -806,7 +808,7 @@ func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 			go triggerInlineOnChaosCmdProbe(probe, clients, resultDetails, chaosDetails)
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := createHelperPod(ctx, probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -820,7 +822,7 @@ func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 
 // createHelperPod create the helper pod with the source image
 // it will be created if the mode is not inline
-func createHelperPod(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (litmusexec.PodDetails, error) {
+func createHelperPod(ctx context.Context, probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (litmusexec.PodDetails, error) {
 	// Generate the run_id
 	runID := stringutils.GetRunID()
 	setRunIDForProbe(resultDetails, probe.Name, probe.Type, runID)
@@ -832,7 +834,7 @@ func createHelperPod(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 
 	// verify the running status of external probe pod
 	log.Info("[Status]: Checking the status of the probe pod")
-	if err = status.CheckApplicationStatusesByLabels(chaosDetails.ChaosNamespace, "name="+chaosDetails.ExperimentName+"-probe-"+runID, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
+	if err = status.CheckApplicationStatusesByLabels(ctx, chaosDetails.ChaosNamespace, "name="+chaosDetails.ExperimentName+"-probe-"+runID, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
 		return litmusexec.PodDetails{}, stacktrace.Propagate(err, "probe pod is not in running state")
 	}
 
@@ -2,6 +2,7 @@ package probe
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/litmuschaos/litmus-go/pkg/utils"
 	"os/exec"
@@ -19,6 +20,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/sirupsen/logrus"
@@ -123,6 +125,8 @@ func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails
 			}
 
 			code := strconv.Itoa(resp.StatusCode)
+			telemetry.RecordProbeValue(context.Background(), probe.Name, probe.Type, float64(resp.StatusCode))
+			setMeasuredValue(resultDetails, probe.Name, code)
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
 
 			// comparing the response code with the expected criteria
@@ -169,6 +173,8 @@ func httpPost(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails
 				return cerrors.Error{ErrorCode: cerrors.ErrorTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
 			}
 			code := strconv.Itoa(resp.StatusCode)
+			telemetry.RecordProbeValue(context.Background(), probe.Name, probe.Type, float64(resp.StatusCode))
+			setMeasuredValue(resultDetails, probe.Name, code)
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
 
 			// comparing the response code with the expected criteria
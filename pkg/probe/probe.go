@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyokomi/emoji"
@@ -18,11 +19,16 @@ import (
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var err error
 
+// timelineLock guards the timeline and the measured value of the probes,
+// as the onchaos and continuous probes are evaluated concurrently with the probes of the intensity steps
+var timelineLock sync.Mutex
+
 // RunProbes contains the steps to trigger the probes
 // It contains steps to trigger all three probes: k8sprobe, httpprobe, cmdprobe
 func RunProbes(ctx context.Context, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
@@ -38,19 +44,28 @@ func RunProbes(ctx context.Context, chaosDetails *types.ChaosDetails, clients cl
 	switch strings.ToLower(phase) {
 	//execute probes for the prechaos phase
 	case "prechaos":
+		// the steady state is established first, so that the flaky probes don't reach the chaos
+		if err := runBaseline(ctx, probes, chaosDetails, clients, resultDetails); err != nil {
+			return err
+		}
 		for _, probe := range probes {
 			switch strings.ToLower(probe.Mode) {
 			case "sot", "edge", "continuous":
-				if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
+				if err := execute(ctx, probe, chaosDetails, clients, resultDetails, phase); err != nil {
 					return err
 				}
 			}
 		}
 	//execute probes for the duringchaos phase
 	case "duringchaos":
+		// the onchaos probes are triggered only once, even if the chaos is injected multiple times (like in the intensity steps)
+		if chaosDetails.ProbeContext.OnChaosStarted {
+			return nil
+		}
+		chaosDetails.ProbeContext.OnChaosStarted = true
 		for _, probe := range probes {
 			if strings.ToLower(probe.Mode) == "onchaos" {
-				if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
+				if err := execute(ctx, probe, chaosDetails, clients, resultDetails, phase); err != nil {
 					return err
 				}
 			}
@@ -66,7 +81,7 @@ func RunProbes(ctx context.Context, chaosDetails *types.ChaosDetails, clients cl
 			// evaluate continuous and onchaos probes
 			switch strings.ToLower(probe.Mode) {
 			case "onchaos", "continuous":
-				if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
+				if err := execute(ctx, probe, chaosDetails, clients, resultDetails, phase); err != nil {
 					probeError = append(probeError, stacktrace.RootCause(err).Error())
 				}
 			}
@@ -78,7 +93,7 @@ func RunProbes(ctx context.Context, chaosDetails *types.ChaosDetails, clients cl
 		for _, probe := range probes {
 			switch strings.ToLower(probe.Mode) {
 			case "eot", "edge":
-				if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
+				if err := execute(ctx, probe, chaosDetails, clients, resultDetails, phase); err != nil {
 					return err
 				}
 			}
@@ -152,6 +167,18 @@ func getAndIncrementRunCount(resultDetails *types.ResultDetails, probeName strin
 	return 0
 }
 
+// setMeasuredValue records the last value measured by the probe
+func setMeasuredValue(resultDetails *types.ResultDetails, probeName, value string) {
+	for index, probe := range resultDetails.ProbeDetails {
+		if probeName == probe.Name {
+			timelineLock.Lock()
+			resultDetails.ProbeDetails[index].MeasuredValue = value
+			timelineLock.Unlock()
+			return
+		}
+	}
+}
+
 // getRunIDFromProbe return the run_id for the dedicated probe
 // which will used in the continuous cmd probe, run_id is used as suffix in the external pod name
 func getRunIDFromProbe(resultDetails *types.ResultDetails, probeName, probeType string) string {
@@ -212,6 +239,7 @@ func markedVerdictInEnd(err error, resultDetails *types.ResultDetails, probe v1a
 	}
 
 	setProbeVerdict(resultDetails, probe, probeVerdict, description, phase)
+	telemetry.RecordProbeEvaluation(context.Background(), probe.Name, probe.Type, probe.Mode, phase, string(probeVerdict))
 
 	if err != nil {
 		switch probe.RunProperties.StopOnFailure {
@@ -329,8 +357,46 @@ func stopChaosEngine(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 	return nil
 }
 
-// execute contains steps to execute & evaluate probes in different modes at different phases
-func execute(probe v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string) error {
+// execute traces the execution of the probe, along with its verdict and the measured value
+func execute(ctx context.Context, probe v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string) error {
+	_, span := telemetry.StartSpan(ctx, "ExecuteProbe",
+		attribute.String("probe.name", probe.Name),
+		attribute.String("probe.type", probe.Type),
+		attribute.String("probe.mode", probe.Mode),
+		attribute.String("probe.phase", phase),
+	)
+
+	err := evaluateProbe(ctx, probe, chaosDetails, clients, resultDetails, phase)
+	if probeDetails := getProbeByName(probe.Name, resultDetails.ProbeDetails); probeDetails != nil {
+		evaluation := recordEvaluation(probeDetails, phase, probeDetails.Status.Verdict)
+		span.SetAttributes(attribute.String("probe.verdict", string(evaluation.Verdict)))
+		if evaluation.MeasuredValue != "" {
+			span.SetAttributes(attribute.String("probe.measured_value", evaluation.MeasuredValue))
+		}
+	}
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+// evaluateProbe runs the probe in the given phase, it is replaced in the tests
+var evaluateProbe = executeProbe
+
+// recordEvaluation appends the evaluation of the probe to its timeline, along with the last measured value
+func recordEvaluation(probeDetails *types.ProbeDetails, phase string, verdict v1alpha1.ProbeVerdict) types.ProbeEvaluation {
+	timelineLock.Lock()
+	defer timelineLock.Unlock()
+	evaluation := types.ProbeEvaluation{
+		Phase:         phase,
+		Time:          time.Now(),
+		Verdict:       verdict,
+		MeasuredValue: probeDetails.MeasuredValue,
+	}
+	probeDetails.Timeline = append(probeDetails.Timeline, evaluation)
+	return evaluation
+}
+
+// executeProbe contains steps to execute & evaluate probes in different modes at different phases
+func executeProbe(ctx context.Context, probe v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string) error {
 	switch strings.ToLower(probe.Type) {
 	case "k8sprobe":
 		// it contains steps to prepare the k8s probe
@@ -339,7 +405,7 @@ func execute(probe v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, c
 		}
 	case "cmdprobe":
 		// it contains steps to prepare cmd probe
-		if err = prepareCmdProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+		if err = prepareCmdProbe(ctx, probe, clients, chaosDetails, resultDetails, phase); err != nil {
 			return stacktrace.Propagate(err, "probes failed")
 		}
 	case "httpprobe":
@@ -386,7 +452,12 @@ func getAttempts(attempt, retries int) int {
 	return attempt
 }
 
+// IsProbeFailed checks if the fail step denotes the failure of a probe
 func IsProbeFailed(reason string) bool {
+	if e, ok := cerrors.ParseError(reason); ok && e.ErrorCode.IsProbeFailure() {
+		return true
+	}
+	// the preserved errors contain the list of errors, which can't be parsed
 	if strings.Contains(reason, string(cerrors.FailureTypeK8sProbe)) || strings.Contains(reason, string(cerrors.FailureTypePromProbe)) ||
 		strings.Contains(reason, string(cerrors.FailureTypeCmdProbe)) || strings.Contains(reason, string(cerrors.FailureTypeHttpProbe)) {
 		return true
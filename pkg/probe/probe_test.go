@@ -0,0 +1,67 @@
+package probe
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/fake"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordEvaluationConcurrently(t *testing.T) {
+	probeDetails := &types.ProbeDetails{Name: "check-frontend"}
+	resultDetails := &types.ResultDetails{ProbeDetails: []*types.ProbeDetails{probeDetails}}
+
+	// the onchaos probe and the probes of the intensity steps are evaluated concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			setMeasuredValue(resultDetails, "check-frontend", strconv.Itoa(i))
+			recordEvaluation(probeDetails, "DuringChaos", v1alpha1.ProbeVerdictPassed)
+		}()
+		go func() {
+			defer wg.Done()
+			recordEvaluation(probeDetails, "step-1", v1alpha1.ProbeVerdictFailed)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, probeDetails.Timeline, 40)
+}
+
+func TestRunProbesStartsTheOnChaosProbesOnce(t *testing.T) {
+	engine := &v1alpha1.ChaosEngine{
+		ObjectMeta: v1.ObjectMeta{Name: "nginx-chaos", Namespace: "litmus"},
+		Spec: v1alpha1.ChaosEngineSpec{Experiments: []v1alpha1.ExperimentList{{
+			Name: "pod-network-latency",
+			Spec: v1alpha1.ExperimentAttributes{Probe: []v1alpha1.ProbeAttributes{
+				{Name: "check-frontend", Mode: "OnChaos"},
+				{Name: "check-backend", Mode: "Continuous"},
+			}},
+		}}},
+	}
+	clientSets := clients.ClientSets{LitmusClient: fake.NewSimpleClientset(engine).LitmuschaosV1alpha1()}
+	chaosDetails := &types.ChaosDetails{EngineName: "nginx-chaos", ChaosNamespace: "litmus", ExperimentName: "pod-network-latency", Timeout: 1, Delay: 1}
+
+	var started []string
+	evaluateProbe = func(_ context.Context, probe v1alpha1.ProbeAttributes, _ *types.ChaosDetails, _ clients.ClientSets, _ *types.ResultDetails, phase string) error {
+		started = append(started, probe.Name+"/"+phase)
+		return nil
+	}
+	t.Cleanup(func() { evaluateProbe = executeProbe })
+
+	// the chaos is injected multiple times in the intensity steps and in the batches of the rolling sequence
+	for i := 0; i < 3; i++ {
+		require.NoError(t, RunProbes(context.Background(), chaosDetails, clientSets, &types.ResultDetails{}, "DuringChaos", &types.EventDetails{}))
+	}
+	assert.Equal(t, []string{"check-frontend/DuringChaos"}, started)
+}
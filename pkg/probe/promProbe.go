@@ -2,8 +2,10 @@ package probe
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/sirupsen/logrus"
@@ -208,6 +211,10 @@ func triggerPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			if err != nil {
 				return err
 			}
+			setMeasuredValue(resultDetails, probe.Name, value)
+			if measured, err := strconv.ParseFloat(value, 64); err == nil {
+				telemetry.RecordProbeValue(context.Background(), probe.Name, probe.Type, measured)
+			}
 
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
 			// comparing the metrics output with the expected criteria
@@ -0,0 +1,75 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/palantir/stacktrace"
+)
+
+// RunStepProbes evaluates the onchaos and continuous probes once, during a step of the stepped intensity
+// the verdicts of the probes aren't changed, the outcome of the evaluation is only recorded in the timeline of the probes
+// it returns the error of the failed probes, which marks the step as not tolerated
+func RunStepProbes(ctx context.Context, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, step string) error {
+	probes, err := getProbesFromChaosEngine(chaosDetails, clients)
+	if err != nil {
+		return err
+	}
+
+	var probeError []string
+	for _, probe := range probes {
+		switch strings.ToLower(probe.Mode) {
+		case "onchaos", "continuous":
+		default:
+			continue
+		}
+
+		err := triggerStepProbe(probe, clients, resultDetails)
+		if err == errStepProbeNotSupported {
+			log.Infof("[Probe]: Skipping the %v probe at the %v, as it can't be evaluated once", probe.Name, step)
+			continue
+		}
+
+		verdict := v1alpha1.ProbeVerdictPassed
+		if err != nil {
+			verdict = v1alpha1.ProbeVerdictFailed
+			probeError = append(probeError, stacktrace.RootCause(err).Error())
+		}
+		if probeDetails := getProbeByName(probe.Name, resultDetails.ProbeDetails); probeDetails != nil {
+			recordEvaluation(probeDetails, step, verdict)
+		}
+	}
+
+	if len(probeError) != 0 {
+		return fmt.Errorf("[%s]", strings.Join(probeError, ","))
+	}
+	return nil
+}
+
+var errStepProbeNotSupported = fmt.Errorf("probe can't be evaluated in a step")
+
+// triggerStepProbe runs a single evaluation of the probe
+// the probes, which modify the resources or need a source pod, aren't evaluated in the steps
+func triggerStepProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	switch strings.ToLower(probe.Type) {
+	case "k8sprobe":
+		switch strings.ToLower(probe.K8sProbeInputs.Operation) {
+		case "present", "absent":
+			return triggerK8sProbe(probe, clients, resultDetails)
+		}
+	case "httpprobe":
+		return triggerHTTPProbe(probe, resultDetails)
+	case "promprobe":
+		return triggerPromProbe(probe, resultDetails)
+	case "cmdprobe":
+		if isInlineProbe(probe.CmdProbeInputs) {
+			return triggerInlineCmdProbe(probe, resultDetails)
+		}
+	}
+	return errStepProbeNotSupported
+}
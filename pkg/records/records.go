@@ -0,0 +1,262 @@
+package records
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/journal"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	retries "k8s.io/client-go/util/retry"
+)
+
+const (
+	// RevertSucceeded denotes the successful revert of the chaos on the target
+	RevertSucceeded = "Succeeded"
+	// RevertFailed denotes the failed revert of the chaos on the target
+	RevertFailed = "Failed"
+	// RevertNotApplicable denotes the chaos, which doesn't need a revert (like pod-delete)
+	RevertNotApplicable = "NotApplicable"
+)
+
+// timeout bounds the recording of a single injection or revert, including the retries on conflict
+// the records are best-effort, so a slow api server should not hold the injection or the revert
+var timeout = 10 * time.Second
+
+// Record contains the outcome of the chaos on a single target
+// The records are stored inside the companion configmap of the chaosresult, as the chaosresult targets
+// contain only the name, kind and status of the targets
+type Record struct {
+	ID           string            `json:"id"`
+	Experiment   string            `json:"experiment"`
+	Kind         string            `json:"kind"`
+	Name         string            `json:"name"`
+	Namespace    string            `json:"namespace,omitempty"`
+	Container    string            `json:"container,omitempty"`
+	Params       map[string]string `json:"params,omitempty"`
+	HelperPod    string            `json:"helperPod,omitempty"`
+	InjectedAt   string            `json:"injectedAt,omitempty"`
+	RevertedAt   string            `json:"revertedAt,omitempty"`
+	RevertResult string            `json:"revertResult,omitempty"`
+	RevertError  string            `json:"revertError,omitempty"`
+}
+
+// FromJournalEntry returns the record of the target of the journal entry
+// the journal entry already contains the target details and the effective fault parameters
+func FromJournalEntry(entry *journal.Entry) *Record {
+	record := &Record{
+		ID:         entry.ID,
+		Experiment: entry.Experiment,
+		Kind:       entry.Kind,
+		Name:       entry.Name,
+		Namespace:  entry.Namespace,
+		Container:  entry.Container,
+		Params:     map[string]string{},
+	}
+	for key, value := range entry.Params {
+		record.Params[key] = value
+	}
+	return record
+}
+
+// NewRecord returns the record of the given target
+func NewRecord(experiment, kind, name, namespace string) *Record {
+	return &Record{
+		ID:         journal.EntryID(kind, namespace, name, ""),
+		Experiment: experiment,
+		Kind:       kind,
+		Name:       name,
+		Namespace:  namespace,
+		Params:     map[string]string{},
+	}
+}
+
+// WithContainer sets the target container
+func (record *Record) WithContainer(container string) *Record {
+	record.Container = container
+	record.ID = journal.EntryID(record.Kind, record.Namespace, record.Name, container)
+	return record
+}
+
+// WithoutRevert marks the chaos as the one which doesn't need a revert
+func (record *Record) WithoutRevert() *Record {
+	record.RevertResult = RevertNotApplicable
+	return record
+}
+
+// WithParam sets the effective fault parameter of the injection
+func (record *Record) WithParam(key, value string) *Record {
+	record.Params[key] = value
+	return record
+}
+
+// Injected records the injection on the target, along with the chaos pod (helper or experiment pod) which injected it
+// The failures are only logged, as the records are informational and should not affect the experiment
+func Injected(record *Record, resultName string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	record.InjectedAt = time.Now().UTC().Format(time.RFC3339)
+	if chaosDetails.ChaosPodName != "" {
+		record.HelperPod = chaosDetails.ChaosNamespace + "/" + chaosDetails.ChaosPodName
+	}
+	if err := write(resultName, chaosDetails.ChaosNamespace, record, chaosDetails, clients); err != nil {
+		log.Warnf("[Records]: Unable to record the injection on %v target, err: %v", record.ID, err)
+	}
+}
+
+// Reverted records the revert of the chaos on the target, the revert error marks it as failed
+func Reverted(id, resultName string, revertErr error, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	err := update(getName(resultName), chaosDetails.ChaosNamespace, id, clients, func(record *Record) {
+		record.RevertedAt = time.Now().UTC().Format(time.RFC3339)
+		record.RevertResult = RevertSucceeded
+		record.RevertError = ""
+		if revertErr != nil {
+			record.RevertResult = RevertFailed
+			record.RevertError = revertErr.Error()
+		}
+	})
+	if err != nil {
+		log.Warnf("[Records]: Unable to record the revert on %v target, err: %v", id, err)
+	}
+}
+
+// List returns the records of all the targets of the chaosresult, sorted by the injection time
+func List(resultName, namespace string, clients clients.ClientSets) ([]Record, error) {
+	cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), getName(resultName), v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{configmap: %s, namespace: %s}", getName(resultName), namespace), Reason: err.Error()}
+	}
+
+	var records []Record
+	for key, data := range cm.Data {
+		var record Record
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			log.Warnf("[Records]: Skipping invalid record %v, err: %v", key, err)
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].InjectedAt == records[j].InjectedAt {
+			return records[i].ID < records[j].ID
+		}
+		return records[i].InjectedAt < records[j].InjectedAt
+	})
+	return records, nil
+}
+
+// write creates or updates the record inside the configmap
+// the configmap is owned by the chaosresult, so that it is garbage collected along with the chaosresult
+func write(resultName, namespace string, record *Record, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	name := getName(resultName)
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, v1.GetOptions{})
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			cm = &apiv1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels: map[string]string{
+						"chaosUID":                    string(chaosDetails.ChaosUID),
+						"app.kubernetes.io/part-of":   "litmus",
+						"app.kubernetes.io/component": "chaos-target-records",
+					},
+					OwnerReferences: getOwnerReferences(ctx, resultName, namespace, clients),
+				},
+				Data: map[string]string{record.ID: string(value)},
+			}
+			if _, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Create(ctx, cm, v1.CreateOptions{}); err != nil {
+				if k8serrors.IsAlreadyExists(err) {
+					return k8serrors.NewConflict(apiv1.Resource("configmaps"), name, err)
+				}
+				return err
+			}
+			return nil
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[record.ID] = string(value)
+		_, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Update(ctx, cm, v1.UpdateOptions{})
+		return err
+	})
+}
+
+// update applies the mutation on the existing record, it is a no-op if the record doesn't exist
+func update(name, namespace, id string, clients clients.ClientSets, mutate func(*Record)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, v1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		data, ok := cm.Data[id]
+		if !ok {
+			return nil
+		}
+
+		var record Record
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return err
+		}
+		mutate(&record)
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		cm.Data[id] = string(value)
+		_, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Update(ctx, cm, v1.UpdateOptions{})
+		return err
+	})
+}
+
+// getOwnerReferences returns the owner reference of the chaosresult
+// the configmap is created without the owner, if the chaosresult isn't found
+func getOwnerReferences(ctx context.Context, resultName, namespace string, clients clients.ClientSets) []v1.OwnerReference {
+	if clients.LitmusClient == nil {
+		return nil
+	}
+	chaosResult, err := clients.LitmusClient.ChaosResults(namespace).Get(ctx, resultName, v1.GetOptions{})
+	if err != nil {
+		log.Warnf("[Records]: Unable to get the %v chaosresult, the records aren't owned by it, err: %v", resultName, err)
+		return nil
+	}
+	return []v1.OwnerReference{{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       "ChaosResult",
+		Name:       chaosResult.Name,
+		UID:        chaosResult.UID,
+	}}
+}
+
+// getName returns the name of the companion configmap of the chaosresult
+func getName(resultName string) string {
+	return resultName + "-targets"
+}
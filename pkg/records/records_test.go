@@ -0,0 +1,101 @@
+package records
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	litmusFake "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/fake"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newClientSets() clients.ClientSets {
+	chaosResult := &v1alpha1.ChaosResult{ObjectMeta: v1.ObjectMeta{Name: "nginx-chaos-pod-delete", Namespace: "litmus", UID: "result-uid"}}
+	return clients.ClientSets{
+		KubeClient:   fake.NewSimpleClientset(),
+		LitmusClient: litmusFake.NewSimpleClientset(chaosResult).LitmuschaosV1alpha1(),
+	}
+}
+
+func TestInjectedAndReverted(t *testing.T) {
+	clientSets := newClientSets()
+	chaosDetails := &types.ChaosDetails{ChaosNamespace: "litmus", ChaosPodName: "nginx-helper", ChaosUID: "chaos-uid"}
+
+	Injected(NewRecord("pod-network-latency", "pod", "nginx-1", "default").WithContainer("nginx").WithParam("latency", "2000"), "nginx-chaos-pod-delete", chaosDetails, clientSets)
+	Injected(NewRecord("pod-network-latency", "pod", "nginx-2", "default").WithContainer("nginx"), "nginx-chaos-pod-delete", chaosDetails, clientSets)
+	Reverted(NewRecord("pod-network-latency", "pod", "nginx-1", "default").WithContainer("nginx").ID, "nginx-chaos-pod-delete", nil, chaosDetails, clientSets)
+	Reverted(NewRecord("pod-network-latency", "pod", "nginx-2", "default").WithContainer("nginx").ID, "nginx-chaos-pod-delete", errors.New("tc qdisc not found"), chaosDetails, clientSets)
+
+	cm, err := clientSets.KubeClient.CoreV1().ConfigMaps("litmus").Get(context.Background(), "nginx-chaos-pod-delete-targets", v1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, v1.OwnerReference{APIVersion: "litmuschaos.io/v1alpha1", Kind: "ChaosResult", Name: "nginx-chaos-pod-delete", UID: "result-uid"}, cm.OwnerReferences[0])
+	assert.Equal(t, "chaos-uid", cm.Labels["chaosUID"])
+
+	records, err := List("nginx-chaos-pod-delete", "litmus", clientSets)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, record := range records {
+		assert.Equal(t, "litmus/nginx-helper", record.HelperPod)
+		assert.NotEmpty(t, record.InjectedAt)
+		assert.NotEmpty(t, record.RevertedAt)
+		switch record.Name {
+		case "nginx-1":
+			assert.Equal(t, RevertSucceeded, record.RevertResult)
+			assert.Equal(t, map[string]string{"latency": "2000"}, record.Params)
+		case "nginx-2":
+			assert.Equal(t, RevertFailed, record.RevertResult)
+			assert.Equal(t, "tc qdisc not found", record.RevertError)
+		}
+	}
+}
+
+func TestRevertedWithoutRecord(t *testing.T) {
+	clientSets := newClientSets()
+	chaosDetails := &types.ChaosDetails{ChaosNamespace: "litmus"}
+
+	Reverted("pod/default/nginx-1", "nginx-chaos-pod-delete", nil, chaosDetails, clientSets)
+
+	records, err := List("nginx-chaos-pod-delete", "litmus", clientSets)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestInjectedWithoutChaosResult(t *testing.T) {
+	clientSets := clients.ClientSets{KubeClient: fake.NewSimpleClientset(), LitmusClient: litmusFake.NewSimpleClientset().LitmuschaosV1alpha1()}
+
+	Injected(NewRecord("pod-delete", "pod", "nginx-1", "default").WithoutRevert(), "nginx-chaos-pod-delete", &types.ChaosDetails{ChaosNamespace: "litmus"}, clientSets)
+
+	cm, err := clientSets.KubeClient.CoreV1().ConfigMaps("litmus").Get(context.Background(), "nginx-chaos-pod-delete-targets", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, cm.OwnerReferences)
+	assert.Len(t, cm.Data, 1)
+}
+
+func TestInjectedConcurrently(t *testing.T) {
+	clientSets := newClientSets()
+	chaosDetails := &types.ChaosDetails{ChaosNamespace: "litmus"}
+
+	// the helper pods record the injections on their targets concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Injected(NewRecord("pod-cpu-hog", "pod", fmt.Sprintf("nginx-%d", i), "default"), "nginx-chaos-pod-delete", chaosDetails, clientSets)
+		}()
+	}
+	wg.Wait()
+
+	records, err := List("nginx-chaos-pod-delete", "litmus", clientSets)
+	require.NoError(t, err)
+	assert.Len(t, records, 20)
+}
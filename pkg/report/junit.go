@@ -0,0 +1,159 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+)
+
+// junitTestSuites is the root element of the junit report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite contains the test cases of the experiment run
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitTestCase represents a probe or the experiment itself
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// JUnit returns the report as junit xml
+// every probe is reported as a test case, along with a test case for the experiment verdict,
+// so that the pipelines can gate on the chaos results
+func (report *Report) JUnit() ([]byte, error) {
+	duration := "0"
+	timestamp := ""
+	if report.StartTime != nil {
+		duration = fmt.Sprintf("%.3f", report.EndTime.Sub(*report.StartTime).Seconds())
+		timestamp = report.StartTime.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	suite := junitTestSuite{
+		Name:      report.Experiment,
+		Time:      duration,
+		Timestamp: timestamp,
+		Properties: []junitProperty{
+			{Name: "engine", Value: report.Engine},
+			{Name: "namespace", Value: report.Namespace},
+			{Name: "runID", Value: report.RunID},
+			{Name: "verdict", Value: report.Verdict},
+		},
+	}
+
+	// test case for the experiment verdict
+	experiment := junitTestCase{Name: report.Experiment, ClassName: "experiment", Time: duration}
+	switch v1alpha1.ResultVerdict(report.Verdict) {
+	case v1alpha1.ResultVerdictPassed:
+	case v1alpha1.ResultVerdictFailed:
+		experiment.Failure = &junitMessage{Message: "experiment failed", Type: report.ErrorCode, Body: report.FailStep}
+	case v1alpha1.ResultVerdictStopped:
+		experiment.Skipped = &junitMessage{Message: "experiment stopped", Body: report.FailStep}
+	default:
+		experiment.Error = &junitMessage{Message: fmt.Sprintf("experiment verdict: %s", report.Verdict), Type: report.ErrorCode, Body: report.FailStep}
+	}
+	suite.TestCases = append(suite.TestCases, experiment)
+
+	for _, probe := range report.Probes {
+		testCase := junitTestCase{
+			Name:      probe.Name,
+			ClassName: fmt.Sprintf("%s.%s", report.Experiment, probe.Type),
+			Time:      probeDuration(probe),
+			SystemOut: probeTimeline(probe),
+		}
+		switch v1alpha1.ProbeVerdict(probe.Verdict) {
+		case v1alpha1.ProbeVerdictPassed:
+		case v1alpha1.ProbeVerdictFailed:
+			testCase.Failure = &junitMessage{Message: "probe failed", Type: probe.Mode, Body: probe.Description}
+		default:
+			testCase.Skipped = &junitMessage{Message: fmt.Sprintf("probe verdict: %s", probe.Verdict), Body: probe.Description}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for _, testCase := range suite.TestCases {
+		suite.Tests++
+		switch {
+		case testCase.Failure != nil:
+			suite.Failures++
+		case testCase.Error != nil:
+			suite.Errors++
+		case testCase.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+
+	suites := junitTestSuites{
+		Name:     report.Experiment,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// probeDuration returns the time between the first and the last evaluation of the probe
+func probeDuration(probe Probe) string {
+	if len(probe.Timeline) < 2 {
+		return "0"
+	}
+	return fmt.Sprintf("%.3f", probe.Timeline[len(probe.Timeline)-1].Time.Sub(probe.Timeline[0].Time).Seconds())
+}
+
+// probeTimeline returns the evaluations of the probe, one per line
+func probeTimeline(probe Probe) string {
+	var lines []string
+	for _, e := range probe.Timeline {
+		line := fmt.Sprintf("%s %s: %s", e.Time.UTC().Format("2006-01-02T15:04:05Z"), e.Phase, e.Verdict)
+		if e.MeasuredValue != "" {
+			line += fmt.Sprintf(" (measured value: %s)", e.MeasuredValue)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
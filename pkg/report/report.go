@@ -0,0 +1,375 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/records"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	retries "k8s.io/client-go/util/retry"
+)
+
+const (
+	// FormatsEnv contains the comma separated formats of the report, it supports json and junit
+	// the report is not generated if it is empty
+	FormatsEnv = "RUN_REPORT_FORMATS"
+	// PathEnv is the directory in which the report files are written
+	PathEnv = "RUN_REPORT_PATH"
+	// ConfigMapEnv is the name of the configmap, inside the chaos namespace, in which the report is written
+	ConfigMapEnv = "RUN_REPORT_CONFIGMAP"
+
+	// FormatJSON writes the report as json
+	FormatJSON = "json"
+	// FormatJUnit writes the report as junit xml, with each probe as a test case
+	FormatJUnit = "junit"
+
+	defaultPath = "/tmp/chaos-report"
+)
+
+// Report contains the summary of the experiment run
+type Report struct {
+	Experiment string           `json:"experiment"`
+	Engine     string           `json:"engine,omitempty"`
+	Namespace  string           `json:"namespace"`
+	RunID      string           `json:"runID"`
+	Result     string           `json:"result"`
+	StartTime  *time.Time       `json:"startTime,omitempty"`
+	EndTime    time.Time        `json:"endTime"`
+	Config     Config           `json:"config"`
+	Targets    []Target         `json:"targets"`
+	Phases     []Phase          `json:"phases"`
+	Probes     []Probe          `json:"probes"`
+	Steps      []Step           `json:"steps,omitempty"`
+	Faults     []Fault          `json:"faults,omitempty"`
+	Baseline   []Baseline       `json:"baseline,omitempty"`
+	Records    []records.Record `json:"records,omitempty"`
+	Phase      string           `json:"phase"`
+	Verdict    string           `json:"verdict"`
+	FailStep   string           `json:"failStep,omitempty"`
+	ErrorCode  string           `json:"errorCode,omitempty"`
+	Error      *cerrors.Error   `json:"error,omitempty"`
+}
+
+// Config contains the chaos parameters of the run
+type Config struct {
+	ChaosDuration      int           `json:"chaosDuration"`
+	Timeout            int           `json:"timeout"`
+	Delay              int           `json:"delay"`
+	Randomness         bool          `json:"randomness"`
+	DefaultHealthCheck bool          `json:"defaultHealthCheck"`
+	Sampling           string        `json:"sampling,omitempty"`
+	Seed               int64         `json:"seed"`
+	Applications       []Application `json:"applications,omitempty"`
+}
+
+// Application contains the details of the applications under chaos
+type Application struct {
+	Namespace string   `json:"namespace,omitempty"`
+	Kind      string   `json:"kind,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Names     []string `json:"names,omitempty"`
+}
+
+// Target contains the details of the target resolved by the experiment
+type Target struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Status string `json:"status"`
+}
+
+// Phase contains the time spent by the experiment in a phase
+type Phase struct {
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Duration  string    `json:"duration"`
+}
+
+// Step contains the outcome of a step of the stepped intensity
+type Step struct {
+	Index     int    `json:"index"`
+	Parameter string `json:"parameter"`
+	Value     string `json:"value"`
+	Tolerated bool   `json:"tolerated"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Fault contains the outcome of a fault of the scenario
+type Fault struct {
+	Name        string `json:"name"`
+	Fault       string `json:"fault"`
+	StartOffset int    `json:"startOffset"`
+	Duration    int    `json:"duration"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Baseline contains the steady state of a probe, sampled before the chaos
+type Baseline struct {
+	Probe     string     `json:"probe"`
+	Samples   int        `json:"samples"`
+	Failed    int        `json:"failed"`
+	Flakiness float64    `json:"flakiness"`
+	Measured  *Statistic `json:"measured,omitempty"`
+}
+
+// Statistic contains the statistics of the numeric values measured by the probe
+type Statistic struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Mean  float64 `json:"mean"`
+}
+
+// Probe contains the outcome of the probe along with its evaluations
+type Probe struct {
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	Mode        string       `json:"mode"`
+	Verdict     string       `json:"verdict"`
+	Description string       `json:"description,omitempty"`
+	Timeline    []Evaluation `json:"timeline,omitempty"`
+}
+
+// Evaluation contains the outcome of a single execution of the probe
+type Evaluation struct {
+	Phase         string    `json:"phase"`
+	Time          time.Time `json:"time"`
+	Verdict       string    `json:"verdict"`
+	MeasuredValue string    `json:"measuredValue,omitempty"`
+}
+
+// Generate builds the report of the run from the chaos and result details
+func Generate(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) *Report {
+	report := &Report{
+		Experiment: chaosDetails.ExperimentName,
+		Engine:     chaosDetails.EngineName,
+		Namespace:  chaosDetails.ChaosNamespace,
+		RunID:      string(chaosDetails.ChaosUID),
+		Result:     resultDetails.Name,
+		EndTime:    time.Now(),
+		Config: Config{
+			ChaosDuration:      chaosDetails.ChaosDuration,
+			Timeout:            chaosDetails.Timeout,
+			Delay:              chaosDetails.Delay,
+			Randomness:         chaosDetails.Randomness,
+			DefaultHealthCheck: chaosDetails.DefaultHealthCheck,
+			Sampling:           chaosDetails.Sampling.GetStrategy(),
+			Seed:               chaosDetails.Seed,
+		},
+		Targets: []Target{},
+		Phases:  []Phase{},
+		Probes:  []Probe{},
+		Phase:   string(resultDetails.Phase),
+		Verdict: string(resultDetails.Verdict),
+	}
+
+	for _, app := range chaosDetails.AppDetail {
+		report.Config.Applications = append(report.Config.Applications, Application{Namespace: app.Namespace, Kind: app.Kind, Labels: app.Labels, Names: app.Names})
+	}
+
+	for _, t := range chaosDetails.Targets {
+		report.Targets = append(report.Targets, Target{Name: t.Name, Kind: t.Kind, Status: t.ChaosStatus})
+	}
+
+	for _, p := range chaosDetails.PhaseTimeline {
+		report.Phases = append(report.Phases, Phase{
+			Name:      string(p.Phase),
+			StartTime: p.StartTime,
+			EndTime:   p.EndTime,
+			Duration:  p.EndTime.Sub(p.StartTime).Round(time.Millisecond).String(),
+		})
+	}
+	if len(report.Phases) != 0 {
+		report.StartTime = &report.Phases[0].StartTime
+	}
+
+	for _, s := range chaosDetails.StepResults {
+		report.Steps = append(report.Steps, Step{Index: s.Index, Parameter: s.Parameter, Value: s.Value, Tolerated: s.Tolerated, Reason: s.Reason})
+	}
+
+	for _, f := range chaosDetails.FaultResults {
+		report.Faults = append(report.Faults, Fault{Name: f.Name, Fault: f.Fault, StartOffset: f.StartOffset, Duration: f.Duration, Status: f.Status, Reason: f.Reason})
+	}
+
+	for _, b := range chaosDetails.BaselineResults {
+		baseline := Baseline{Probe: b.Probe, Samples: b.Samples, Failed: b.Failed, Flakiness: b.Flakiness}
+		if b.Measured > 0 {
+			baseline.Measured = &Statistic{Count: b.Measured, Min: b.Min, Max: b.Max, Mean: b.Mean}
+		}
+		report.Baseline = append(report.Baseline, baseline)
+	}
+
+	for _, p := range resultDetails.ProbeDetails {
+		probe := Probe{
+			Name:        p.Name,
+			Type:        p.Type,
+			Mode:        p.Mode,
+			Verdict:     string(p.Status.Verdict),
+			Description: p.Status.Description,
+		}
+		for _, e := range p.Timeline {
+			probe.Timeline = append(probe.Timeline, Evaluation{
+				Phase:         e.Phase,
+				Time:          e.Time,
+				Verdict:       string(e.Verdict),
+				MeasuredValue: e.MeasuredValue,
+			})
+		}
+		report.Probes = append(report.Probes, probe)
+	}
+
+	if resultDetails.ErrorOutput != nil {
+		report.FailStep = resultDetails.ErrorOutput.Reason
+		report.ErrorCode = resultDetails.ErrorOutput.ErrorCode
+		// the fail step contains the json encoded error, if the error is user-friendly
+		var structuredErr cerrors.Error
+		if err := json.Unmarshal([]byte(report.FailStep), &structuredErr); err == nil {
+			report.Error = &structuredErr
+		}
+	}
+	return report
+}
+
+// Write generates the report of the run and writes it in all the requested formats & destinations
+// The failures are only logged, as the report should not affect the verdict of the experiment
+func Write(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, clients clients.ClientSets) {
+	formats := getFormats()
+	if len(formats) == 0 {
+		return
+	}
+
+	report := Generate(chaosDetails, resultDetails)
+	targetRecords, err := records.List(resultDetails.Name, chaosDetails.ChaosNamespace, clients)
+	if err != nil {
+		log.Warnf("[Report]: Unable to get the target records, err: %v", err)
+	}
+	report.Records = targetRecords
+	files := map[string][]byte{}
+	for _, format := range formats {
+		var (
+			data []byte
+			err  error
+			name string
+		)
+		switch format {
+		case FormatJSON:
+			name = "report.json"
+			data, err = report.JSON()
+		case FormatJUnit:
+			name = "junit.xml"
+			data, err = report.JUnit()
+		default:
+			log.Warnf("[Report]: Unsupported report format: %v", format)
+			continue
+		}
+		if err != nil {
+			log.Errorf("[Report]: Unable to generate the %v report, err: %v", format, err)
+			continue
+		}
+		files[name] = data
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	if configMap := os.Getenv(ConfigMapEnv); configMap != "" {
+		if err := writeConfigMap(configMap, chaosDetails.ChaosNamespace, files, clients); err != nil {
+			log.Errorf("[Report]: Unable to write the report to %v configmap, err: %v", configMap, err)
+		} else {
+			log.Infof("[Report]: Report written to %v configmap", configMap)
+		}
+		// the report is written to the files only if the path is explicitly provided
+		if os.Getenv(PathEnv) == "" {
+			return
+		}
+	}
+
+	dir := types.Getenv(PathEnv, defaultPath)
+	if err := writeFiles(dir, resultDetails.Name, files); err != nil {
+		log.Errorf("[Report]: Unable to write the report to %v, err: %v", dir, err)
+		return
+	}
+	log.Infof("[Report]: Report written to %v", dir)
+}
+
+// JSON returns the json encoded report
+func (report *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// writeFiles writes the report files inside the given directory, prefixed with the result name
+func writeFiles(dir, prefix string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, prefix+"-"+name), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeConfigMap creates or updates the configmap with the report files
+func writeConfigMap(name, namespace string, files map[string][]byte, clients clients.ClientSets) error {
+	data := map[string]string{}
+	for key, value := range files {
+		data[key] = string(value)
+	}
+
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, v1.GetOptions{})
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{configmap: %s, namespace: %s}", name, namespace), Reason: err.Error()}
+			}
+			cm = &apiv1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    map[string]string{"app.kubernetes.io/component": "chaos-report"},
+				},
+				Data: data,
+			}
+			if _, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Create(context.Background(), cm, v1.CreateOptions{}); err != nil {
+				if k8serrors.IsAlreadyExists(err) {
+					return k8serrors.NewConflict(apiv1.Resource("configmaps"), name, err)
+				}
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{configmap: %s, namespace: %s}", name, namespace), Reason: err.Error()}
+			}
+			return nil
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		for key, value := range data {
+			cm.Data[key] = value
+		}
+		_, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Update(context.Background(), cm, v1.UpdateOptions{})
+		return err
+	})
+}
+
+// getFormats returns the requested report formats
+func getFormats() []string {
+	var formats []string
+	for _, format := range strings.Split(os.Getenv(FormatsEnv), ",") {
+		if format = strings.ToLower(strings.TrimSpace(format)); format != "" {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
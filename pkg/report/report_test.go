@@ -0,0 +1,133 @@
+package report
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/records"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// testRun returns the chaos and result details of a failed run, with all the sections of the report populated
+func testRun() (*types.ChaosDetails, *types.ResultDetails) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	chaosDetails := &types.ChaosDetails{
+		ExperimentName:     "pod-network-latency",
+		EngineName:         "nginx-chaos",
+		ChaosNamespace:     "litmus",
+		ChaosUID:           "3f2c1a9e-8d7b-4c6a-9e5f-1b2c3d4e5f60",
+		ChaosDuration:      60,
+		Timeout:            180,
+		Delay:              2,
+		DefaultHealthCheck: true,
+		Sampling:           types.Sampling{Strategy: types.SamplingPerWorkload, MaxPerWorkload: 1},
+		Seed:               42,
+		AppDetail: []types.AppDetails{
+			{Namespace: "default", Kind: "deployment", Labels: []string{"app=nginx"}},
+		},
+		Targets: []v1alpha1.TargetDetails{
+			{Name: "nginx-7d9c8b6f5-x2k4p", Kind: "pod", ChaosStatus: "injected"},
+		},
+		PhaseTimeline: []types.PhaseRecord{
+			{Phase: types.BaselinePhase, StartTime: at(0), EndTime: at(10)},
+			{Phase: types.PreChaosPhase, StartTime: at(10), EndTime: at(12)},
+			{Phase: types.ChaosInjectPhase, StartTime: at(12), EndTime: at(92)},
+		},
+		StepResults: []types.StepResult{
+			{Index: 0, Parameter: "NETWORK_LATENCY", Value: "100", Tolerated: true},
+			{Index: 1, Parameter: "NETWORK_LATENCY", Value: "500", Reason: "check-frontend probe failed"},
+		},
+		FaultResults: []types.FaultResult{
+			{Name: "latency", Fault: "pod-network-latency", StartOffset: 0, Duration: 60, Status: "Completed"},
+			{Name: "loss", Fault: "pod-network-loss", StartOffset: 30, Duration: 30, Status: "Failed", Reason: "unable to inject the network loss"},
+		},
+		BaselineResults: []types.BaselineResult{
+			{Probe: "check-frontend", Samples: 5, Failed: 1, Flakiness: 20, Measured: 4, Min: 12.5, Max: 40, Mean: 21.25},
+			{Probe: "check-pods", Samples: 5},
+		},
+	}
+
+	resultDetails := &types.ResultDetails{
+		Name:    "nginx-chaos-pod-network-latency",
+		Phase:   v1alpha1.ResultPhaseCompleted,
+		Verdict: v1alpha1.ResultVerdictFailed,
+		ProbeDetails: []*types.ProbeDetails{
+			{
+				Name:   "check-frontend",
+				Type:   "httpProbe",
+				Mode:   "Continuous",
+				Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictFailed, Description: "the response time exceeded the threshold"},
+				Timeline: []types.ProbeEvaluation{
+					{Phase: "PreChaos", Time: at(11), Verdict: v1alpha1.ProbeVerdictPassed, MeasuredValue: "18"},
+					{Phase: "DuringChaos", Time: at(50), Verdict: v1alpha1.ProbeVerdictFailed, MeasuredValue: "640"},
+				},
+			},
+			{
+				Name:   "check-pods",
+				Type:   "k8sProbe",
+				Mode:   "SOT",
+				Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictPassed},
+				Timeline: []types.ProbeEvaluation{
+					{Phase: "PreChaos", Time: at(11), Verdict: v1alpha1.ProbeVerdictPassed},
+				},
+			},
+		},
+		ErrorOutput: &v1alpha1.ErrorOutput{
+			ErrorCode: string(cerrors.ErrorTypeChaosInject),
+			Reason:    cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosInject, Target: "{podName: nginx-7d9c8b6f5-x2k4p, namespace: default}", Reason: "unable to inject the network loss"}.Error(),
+		},
+	}
+	return chaosDetails, resultDetails
+}
+
+// assertGolden compares the data with the golden file, the golden file is rewritten if the update flag is set
+func assertGolden(t *testing.T, name string, data []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0755))
+		require.NoError(t, os.WriteFile(path, data, 0644))
+	}
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(data))
+}
+
+func TestGenerate(t *testing.T) {
+	chaosDetails, resultDetails := testRun()
+	report := Generate(chaosDetails, resultDetails)
+	report.EndTime = time.Date(2024, 3, 1, 10, 1, 40, 0, time.UTC)
+	report.Records = []records.Record{
+		{
+			ID:           "nginx-chaos-pod-network-latency-x2k4p",
+			Experiment:   "pod-network-latency",
+			Kind:         "pod",
+			Name:         "nginx-7d9c8b6f5-x2k4p",
+			Namespace:    "default",
+			Container:    "nginx",
+			Params:       map[string]string{"latency": "500", "jitter": "0"},
+			HelperPod:    "pod-network-latency-helper-abcde",
+			InjectedAt:   "2024-03-01T10:00:12Z",
+			RevertedAt:   "2024-03-01T10:01:32Z",
+			RevertResult: "reverted",
+		},
+	}
+
+	data, err := report.JSON()
+	require.NoError(t, err)
+	assertGolden(t, "report.golden.json", data)
+
+	data, err = report.JUnit()
+	require.NoError(t, err)
+	assertGolden(t, "junit.golden.xml", data)
+}
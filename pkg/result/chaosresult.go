@@ -1,10 +1,9 @@
 package result
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 	"time"
@@ -18,10 +17,14 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/report"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	retries "k8s.io/client-go/util/retry"
 )
 
 // ChaosResult Create and Update the chaos result
@@ -71,7 +74,24 @@ func ChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, r
 	if resultDetails.Phase == v1alpha1.ResultPhaseRunning {
 		resultDetails.Phase = v1alpha1.ResultPhaseCompleted
 	}
-	return PatchChaosResult(clients, chaosDetails, resultDetails, experimentLabel)
+	recordMetricsAtEOT(chaosDetails, resultDetails)
+	if err := PatchChaosResult(clients, chaosDetails, resultDetails, experimentLabel); err != nil {
+		return err
+	}
+	// the report is written after the patch, as the verdict is finalised while patching the result
+	report.Write(chaosDetails, resultDetails, clients)
+	return nil
+}
+
+// recordMetricsAtEOT records the phase durations and the verdict of the experiment
+func recordMetricsAtEOT(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) {
+	types.EndExperimentPhase(chaosDetails)
+	for phase, duration := range chaosDetails.PhaseDurations {
+		telemetry.RecordPhaseDuration(context.Background(), string(phase), duration)
+	}
+	// the durations are recorded only once, even if the result is patched again
+	chaosDetails.PhaseDurations = map[types.ExperimentPhase]time.Duration{}
+	telemetry.RecordVerdict(context.Background(), string(resultDetails.Verdict))
 }
 
 // InitializeChaosResult create the chaos result
@@ -150,6 +170,15 @@ func GetProbeStatus(resultDetails *types.ResultDetails) (bool, bool, []v1alpha1.
 	return isAllProbePassed, experimentStopped, probeStatus
 }
 
+const (
+	// SamplingStrategyAnnotation records the strategy used to sample the targets, in the chaosresult
+	SamplingStrategyAnnotation = "litmuschaos.io/sampling-strategy"
+	// RandomSeedAnnotation records the seed of the random decisions, which can be used to replay the run
+	RandomSeedAnnotation = "litmuschaos.io/random-seed"
+	// HighestToleratedStepAnnotation records the highest step of the stepped intensity, which was tolerated by the system
+	HighestToleratedStepAnnotation = "litmuschaos.io/highest-tolerated-step"
+)
+
 func updateResultAttributes(clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, chaosResultLabel map[string]string) (*v1alpha1.ChaosResult, error) {
 	result, err := GetChaosStatus(resultDetails, chaosDetails, clients)
 	if err != nil {
@@ -166,6 +195,21 @@ func updateResultAttributes(clients clients.ClientSets, chaosDetails *types.Chao
 
 	// for existing chaos result resource it will patch the label
 	result.ObjectMeta.Labels = chaosResultLabel
+	if result.Annotations == nil {
+		result.Annotations = map[string]string{}
+	}
+	if chaosDetails.Sampling.Strategy != "" {
+		result.Annotations[SamplingStrategyAnnotation] = chaosDetails.Sampling.GetStrategy()
+	}
+	if chaosDetails.Random != nil {
+		result.Annotations[RandomSeedAnnotation] = strconv.FormatInt(chaosDetails.Seed, 10)
+	}
+	if len(chaosDetails.StepResults) != 0 {
+		result.Annotations[HighestToleratedStepAnnotation] = "none"
+		if step := chaosDetails.GetHighestToleratedStep(); step != nil {
+			result.Annotations[HighestToleratedStepAnnotation] = step.Parameter + "=" + step.Value
+		}
+	}
 	result.Status.History.Targets = chaosDetails.Targets
 	isAllProbePassed, experimentStopped, result.Status.ProbeStatuses = GetProbeStatus(resultDetails)
 	result.Status.ExperimentStatus.Verdict = resultDetails.Verdict
@@ -259,6 +303,11 @@ func RecordAfterFailure(chaosDetails *types.ChaosDetails, resultDetails *types.R
 		phase = v1alpha1.ResultPhaseCompleted
 		verdict = v1alpha1.ResultVerdictFailed
 	}
+	classification := cerrors.Classify(err)
+	log.Infof("[Error]: category: %v, retryable: %v", classification.Category, classification.Retryable)
+	if classification.Remediation != "" {
+		log.Infof("[Remediation]: %v", classification.Remediation)
+	}
 	// update the chaos result
 	types.SetResultAfterCompletion(resultDetails, verdict, phase, failStep, errorCode)
 	if err := ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
@@ -295,19 +344,58 @@ func updateHistory(result *v1alpha1.ChaosResult) {
 }
 
 // AnnotateChaosResult annotate the chaosResult for the chaos status
-// using kubectl cli to annotate the chaosresult as it will automatically handle the race condition in case of multiple helpers
-func AnnotateChaosResult(resultName, namespace, status, kind, name string) error {
-	command := exec.Command("kubectl", "annotate", "chaosresult", resultName, "-n", namespace, kind+"/"+name+"="+status, "--overwrite")
-	var out, stderr bytes.Buffer
-	command.Stdout = &out
-	command.Stderr = &stderr
-	if err := command.Run(); err != nil {
-		log.Infof("Error String: %v", stderr.String())
-		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosResultCRUD, Target: fmt.Sprintf("{name: %s, namespace: %s}", resultName, namespace), Reason: out.String()}
+// every target owns a distinct annotation key, so the annotation is set by a plain merge patch,
+// which doesn't conflict with the concurrent patches from the other helpers
+func AnnotateChaosResult(resultName, namespace, status, kind, name string, clients clients.ClientSets) error {
+	patch, err := getAnnotationPatch(kind+"/"+name, status)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosResultCRUD, Target: fmt.Sprintf("{name: %s, namespace: %s}", resultName, namespace), Reason: err.Error()}
+	}
+	err = retries.OnError(retries.DefaultBackoff, isRetriableAnnotationError, func() error {
+		_, err := clients.LitmusClient.ChaosResults(namespace).Patch(context.Background(), resultName, k8stypes.MergePatchType, patch, v1.PatchOptions{})
+		return err
+	})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosResultCRUD, Target: fmt.Sprintf("{name: %s, namespace: %s}", resultName, namespace), Reason: err.Error()}
 	}
+	telemetry.RecordTarget(context.Background(), kind, status)
 	return nil
 }
 
+// UpdateResultPhase updates the phase of the chaosresult, while the experiment is in progress
+// it is used to surface the waits of the run, like the approval gates, without patching the other attributes
+func UpdateResultPhase(resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	err := retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		result, err := clients.LitmusClient.ChaosResults(chaosDetails.ChaosNamespace).Get(context.Background(), resultDetails.Name, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		result.Status.ExperimentStatus.Phase = resultDetails.Phase
+		_, err = clients.LitmusClient.ChaosResults(chaosDetails.ChaosNamespace).Update(context.Background(), result, v1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosResultCRUD, Target: fmt.Sprintf("{name: %s, namespace: %s}", resultDetails.Name, chaosDetails.ChaosNamespace), Reason: err.Error()}
+	}
+	return nil
+}
+
+// getAnnotationPatch returns the merge patch, which sets the given annotation
+func getAnnotationPatch(key, value string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				key: value,
+			},
+		},
+	})
+}
+
+// isRetriableAnnotationError returns true for the conflicts and the transient api server errors
+func isRetriableAnnotationError(err error) bool {
+	return k8serrors.IsConflict(err) || k8serrors.IsServerTimeout(err) || k8serrors.IsTimeout(err) || k8serrors.IsTooManyRequests(err)
+}
+
 // GetChaosStatus get the chaos status based on annotations in chaosresult
 func GetChaosStatus(resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, clients clients.ClientSets) (*v1alpha1.ChaosResult, error) {
 
@@ -0,0 +1,95 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/fake"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newChaosResult() *v1alpha1.ChaosResult {
+	return &v1alpha1.ChaosResult{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            "nginx-chaos-pod-delete",
+			Namespace:       "litmus",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{"pod/nginx-1": "injected"},
+		},
+	}
+}
+
+func TestAnnotateChaosResult(t *testing.T) {
+	litmusClient := fake.NewSimpleClientset(newChaosResult())
+	clientSets := clients.ClientSets{LitmusClient: litmusClient.LitmuschaosV1alpha1()}
+
+	require.NoError(t, AnnotateChaosResult("nginx-chaos-pod-delete", "litmus", "injected", "pod", "nginx-2", clientSets))
+	require.NoError(t, AnnotateChaosResult("nginx-chaos-pod-delete", "litmus", "reverted", "pod", "nginx-1", clientSets))
+
+	result, err := litmusClient.LitmuschaosV1alpha1().ChaosResults("litmus").Get(context.Background(), "nginx-chaos-pod-delete", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pod/nginx-1": "reverted", "pod/nginx-2": "injected"}, result.Annotations)
+}
+
+func TestAnnotateChaosResultRetriesOnConflict(t *testing.T) {
+	litmusClient := fake.NewSimpleClientset(newChaosResult())
+	conflicts := 2
+	litmusClient.PrependReactor("patch", "chaosresults", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			conflicts--
+			return true, nil, k8serrors.NewConflict(schema.GroupResource{Group: "litmuschaos.io", Resource: "chaosresults"}, "nginx-chaos-pod-delete", nil)
+		}
+		return false, nil, nil
+	})
+	clientSets := clients.ClientSets{LitmusClient: litmusClient.LitmuschaosV1alpha1()}
+
+	require.NoError(t, AnnotateChaosResult("nginx-chaos-pod-delete", "litmus", "injected", "pod", "nginx-2", clientSets))
+	assert.Equal(t, 0, conflicts)
+
+	result, err := litmusClient.LitmuschaosV1alpha1().ChaosResults("litmus").Get(context.Background(), "nginx-chaos-pod-delete", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "injected", result.Annotations["pod/nginx-2"])
+}
+
+func TestAnnotateChaosResultConcurrently(t *testing.T) {
+	litmusClient := fake.NewSimpleClientset(newChaosResult())
+	clientSets := clients.ClientSets{LitmusClient: litmusClient.LitmuschaosV1alpha1()}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	expected := map[string]string{"pod/nginx-1": "injected"}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("nginx-%d", i+2)
+		expected["pod/"+name] = "injected"
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- AnnotateChaosResult("nginx-chaos-pod-delete", "litmus", "injected", "pod", name, clientSets)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	result, err := litmusClient.LitmuschaosV1alpha1().ChaosResults("litmus").Get(context.Background(), "nginx-chaos-pod-delete", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, result.Annotations)
+}
+
+func TestAnnotateChaosResultNotFound(t *testing.T) {
+	clientSets := clients.ClientSets{LitmusClient: fake.NewSimpleClientset().LitmuschaosV1alpha1()}
+
+	assert.Error(t, AnnotateChaosResult("nginx-chaos-pod-delete", "litmus", "injected", "pod", "nginx-2", clientSets))
+}
@@ -0,0 +1,331 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/palantir/stacktrace"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScenarioEnv contains the faults of the scenario in yaml, for example
+//
+//	faults:
+//	  - fault: pod-network-latency
+//	    duration: 60
+//	    env:
+//	      NETWORK_LATENCY: "2000"
+//	  - fault: pod-cpu-hog-exec
+//	    startOffset: 30
+//	    duration: 30
+const ScenarioEnv = "SCENARIO"
+
+var faultNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Fault is a fault of the scenario
+type Fault struct {
+	// Name identifies the fault in the scenario, it defaults to the name of the fault
+	Name string `yaml:"name"`
+	// Fault is the name of the registered fault, like pod-network-latency
+	Fault string `yaml:"fault"`
+	// StartOffset is the delay (in seconds) after the start of the scenario, before the fault is injected
+	StartOffset int `yaml:"startOffset"`
+	// Duration is the chaos duration of the fault (in seconds), it defaults to the chaos duration of the scenario
+	Duration int `yaml:"duration"`
+	// Env contains the tunables of the fault, which override the env of the scenario
+	// the targets, the probes and the other env of the scenario are shared by all the faults
+	Env map[string]string `yaml:"env"`
+}
+
+// Scenario contains the faults, which are injected concurrently as per their start offsets
+type Scenario struct {
+	Faults []Fault `yaml:"faults"`
+}
+
+// Injector injects a fault, with the tunables read while preparing it
+type Injector func(ctx context.Context, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error
+
+// Registry maps the faults, which can be composed in a scenario, to the functions preparing them
+// the functions read the tunables of the fault through types.Getenv and return its injector
+type Registry map[string]func(chaosDetails *types.ChaosDetails) (Injector, error)
+
+// GetScenario parses the scenario from the SCENARIO env and validates it against the registry
+// the faults without the duration are run for the chaos duration of the scenario
+func GetScenario(chaosDuration int, registry Registry) (*Scenario, error) {
+	spec := strings.TrimSpace(os.Getenv(ScenarioEnv))
+	if spec == "" {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("no fault provided, please provide the faults of the scenario in the %s env", ScenarioEnv)}
+	}
+
+	var scenario Scenario
+	if err := yaml.UnmarshalStrict([]byte(spec), &scenario); err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("could not parse the %s env: %v", ScenarioEnv, err)}
+	}
+	if len(scenario.Faults) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("no fault provided, please provide the faults of the scenario in the %s env", ScenarioEnv)}
+	}
+
+	names := map[string]bool{}
+	for i := range scenario.Faults {
+		fault := &scenario.Faults[i]
+		if fault.Name == "" {
+			fault.Name = fault.Fault
+		}
+		if fault.Duration == 0 {
+			fault.Duration = chaosDuration
+		}
+
+		target := fmt.Sprintf("{fault: %s}", fault.Name)
+		if _, ok := registry[fault.Fault]; !ok {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: target, Reason: fmt.Sprintf("'%s' fault can't be composed in a scenario, supported faults are %v", fault.Fault, registry.names())}
+		}
+		if !faultNameRegex.MatchString(fault.Name) {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: target, Reason: "fault name should consist of lower case alphanumeric characters or '-'"}
+		}
+		if names[fault.Name] {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: target, Reason: "fault name should be unique in the scenario"}
+		}
+		names[fault.Name] = true
+		if fault.StartOffset < 0 || fault.Duration < 0 {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: target, Reason: "start offset and duration of the fault can't be negative"}
+		}
+	}
+
+	// the chaoslibs keep the state of the run on the targets, so the faults of the same chaoslib can't overlap on the same app
+	for i, a := range scenario.Faults {
+		for _, b := range scenario.Faults[i+1:] {
+			if !a.overlaps(b) {
+				continue
+			}
+			target := fmt.Sprintf("{faults: [%s, %s]}", a.Name, b.Name)
+			if a.Fault == b.Fault {
+				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: target, Reason: fmt.Sprintf("'%s' fault can't overlap with itself in the timeline", a.Fault)}
+			}
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: target, Reason: fmt.Sprintf("'%s' and '%s' faults share the %s chaoslib, so they can't overlap on the same app in the timeline", a.Fault, b.Fault, faultFamilies[a.Fault])}
+		}
+	}
+	return &scenario, nil
+}
+
+// faultFamilies maps the faults to their chaoslib, the faults of the same chaoslib replace the state of each other on the targets
+var faultFamilies = map[string]string{
+	"pod-network-latency":     "network",
+	"pod-network-loss":        "network",
+	"pod-network-corruption":  "network",
+	"pod-network-duplication": "network",
+	"pod-network-rate-limit":  "network",
+	"pod-cpu-hog":             "stress",
+	"pod-memory-hog":          "stress",
+	"pod-io-stress":           "stress",
+}
+
+// overlaps checks if the faults conflict, i.e. they are of the same fault or chaoslib and overlap on the same app in the timeline
+// the faults are considered to target the same app, unless their env override the targets with the different values
+func (f Fault) overlaps(other Fault) bool {
+	if f.StartOffset >= other.StartOffset+other.Duration || other.StartOffset >= f.StartOffset+f.Duration {
+		return false
+	}
+	if f.Fault == other.Fault {
+		return true
+	}
+	family, ok := faultFamilies[f.Fault]
+	return ok && family == faultFamilies[other.Fault] && f.Env["TARGETS"] == other.Env["TARGETS"]
+}
+
+// Duration returns the length of the timeline of the scenario, in seconds
+func (s *Scenario) Duration() int {
+	var duration int
+	for _, fault := range s.Faults {
+		if end := fault.StartOffset + fault.Duration; end > duration {
+			duration = end
+		}
+	}
+	return duration
+}
+
+// PreparedFault is a fault with its tunables read, which is ready to be injected
+type PreparedFault struct {
+	Fault
+	inject        Injector
+	chaosDetails  *types.ChaosDetails
+	resultDetails *types.ResultDetails
+}
+
+// Prepare reads the tunables of the faults, each fault reads its env on top of the env of the scenario
+// every fault gets its own copy of the chaos details, seeded with the seed of the scenario, so that
+// the faults choose the same targets from the same candidates
+func (s *Scenario) Prepare(registry Registry, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) ([]*PreparedFault, error) {
+	var faults []*PreparedFault
+	for _, fault := range s.Faults {
+		env := maps.Clone(fault.Env)
+		if env == nil {
+			env = map[string]string{}
+		}
+		env["TOTAL_CHAOS_DURATION"] = strconv.Itoa(fault.Duration)
+		env[types.RandomSeedEnv] = strconv.FormatInt(chaosDetails.Seed, 10)
+
+		faultChaosDetails := types.ChaosDetails{}
+		var inject Injector
+		var err error
+		types.WithEnv(env, func() {
+			types.InitialiseChaosVariables(&faultChaosDetails)
+			inject, err = registry[fault.Fault](&faultChaosDetails)
+		})
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "could not prepare the %s fault", fault.Name)
+		}
+		if faultChaosDetails.TargetsError != nil {
+			return nil, stacktrace.Propagate(faultChaosDetails.TargetsError, "could not parse the targets of the %s fault", fault.Name)
+		}
+		faultChaosDetails.Annotations = chaosDetails.Annotations
+		faultChaosDetails.Resources = chaosDetails.Resources
+		faultChaosDetails.ImagePullSecrets = chaosDetails.ImagePullSecrets
+		faultChaosDetails.SideCar = chaosDetails.SideCar
+		faultChaosDetails.Labels = maps.Clone(chaosDetails.Labels)
+		faultChaosDetails.Phase = types.ChaosInjectPhase
+		// the approval gates are honoured by the scenario, before injecting all the faults
+		faultChaosDetails.Approval = types.Approval{}
+
+		// the probes are run by the scenario, the faults only inject the chaos
+		faultResultDetails := *resultDetails
+		faultResultDetails.ProbeDetails = nil
+
+		faults = append(faults, &PreparedFault{Fault: fault, inject: inject, chaosDetails: &faultChaosDetails, resultDetails: &faultResultDetails})
+	}
+	return faults, nil
+}
+
+// Run injects the prepared faults concurrently, each of them after its start offset
+// once a fault fails, the faults which aren't started yet are skipped and the run is aborted,
+// so that the faults in progress are reverted through the revert phase.
+// The outcome of the faults and their targets are recorded in the chaos details of the scenario
+func Run(ctx context.Context, faults []*PreparedFault, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	defer close(done)
+	go abortWatcher(done, clients, chaosDetails)
+
+	results := make([]types.FaultResult, len(faults))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, fault := range faults {
+		wg.Add(1)
+		go func(i int, fault *PreparedFault) {
+			defer wg.Done()
+			results[i] = types.FaultResult{Name: fault.Name, Fault: fault.Fault.Fault, StartOffset: fault.StartOffset, Duration: fault.Duration}
+
+			if fault.StartOffset > 0 {
+				log.Infof("[Scenario]: Waiting for the %vs start offset of the %s fault", fault.StartOffset, fault.Name)
+				if err := common.WaitForDurationWithContext(ctx, fault.StartOffset); err != nil {
+					results[i].Status, results[i].Reason = types.FaultSkipped, context.Cause(ctx).Error()
+					return
+				}
+			}
+
+			log.Infof("[Scenario]: Injecting the %s fault for %vs", fault.Name, fault.Duration)
+			// the events of the faults are generated on their own copy of the event details
+			faultEventsDetails := *eventsDetails
+			err := fault.inject(ctx, clients, fault.resultDetails, &faultEventsDetails, fault.chaosDetails)
+
+			mu.Lock()
+			defer mu.Unlock()
+			chaosDetails.Targets = append(chaosDetails.Targets, fault.chaosDetails.Targets...)
+			if err != nil {
+				results[i].Status, results[i].Reason = types.FaultFailed, err.Error()
+				if firstErr == nil {
+					firstErr = stacktrace.Propagate(err, "%s fault failed", fault.Name)
+					log.Errorf("[Scenario]: The %s fault failed, reverting the other faults, err: %v", fault.Name, err)
+					cancel(firstErr)
+					common.AbortOnFaultFailure(firstErr)
+				}
+				return
+			}
+			results[i].Status = types.FaultCompleted
+			log.Infof("[Scenario]: The %s fault is completed", fault.Name)
+		}(i, fault)
+	}
+	wg.Wait()
+
+	chaosDetails.FaultResults = results
+	return firstErr
+}
+
+// abortWatcher reverts the faults with the helper pods, once the run is aborted
+// the helper pods revert the chaos upon deletion, the other faults are reverted by their chaoslibs
+func abortWatcher(done <-chan struct{}, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	// registering the revert, so that the abort is recorded and the process exits only after it is completed
+	revertDone := common.TrackRevert()
+	defer revertDone()
+
+	abort := make(chan os.Signal, 1)
+	common.NotifyAbort(abort)
+
+	select {
+	case <-done:
+		return
+	case <-abort:
+	}
+
+	log.Info("[Abort]: Deleting the helper pods of the scenario faults")
+	if err := deleteHelperPods(clients, chaosDetails); err != nil {
+		log.Errorf("[Abort]: Unable to delete the helper pods, err: %v", err)
+		return
+	}
+	log.Info("[Abort]: Chaos Revert Completed")
+}
+
+// deleteHelperPods deletes the helper pods of the faults of the scenario
+// the helper pods are named after the experiment and share the chaos uid with the experiment pod
+func deleteHelperPods(clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	selector := ""
+	if chaosDetails.ChaosUID != "" {
+		selector = "chaosUID=" + string(chaosDetails.ChaosUID)
+	}
+	pods, err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).List(context.Background(), v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{namespace: %s}", chaosDetails.ChaosNamespace), Reason: fmt.Sprintf("failed to list helper pods: %s", err.Error())}
+	}
+
+	var errs []string
+	for _, pod := range pods.Items {
+		if !strings.HasPrefix(pod.Labels["app"], chaosDetails.ExperimentName+"-helper-") {
+			continue
+		}
+		log.Infof("[Abort]: Deleting the %s helper pod", pod.Name)
+		if err := clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{}); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", pod.Name, err))
+		}
+	}
+	if len(errs) != 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{namespace: %s}", chaosDetails.ChaosNamespace), Reason: fmt.Sprintf("failed to delete helper pods: [%s]", strings.Join(errs, ", "))}
+	}
+	return nil
+}
+
+// names returns the sorted names of the registered faults
+func (registry Registry) names() []string {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,190 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noopFault is a registered fault which doesn't inject any chaos
+func noopFault(*types.ChaosDetails) (Injector, error) {
+	return func(context.Context, clients.ClientSets, *types.ResultDetails, *types.EventDetails, *types.ChaosDetails) error {
+		return nil
+	}, nil
+}
+
+var testRegistry = Registry{"pod-network-latency": noopFault, "pod-network-loss": noopFault, "pod-cpu-hog-exec": noopFault}
+
+func TestGetScenario(t *testing.T) {
+	testCases := map[string]struct {
+		spec   string
+		faults []Fault
+		err    string
+	}{
+		"defaults": {
+			spec: `
+faults:
+  - fault: pod-network-latency
+    env:
+      NETWORK_LATENCY: "2000"
+  - name: cpu
+    fault: pod-cpu-hog-exec
+    startOffset: 30
+    duration: 10`,
+			faults: []Fault{
+				{Name: "pod-network-latency", Fault: "pod-network-latency", Duration: 60, Env: map[string]string{"NETWORK_LATENCY": "2000"}},
+				{Name: "cpu", Fault: "pod-cpu-hog-exec", StartOffset: 30, Duration: 10},
+			},
+		},
+		"same fault one after another": {
+			spec: `
+faults:
+  - {name: first, fault: pod-cpu-hog-exec, duration: 30}
+  - {name: second, fault: pod-cpu-hog-exec, startOffset: 30, duration: 30}`,
+			faults: []Fault{
+				{Name: "first", Fault: "pod-cpu-hog-exec", Duration: 30},
+				{Name: "second", Fault: "pod-cpu-hog-exec", StartOffset: 30, Duration: 30},
+			},
+		},
+		"network faults on the different apps": {
+			spec: `
+faults:
+  - {name: latency, fault: pod-network-latency, env: {TARGETS: "deployment:default:[app=nginx]"}}
+  - {name: loss, fault: pod-network-loss, env: {TARGETS: "deployment:default:[app=redis]"}}`,
+			faults: []Fault{
+				{Name: "latency", Fault: "pod-network-latency", Duration: 60, Env: map[string]string{"TARGETS": "deployment:default:[app=nginx]"}},
+				{Name: "loss", Fault: "pod-network-loss", Duration: 60, Env: map[string]string{"TARGETS": "deployment:default:[app=redis]"}},
+			},
+		},
+		"overlapping network faults": {
+			spec: "faults: [{name: latency, fault: pod-network-latency}, {name: loss, fault: pod-network-loss, startOffset: 30}]",
+			err:  "'pod-network-latency' and 'pod-network-loss' faults share the network chaoslib",
+		},
+		"empty":              {spec: "", err: "no fault provided"},
+		"no faults":          {spec: "faults: []", err: "no fault provided"},
+		"unknown field":      {spec: "faults: [{fault: pod-cpu-hog-exec, offset: 10}]", err: "could not parse the SCENARIO env"},
+		"unregistered fault": {spec: "faults: [{fault: pod-delete}]", err: "'pod-delete' fault can't be composed in a scenario, supported faults are [pod-cpu-hog-exec pod-network-latency pod-network-loss]"},
+		"invalid name":       {spec: "faults: [{name: CPU, fault: pod-cpu-hog-exec}]", err: "fault name should consist of lower case alphanumeric characters"},
+		"duplicate name":     {spec: "faults: [{name: a, fault: pod-cpu-hog-exec}, {name: a, fault: pod-network-latency}]", err: "fault name should be unique"},
+		"negative start":     {spec: "faults: [{fault: pod-cpu-hog-exec, startOffset: -1}]", err: "can't be negative"},
+		"overlapping itself": {spec: "faults: [{name: a, fault: pod-cpu-hog-exec}, {name: b, fault: pod-cpu-hog-exec, startOffset: 59}]", err: "'pod-cpu-hog-exec' fault can't overlap with itself"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(ScenarioEnv, tc.spec)
+			scenario, err := GetScenario(60, testRegistry)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.faults, scenario.Faults)
+		})
+	}
+}
+
+func TestDuration(t *testing.T) {
+	scenario := Scenario{Faults: []Fault{{StartOffset: 10, Duration: 60}, {StartOffset: 30, Duration: 20}}}
+	assert.Equal(t, 70, scenario.Duration())
+}
+
+func TestPrepare(t *testing.T) {
+	t.Setenv("NETWORK_LATENCY", "100")
+	t.Setenv("TARGETS", "deployment:default:[app=nginx]")
+
+	latencies := map[string]string{}
+	registry := Registry{"pod-network-latency": func(chaosDetails *types.ChaosDetails) (Injector, error) {
+		latencies[chaosDetails.ExperimentName+"/"+types.Getenv("NETWORK_LATENCY", "")] = types.Getenv("TOTAL_CHAOS_DURATION", "")
+		return noopFault(chaosDetails)
+	}}
+	scenario := Scenario{Faults: []Fault{
+		{Name: "default", Fault: "pod-network-latency", Duration: 30},
+		{Name: "high", Fault: "pod-network-latency", StartOffset: 30, Duration: 10, Env: map[string]string{"NETWORK_LATENCY": "2000", "EXPERIMENT_NAME": "scenario"}},
+	}}
+	chaosDetails := &types.ChaosDetails{Labels: map[string]string{"chaosUID": "uid"}}
+	types.SetRandomSeed(chaosDetails, 7)
+	resultDetails := &types.ResultDetails{Name: "engine-scenario", ProbeDetails: []*types.ProbeDetails{{Name: "probe"}}}
+
+	faults, err := scenario.Prepare(registry, resultDetails, chaosDetails)
+	require.NoError(t, err)
+	require.Len(t, faults, 2)
+	assert.Equal(t, map[string]string{"/100": "30", "scenario/2000": "10"}, latencies)
+
+	for _, fault := range faults {
+		assert.Equal(t, int64(7), fault.chaosDetails.Seed)
+		assert.Equal(t, types.ChaosInjectPhase, fault.chaosDetails.Phase)
+		assert.Equal(t, chaosDetails.Labels, fault.chaosDetails.Labels)
+		require.Len(t, fault.chaosDetails.AppDetail, 1)
+		assert.Equal(t, "engine-scenario", fault.resultDetails.Name)
+		assert.Empty(t, fault.resultDetails.ProbeDetails)
+	}
+	assert.Len(t, resultDetails.ProbeDetails, 1, "probes of the scenario shouldn't be modified")
+	assert.Equal(t, "", types.Getenv("EXPERIMENT_NAME", ""), "env of the fault shouldn't leak outside of it")
+}
+
+func TestPrepareFailure(t *testing.T) {
+	registry := Registry{"pod-cpu-hog-exec": func(*types.ChaosDetails) (Injector, error) {
+		return nil, errors.New("invalid tunables")
+	}}
+	scenario := Scenario{Faults: []Fault{{Name: "cpu", Fault: "pod-cpu-hog-exec"}}}
+
+	_, err := scenario.Prepare(registry, &types.ResultDetails{}, &types.ChaosDetails{})
+	assert.ErrorContains(t, err, "could not prepare the cpu fault")
+}
+
+func TestRun(t *testing.T) {
+	injector := func(err error, targets ...string) Injector {
+		return func(ctx context.Context, _ clients.ClientSets, _ *types.ResultDetails, _ *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+			for _, target := range targets {
+				chaosDetails.Targets = append(chaosDetails.Targets, v1alpha1.TargetDetails{Name: target})
+			}
+			return err
+		}
+	}
+	prepared := func(name string, startOffset int, inject Injector) *PreparedFault {
+		return &PreparedFault{
+			Fault:         Fault{Name: name, Fault: name, StartOffset: startOffset, Duration: 10},
+			inject:        inject,
+			chaosDetails:  &types.ChaosDetails{},
+			resultDetails: &types.ResultDetails{},
+		}
+	}
+
+	t.Run("completed", func(t *testing.T) {
+		chaosDetails := &types.ChaosDetails{}
+		err := Run(context.Background(), []*PreparedFault{
+			prepared("latency", 0, injector(nil, "nginx-1")),
+			prepared("cpu", 1, injector(nil, "nginx-2")),
+		}, clients.ClientSets{}, &types.EventDetails{}, chaosDetails)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []v1alpha1.TargetDetails{{Name: "nginx-1"}, {Name: "nginx-2"}}, chaosDetails.Targets)
+		assert.Equal(t, []types.FaultResult{
+			{Name: "latency", Fault: "latency", Duration: 10, Status: types.FaultCompleted},
+			{Name: "cpu", Fault: "cpu", StartOffset: 1, Duration: 10, Status: types.FaultCompleted},
+		}, chaosDetails.FaultResults)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		chaosDetails := &types.ChaosDetails{}
+		err := Run(context.Background(), []*PreparedFault{
+			prepared("latency", 0, injector(errors.New("tc failed"), "nginx-1")),
+			prepared("cpu", 60, injector(nil, "nginx-2")),
+		}, clients.ClientSets{}, &types.EventDetails{}, chaosDetails)
+		assert.ErrorContains(t, err, "latency fault failed")
+
+		assert.Equal(t, []v1alpha1.TargetDetails{{Name: "nginx-1"}}, chaosDetails.Targets)
+		require.Len(t, chaosDetails.FaultResults, 2)
+		assert.Equal(t, types.FaultFailed, chaosDetails.FaultResults[0].Status)
+		assert.Equal(t, "tc failed", chaosDetails.FaultResults[0].Reason)
+		assert.Equal(t, types.FaultSkipped, chaosDetails.FaultResults[1].Status)
+		assert.Contains(t, chaosDetails.FaultResults[1].Reason, "latency fault failed")
+	})
+}
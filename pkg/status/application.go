@@ -12,42 +12,67 @@ import (
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
-	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/litmuschaos/litmus-go/pkg/workloads"
 	logrus "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // AUTStatusCheck checks the status of application under test
 // if annotationCheck is true, it will check the status of the annotated pod only
 // else it will check status of all pods with matching label
-func AUTStatusCheck(clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func AUTStatusCheck(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	if chaosDetails.TargetsError != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: chaosDetails.TargetsError.Error()}
+	}
 	if chaosDetails.AppDetail == nil || (len(chaosDetails.AppDetail) == 1 && chaosDetails.AppDetail[0].Kind == "KIND") {
 		log.Info("[Status]: No appLabels provided, skipping the application status checks")
 		return nil
 	}
 
-	for _, target := range chaosDetails.AppDetail {
-		switch target.Kind {
-		case "pod":
-			for _, name := range target.Names {
-				if err := CheckApplicationStatusesByPodName(target.Namespace, name, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
-					return stacktrace.Propagate(err, "could not check application status by pod names")
-				}
-			}
-		default:
-			if target.Labels != nil {
-				for _, label := range target.Labels {
-					if err := CheckApplicationStatusesByLabels(target.Namespace, label, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
-						return stacktrace.Propagate(err, "could not check application status by labels")
+	for _, appDetail := range chaosDetails.AppDetail {
+		namespaces, err := workloads.GetTargetNamespaces(appDetail, clients)
+		if err != nil {
+			return stacktrace.Propagate(err, "could not get target namespaces")
+		}
+		// the names are looked up inside all the matching namespaces, if the namespace is a pattern
+		// so the targets missing in some of the namespaces are skipped
+		isPattern := appDetail.IsNamespacePattern()
+		for _, ns := range namespaces {
+			target := appDetail
+			target.Namespace = ns
+			switch target.Kind {
+			case "pod":
+				for _, name := range target.Names {
+					isTarget, err := isTargetPod(ctx, target, name, isPattern, clients)
+					if err != nil {
+						return stacktrace.Propagate(err, "could not check the target pod")
+					}
+					if !isTarget {
+						continue
+					}
+					if err := CheckApplicationStatusesByPodName(ctx, target.Namespace, name, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
+						return stacktrace.Propagate(err, "could not check application status by pod names")
 					}
 				}
-			} else {
-				if err := CheckApplicationStatusesByWorkloadName(target, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
-					return stacktrace.Propagate(err, "could not check application status by workload names")
+			default:
+				if target.Labels != nil {
+					for _, label := range target.Labels {
+						if err := checkApplicationStatusesByLabels(ctx, target.Namespace, label, target.MatchesPod, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
+							return stacktrace.Propagate(err, "could not check application status by labels")
+						}
+					}
+				} else {
+					if err := CheckApplicationStatusesByWorkloadName(ctx, target, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
+						if isPattern && cerrors.GetErrorType(err) == cerrors.ErrorTypeTargetSelection {
+							continue
+						}
+						return stacktrace.Propagate(err, "could not check application status by workload names")
+					}
 				}
 			}
 		}
@@ -56,8 +81,29 @@ func AUTStatusCheck(clients clients.ClientSets, chaosDetails *types.ChaosDetails
 	return nil
 }
 
+// isTargetPod checks if the pod should be checked for the target
+// the pod is skipped if it doesn't match the field and annotation requirements, or it is missing in the namespace matched by the pattern
+func isTargetPod(ctx context.Context, target types.AppDetails, name string, isPattern bool, clients clients.ClientSets) (bool, error) {
+	if !isPattern && target.Fields == nil && target.Annotations == nil {
+		return true, nil
+	}
+	pod, err := clients.KubeClient.CoreV1().Pods(target.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if isPattern && k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", name, target.Namespace), Reason: err.Error()}
+	}
+	return target.MatchesPod(pod), nil
+}
+
 // CheckApplicationStatusesByLabels checks the status of the AUT
-func CheckApplicationStatusesByLabels(appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
+func CheckApplicationStatusesByLabels(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
+	return checkApplicationStatusesByLabels(ctx, appNs, appLabel, nil, timeout, delay, clients)
+}
+
+// checkApplicationStatusesByLabels checks the status of the AUT, only the pods accepted by the filter are checked
+func checkApplicationStatusesByLabels(ctx context.Context, appNs, appLabel string, filter podFilter, timeout, delay int, clients clients.ClientSets) error {
 
 	switch appLabel {
 	case "":
@@ -66,12 +112,12 @@ func CheckApplicationStatusesByLabels(appNs, appLabel string, timeout, delay int
 	default:
 		// Checking whether application containers are in ready state
 		log.Info("[Status]: Checking whether application containers are in ready state")
-		if err := CheckContainerStatus(appNs, appLabel, "", timeout, delay, clients); err != nil {
+		if err := checkContainerStatus(ctx, appNs, appLabel, "", filter, timeout, delay, clients); err != nil {
 			return stacktrace.Propagate(err, "could not check container status")
 		}
 		// Checking whether application pods are in running state
 		log.Info("[Status]: Checking whether application pods are in running state")
-		if err := CheckPodStatus(appNs, appLabel, timeout, delay, clients); err != nil {
+		if err := checkPodStatusPhase(ctx, appNs, appLabel, filter, timeout, delay, clients, "Running"); err != nil {
 			return stacktrace.Propagate(err, "could not check pod status")
 		}
 	}
@@ -79,13 +125,13 @@ func CheckApplicationStatusesByLabels(appNs, appLabel string, timeout, delay int
 }
 
 // CheckAuxiliaryApplicationStatus checks the status of the Auxiliary applications
-func CheckAuxiliaryApplicationStatus(AuxiliaryAppDetails string, timeout, delay int, clients clients.ClientSets) error {
+func CheckAuxiliaryApplicationStatus(ctx context.Context, AuxiliaryAppDetails string, timeout, delay int, clients clients.ClientSets) error {
 
 	AuxiliaryAppInfo := strings.Split(AuxiliaryAppDetails, ",")
 
 	for _, val := range AuxiliaryAppInfo {
 		AppInfo := strings.Split(val, ":")
-		if err := CheckApplicationStatusesByLabels(AppInfo[0], AppInfo[1], timeout, delay, clients); err != nil {
+		if err := CheckApplicationStatusesByLabels(ctx, AppInfo[0], AppInfo[1], timeout, delay, clients); err != nil {
 			return stacktrace.Propagate(err, "could not check auxiliary application status")
 		}
 	}
@@ -93,28 +139,30 @@ func CheckAuxiliaryApplicationStatus(AuxiliaryAppDetails string, timeout, delay
 }
 
 // CheckPodStatusPhase checks the status of the application pod
-func CheckPodStatusPhase(appNs, appLabel string, timeout, delay int, clients clients.ClientSets, states ...string) error {
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
-			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(context.Background(), metav1.ListOptions{LabelSelector: appLabel})
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabels: %s, namespace: %s}", appLabel, appNs), Reason: err.Error()}
-			} else if len(podList.Items) == 0 {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabels: %s, namespace: %s}", appLabel, appNs), Reason: "no pod found with matching labels"}
-			}
+func CheckPodStatusPhase(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets, states ...string) error {
+	return checkPodStatusPhase(ctx, appNs, appLabel, nil, timeout, delay, clients, states...)
+}
 
-			for _, pod := range podList.Items {
-				isInState := isOneOfState(string(pod.Status.Phase), states)
-				if !isInState {
-					return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, appNs), Reason: fmt.Sprintf("pod is not in [%v] states", states)}
-				}
-				log.InfoWithValues("[Status]: The status of Pods are as follows", logrus.Fields{
-					"Pod": pod.Name, "Status": pod.Status.Phase})
+// checkPodStatusPhase checks the status of the application pods accepted by the filter
+func checkPodStatusPhase(ctx context.Context, appNs, appLabel string, filter podFilter, timeout, delay int, clients clients.ClientSets, states ...string) error {
+	return waitForPods(ctx, appNs, appLabel, "", time.Duration(timeout)*time.Second, time.Duration(delay)*time.Second, clients, func(pods []v1.Pod) error {
+		pods = filterPods(pods, filter)
+		if len(pods) == 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabels: %s, namespace: %s}", appLabel, appNs), Reason: "no pod found with matching labels"}
+		}
+
+		for _, pod := range pods {
+			isInState := isOneOfState(string(pod.Status.Phase), states)
+			if !isInState {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, appNs), Reason: fmt.Sprintf("pod is not in [%v] states", states)}
 			}
-			return nil
-		})
+		}
+		for _, pod := range pods {
+			log.InfoWithValues("[Status]: The status of Pods are as follows", logrus.Fields{
+				"Pod": pod.Name, "Status": pod.Status.Phase})
+		}
+		return nil
+	})
 }
 
 // isOneOfState check for the string should be present inside given list
@@ -128,37 +176,36 @@ func isOneOfState(podState string, states []string) bool {
 }
 
 // CheckPodStatus checks the running status of the application pod
-func CheckPodStatus(appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
-	return CheckPodStatusPhase(appNs, appLabel, timeout, delay, clients, "Running")
+func CheckPodStatus(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
+	return CheckPodStatusPhase(ctx, appNs, appLabel, timeout, delay, clients, "Running")
 }
 
 // CheckContainerStatus checks the status of the application container
-func CheckContainerStatus(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets) error {
-
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
-			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(context.Background(), metav1.ListOptions{LabelSelector: appLabel})
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabels: %s, namespace: %v}", appLabel, appNs), Reason: err.Error()}
-			} else if len(podList.Items) == 0 {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabels: %s, namespace: %v}", appLabel, appNs), Reason: "no pod found with matching labels"}
-			}
-			for _, pod := range podList.Items {
-				switch containerName {
-				case "":
-					if err := validateAllContainerStatus(pod.Name, pod.Status.ContainerStatuses); err != nil {
-						return err
-					}
-				default:
-					if err := validateContainerStatus(containerName, pod.Name, pod.Status.ContainerStatuses); err != nil {
-						return err
-					}
+func CheckContainerStatus(ctx context.Context, appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets) error {
+	return checkContainerStatus(ctx, appNs, appLabel, containerName, nil, timeout, delay, clients)
+}
+
+// checkContainerStatus checks the status of the containers of the application pods accepted by the filter
+func checkContainerStatus(ctx context.Context, appNs, appLabel, containerName string, filter podFilter, timeout, delay int, clients clients.ClientSets) error {
+	return waitForPods(ctx, appNs, appLabel, "", time.Duration(timeout)*time.Second, time.Duration(delay)*time.Second, clients, func(pods []v1.Pod) error {
+		pods = filterPods(pods, filter)
+		if len(pods) == 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabels: %s, namespace: %v}", appLabel, appNs), Reason: "no pod found with matching labels"}
+		}
+		for _, pod := range pods {
+			switch containerName {
+			case "":
+				if err := validateAllContainerStatus(pod.Name, pod.Status.ContainerStatuses); err != nil {
+					return err
+				}
+			default:
+				if err := validateContainerStatus(containerName, pod.Name, pod.Status.ContainerStatuses); err != nil {
+					return err
 				}
 			}
-			return nil
-		})
+		}
+		return nil
+	})
 }
 
 // validateContainerStatus verify that the provided container should be in ready state
@@ -189,52 +236,46 @@ func validateAllContainerStatus(podName string, ContainerStatuses []v1.Container
 }
 
 // WaitForCompletion wait until the completion of pod
-func WaitForCompletion(appNs, appLabel string, clients clients.ClientSets, duration int, containerNames ...string) (string, error) {
+func WaitForCompletion(ctx context.Context, appNs, appLabel string, clients clients.ClientSets, duration int, containerNames ...string) (string, error) {
 	var podStatus string
 	failedPods := 0
 	// It will wait till the completion of target container
-	// it will retry until the target container completed or met the timeout(chaos duration)
-	err := retry.
-		Times(uint(duration)).
-		Wait(1 * time.Second).
-		Try(func(attempt uint) error {
-			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(context.Background(), metav1.ListOptions{LabelSelector: appLabel})
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", appLabel, appNs), Reason: err.Error()}
-			} else if len(podList.Items) == 0 {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", appLabel, appNs), Reason: "no pod with matching label"}
-			}
-			// it will check for the status of helper pod, if it is Succeeded and target container is completed then it will mark it as completed and return
-			// if it is still running then it will check for the target container, as we can have multiple container inside helper pod (istio)
-			// if the target container is in completed state(ready flag is false), then we will mark the helper pod as completed
-			// we will retry till it met the timeout(chaos duration)
-			failedPods = 0
-			for _, pod := range podList.Items {
-				podStatus = string(pod.Status.Phase)
-				log.Infof("helper pod status: %v", podStatus)
-				if podStatus != "Succeeded" && podStatus != "Failed" {
-					for _, container := range pod.Status.ContainerStatuses {
-						if Contains(container.Name, containerNames) {
-							if container.Ready {
-								return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", pod.Name, pod.Namespace, container.Name), Reason: "container is not completed within timeout"}
-							} else if container.State.Terminated != nil && container.State.Terminated.ExitCode == 1 {
-								podStatus = "Failed"
-								break
-							}
+	// it will watch the helper pods until the target container completed or met the timeout(chaos duration)
+	err := waitForPods(ctx, appNs, appLabel, "", time.Duration(duration)*time.Second, time.Second, clients, func(pods []v1.Pod) error {
+		if len(pods) == 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", appLabel, appNs), Reason: "no pod with matching label"}
+		}
+		// it will check for the status of helper pod, if it is Succeeded and target container is completed then it will mark it as completed and return
+		// if it is still running then it will check for the target container, as we can have multiple container inside helper pod (istio)
+		// if the target container is in completed state(ready flag is false), then we will mark the helper pod as completed
+		// it is re-evaluated on every change of the helper pods, till it met the timeout(chaos duration)
+		failedPods = 0
+		for _, pod := range pods {
+			podStatus = string(pod.Status.Phase)
+			log.Infof("helper pod status: %v", podStatus)
+			if podStatus != "Succeeded" && podStatus != "Failed" {
+				for _, container := range pod.Status.ContainerStatuses {
+					if Contains(container.Name, containerNames) {
+						if container.Ready {
+							return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", pod.Name, pod.Namespace, container.Name), Reason: "container is not completed within timeout"}
+						} else if container.State.Terminated != nil && container.State.Terminated.ExitCode == 1 {
+							podStatus = "Failed"
+							break
 						}
 					}
 				}
-				if podStatus == "Pending" {
-					return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: "pod is in pending state"}
-				}
-				log.InfoWithValues("[Status]: The running status of Pods are as follows", logrus.Fields{
-					"Pod": pod.Name, "Status": podStatus})
-				if podStatus == "Failed" {
-					failedPods++
-				}
 			}
-			return nil
-		})
+			if podStatus == "Pending" {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: "pod is in pending state"}
+			}
+			log.InfoWithValues("[Status]: The running status of Pods are as follows", logrus.Fields{
+				"Pod": pod.Name, "Status": podStatus})
+			if podStatus == "Failed" {
+				failedPods++
+			}
+		}
+		return nil
+	})
 	if failedPods > 0 {
 		return "Failed", err
 	}
@@ -243,87 +284,100 @@ func WaitForCompletion(appNs, appLabel string, clients clients.ClientSets, durat
 
 // CheckHelperStatus checks the status of the helper pod
 // and wait until the helper pod comes to one of the {running,completed,failed} states
-func CheckHelperStatus(appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
-
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
-			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(context.Background(), metav1.ListOptions{LabelSelector: appLabel})
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", appLabel, appNs), Reason: fmt.Sprintf("helper status check failed: %s", err.Error())}
-			} else if len(podList.Items) == 0 {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", appLabel, appNs), Reason: "helper status check failed: no pods found with mathcing labels"}
+func CheckHelperStatus(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
+
+	var helperPods []v1.Pod
+	if err := waitForPods(ctx, appNs, appLabel, "", time.Duration(timeout)*time.Second, time.Duration(delay)*time.Second, clients, func(pods []v1.Pod) error {
+		if len(pods) == 0 {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", appLabel, appNs), Reason: "helper status check failed: no pods found with mathcing labels"}
+		}
+		for _, pod := range pods {
+			podStatus := string(pod.Status.Phase)
+			switch strings.ToLower(podStatus) {
+			case "running", "succeeded", "failed":
+				log.Infof("%v helper pod is in %v state", pod.Name, podStatus)
+			default:
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("helper pod is in %s state", podStatus)}
 			}
-			for _, pod := range podList.Items {
-				podStatus := string(pod.Status.Phase)
-				switch strings.ToLower(podStatus) {
-				case "running", "succeeded", "failed":
-					log.Infof("%v helper pod is in %v state", pod.Name, podStatus)
-				default:
-					return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("helper pod is in %s state", podStatus)}
-				}
-				for _, container := range pod.Status.ContainerStatuses {
-					if container.State.Terminated != nil && container.State.Terminated.Reason != "Completed" && container.State.Terminated.Reason != "Error" {
-						return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("helper pod's container is in terminated state with %s reason", container.State.Terminated.Reason)}
-					}
+			for _, container := range pod.Status.ContainerStatuses {
+				if container.State.Terminated != nil && container.State.Terminated.Reason != "Completed" && container.State.Terminated.Reason != "Error" {
+					return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("helper pod's container is in terminated state with %s reason", container.State.Terminated.Reason)}
 				}
 			}
-			return nil
-		})
+		}
+		helperPods = pods
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	recordHelperSchedulingLatency(ctx, helperPods)
+	return nil
 }
 
-func CheckPodStatusByPodName(appNs, appName string, timeout, delay int, clients clients.ClientSets) error {
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
-			pod, err := clients.KubeClient.CoreV1().Pods(appNs).Get(context.Background(), appName, metav1.GetOptions{})
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("podName: %v, namespace: %v", appName, appNs), Reason: err.Error()}
+// recordHelperSchedulingLatency records the time taken by the helper pods to get scheduled
+func recordHelperSchedulingLatency(ctx context.Context, helperPods []v1.Pod) {
+	for _, pod := range helperPods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue {
+				telemetry.RecordHelperSchedulingLatency(ctx, condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time))
 			}
+		}
+	}
+}
 
-			if pod.Status.Phase != v1.PodRunning {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("podName: %v, namespace: %v", appName, appNs), Reason: "pod is not in Running state"}
-			}
-			log.InfoWithValues("[Status]: The status of Pods are as follows", logrus.Fields{
-				"Pod": pod.Name, "Status": pod.Status.Phase})
+func CheckPodStatusByPodName(ctx context.Context, appNs, appName string, timeout, delay int, clients clients.ClientSets) error {
+	return waitForPods(ctx, appNs, "", nameSelector(appName), time.Duration(timeout)*time.Second, time.Duration(delay)*time.Second, clients, func(pods []v1.Pod) error {
+		pod, err := getPodByName(appNs, appName, pods)
+		if err != nil {
+			return err
+		}
+
+		if pod.Status.Phase != v1.PodRunning {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("podName: %v, namespace: %v", appName, appNs), Reason: "pod is not in Running state"}
+		}
+		log.InfoWithValues("[Status]: The status of Pods are as follows", logrus.Fields{
+			"Pod": pod.Name, "Status": pod.Status.Phase})
 
-			return nil
-		})
+		return nil
+	})
 }
 
-func CheckAllContainerStatusesByPodName(appNs, appName string, timeout, delay int, clients clients.ClientSets) error {
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
-			pod, err := clients.KubeClient.CoreV1().Pods(appNs).Get(context.Background(), appName, metav1.GetOptions{})
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("podName: %v, namespace: %v", appName, appNs), Reason: err.Error()}
-			}
-			if err := validateAllContainerStatus(pod.Name, pod.Status.ContainerStatuses); err != nil {
-				return err
-			}
-			return nil
-		})
+func CheckAllContainerStatusesByPodName(ctx context.Context, appNs, appName string, timeout, delay int, clients clients.ClientSets) error {
+	return waitForPods(ctx, appNs, "", nameSelector(appName), time.Duration(timeout)*time.Second, time.Duration(delay)*time.Second, clients, func(pods []v1.Pod) error {
+		pod, err := getPodByName(appNs, appName, pods)
+		if err != nil {
+			return err
+		}
+		return validateAllContainerStatus(pod.Name, pod.Status.ContainerStatuses)
+	})
+}
+
+// getPodByName returns the pod with the given name from the watched pods
+func getPodByName(appNs, appName string, pods []v1.Pod) (*v1.Pod, error) {
+	for i := range pods {
+		if pods[i].Name == appName {
+			return &pods[i], nil
+		}
+	}
+	return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("podName: %v, namespace: %v", appName, appNs), Reason: fmt.Sprintf("pods \"%s\" not found", appName)}
 }
 
-func CheckApplicationStatusesByWorkloadName(target types.AppDetails, timeout, delay int, clients clients.ClientSets) error {
+func CheckApplicationStatusesByWorkloadName(ctx context.Context, target types.AppDetails, timeout, delay int, clients clients.ClientSets) error {
 
 	pods, err := workloads.GetPodsFromWorkloads(target, clients)
 	if err != nil {
 		return stacktrace.Propagate(err, "could not get pods from workloads")
 	}
 	for _, pod := range pods.Items {
-		if err := CheckApplicationStatusesByPodName(target.Namespace, pod.Name, timeout, delay, clients); err != nil {
+		if err := CheckApplicationStatusesByPodName(ctx, target.Namespace, pod.Name, timeout, delay, clients); err != nil {
 			return stacktrace.Propagate(err, "could not check application status by pod name")
 		}
 	}
 	return nil
 }
 
-func CheckUnTerminatedPodStatusesByWorkloadName(target types.AppDetails, timeout, delay int, clients clients.ClientSets) error {
+func CheckUnTerminatedPodStatusesByWorkloadName(ctx context.Context, target types.AppDetails, timeout, delay int, clients clients.ClientSets) error {
 
 	pods, err := workloads.GetPodsFromWorkloads(target, clients)
 	if err != nil {
@@ -331,7 +385,7 @@ func CheckUnTerminatedPodStatusesByWorkloadName(target types.AppDetails, timeout
 	}
 	for _, pod := range pods.Items {
 		if pod.DeletionTimestamp == nil {
-			if err := CheckApplicationStatusesByPodName(target.Namespace, pod.Name, timeout, delay, clients); err != nil {
+			if err := CheckApplicationStatusesByPodName(ctx, target.Namespace, pod.Name, timeout, delay, clients); err != nil {
 				return stacktrace.Propagate(err, "could not check application status by pod name")
 			}
 		}
@@ -339,15 +393,15 @@ func CheckUnTerminatedPodStatusesByWorkloadName(target types.AppDetails, timeout
 	return nil
 }
 
-func CheckApplicationStatusesByPodName(appNs, pod string, timeout, delay int, clients clients.ClientSets) error {
+func CheckApplicationStatusesByPodName(ctx context.Context, appNs, pod string, timeout, delay int, clients clients.ClientSets) error {
 	// Checking whether application containers are in ready state
 	log.Info("[Status]: Checking whether application containers are in ready state")
-	if err := CheckAllContainerStatusesByPodName(appNs, pod, timeout, delay, clients); err != nil {
+	if err := CheckAllContainerStatusesByPodName(ctx, appNs, pod, timeout, delay, clients); err != nil {
 		return stacktrace.Propagate(err, "could not check container statuses by pod name")
 	}
 	// Checking whether application pods are in running state
 	log.Info("[Status]: Checking whether application pods are in running state")
-	if err := CheckPodStatusByPodName(appNs, pod, timeout, delay, clients); err != nil {
+	if err := CheckPodStatusByPodName(ctx, appNs, pod, timeout, delay, clients); err != nil {
 		return stacktrace.Propagate(err, "could not check pod status by pod name")
 	}
 	return nil
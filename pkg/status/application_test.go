@@ -0,0 +1,88 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func testPod(name string, phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "nginx"}},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestCheckPodStatusWaitsForRunningPods(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(testPod("nginx-1", v1.PodRunning), testPod("nginx-2", v1.PodPending))
+	clientSets := clients.ClientSets{KubeClient: kubeClient}
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = kubeClient.CoreV1().Pods("default").UpdateStatus(context.Background(), testPod("nginx-2", v1.PodRunning), metav1.UpdateOptions{})
+	}()
+
+	start := time.Now()
+	require.NoError(t, CheckPodStatus(context.Background(), "default", "app=nginx", 10, 5, clientSets))
+	assert.Less(t, time.Since(start), 5*time.Second, "the change should be observed without waiting for the delay")
+}
+
+func TestCheckPodStatusTimeout(t *testing.T) {
+	clientSets := clients.ClientSets{KubeClient: fake.NewSimpleClientset(testPod("nginx-1", v1.PodPending))}
+
+	err := CheckPodStatus(context.Background(), "default", "app=nginx", 1, 1, clientSets)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pod is not in [[Running]] states")
+}
+
+func TestCheckPodStatusByPodName(t *testing.T) {
+	clientSets := clients.ClientSets{KubeClient: fake.NewSimpleClientset(testPod("nginx-1", v1.PodRunning), testPod("nginx-2", v1.PodPending))}
+
+	require.NoError(t, CheckPodStatusByPodName(context.Background(), "default", "nginx-1", 1, 1, clientSets))
+
+	err := CheckPodStatusByPodName(context.Background(), "default", "nginx-3", 1, 1, clientSets)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestCheckNodeStatus(t *testing.T) {
+	node := func(name string, ready v1.ConditionStatus) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: ready}}},
+		}
+	}
+	clientSets := clients.ClientSets{KubeClient: fake.NewSimpleClientset(node("node-1", v1.ConditionTrue), node("node-2", v1.ConditionFalse))}
+
+	require.NoError(t, CheckNodeStatus(context.Background(), "node-1", 1, 1, clientSets))
+	require.NoError(t, CheckNodeNotReadyState(context.Background(), "node-2", 1, 1, clientSets))
+	assert.Error(t, CheckNodeStatus(context.Background(), "", 1, 1, clientSets))
+	assert.Error(t, CheckNodeStatus(context.Background(), "node-1,node-3", 1, 1, clientSets))
+}
+
+func TestCheckPodStatusPollsIfWatchIsForbidden(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(testPod("nginx-1", v1.PodRunning), testPod("nginx-2", v1.PodPending))
+	kubeClient.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("watch isn't allowed"))
+	})
+	clientSets := clients.ClientSets{KubeClient: kubeClient}
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = kubeClient.CoreV1().Pods("default").UpdateStatus(context.Background(), testPod("nginx-2", v1.PodRunning), metav1.UpdateOptions{})
+	}()
+
+	require.NoError(t, CheckPodStatus(context.Background(), "default", "app=nginx", 10, 1, clientSets))
+}
@@ -10,80 +10,81 @@ import (
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	logrus "github.com/sirupsen/logrus"
 	apiv1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // CheckNodeStatus checks the status of the node
-func CheckNodeStatus(nodes string, timeout, delay int, clients clients.ClientSets) error {
+func CheckNodeStatus(ctx context.Context, nodes string, timeout, delay int, clients clients.ClientSets) error {
 
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
-			nodeList := apiv1.NodeList{}
-			if nodes != "" {
-				targetNodes := strings.Split(nodes, ",")
-				for index := range targetNodes {
-					node, err := clients.KubeClient.CoreV1().Nodes().Get(context.Background(), targetNodes[index], metav1.GetOptions{})
-					if err != nil {
-						return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{nodeName: %s}", targetNodes[index]), Reason: err.Error()}
-					}
-					nodeList.Items = append(nodeList.Items, *node)
-				}
-			} else {
-				nodes, err := clients.KubeClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	var targetNodes []string
+	fieldSelector := ""
+	if nodes != "" {
+		targetNodes = strings.Split(nodes, ",")
+		if len(targetNodes) == 1 {
+			fieldSelector = nameSelector(targetNodes[0])
+		}
+	}
+
+	return waitForNodes(ctx, fieldSelector, time.Duration(timeout)*time.Second, time.Duration(delay)*time.Second, clients, func(nodeList []apiv1.Node) error {
+		if len(targetNodes) != 0 {
+			filtered := make([]apiv1.Node, 0, len(targetNodes))
+			for _, name := range targetNodes {
+				node, err := getNodeByName(name, nodeList)
 				if err != nil {
-					return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Reason: fmt.Sprintf("failed to list all nodes: %s", err.Error())}
+					return err
 				}
-				nodeList = *nodes
+				filtered = append(filtered, *node)
 			}
-			for _, node := range nodeList.Items {
-				conditions := node.Status.Conditions
-				isReady := false
-				for _, condition := range conditions {
-					if condition.Type == apiv1.NodeReady && condition.Status == apiv1.ConditionTrue {
-						isReady = true
-						break
-					}
-				}
-				if !isReady {
-					return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{nodeName: %s}", node.Name), Reason: "node is not in ready state"}
-				}
-				log.InfoWithValues("The Node status are as follows", logrus.Fields{
-					"Node": node.Name, "Ready": isReady})
+			nodeList = filtered
+		}
+		for _, node := range nodeList {
+			isReady := isNodeReady(node)
+			if !isReady {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{nodeName: %s}", node.Name), Reason: "node is not in ready state"}
 			}
-			return nil
-		})
+			log.InfoWithValues("The Node status are as follows", logrus.Fields{
+				"Node": node.Name, "Ready": isReady})
+		}
+		return nil
+	})
 }
 
 // CheckNodeNotReadyState check for node to be in not ready state
-func CheckNodeNotReadyState(nodeName string, timeout, delay int, clients clients.ClientSets) error {
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
-			node, err := clients.KubeClient.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
-			if err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: err.Error()}
-			}
-			conditions := node.Status.Conditions
-			isReady := false
-			for _, condition := range conditions {
-				if condition.Type == apiv1.NodeReady && condition.Status == apiv1.ConditionTrue {
-					isReady = true
-					break
-				}
-			}
-			// It will retry until the node becomes NotReady
-			if isReady {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: "node is not in NotReady state during chaos"}
-			}
-			log.InfoWithValues("The Node status are as follows", logrus.Fields{
-				"Node": node.Name, "Ready": isReady})
+func CheckNodeNotReadyState(ctx context.Context, nodeName string, timeout, delay int, clients clients.ClientSets) error {
+	return waitForNodes(ctx, nameSelector(nodeName), time.Duration(timeout)*time.Second, time.Duration(delay)*time.Second, clients, func(nodeList []apiv1.Node) error {
+		node, err := getNodeByName(nodeName, nodeList)
+		if err != nil {
+			return err
+		}
+		isReady := isNodeReady(*node)
+		// It will wait until the node becomes NotReady
+		if isReady {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: "node is not in NotReady state during chaos"}
+		}
+		log.InfoWithValues("The Node status are as follows", logrus.Fields{
+			"Node": node.Name, "Ready": isReady})
+
+		return nil
+	})
+}
+
+// isNodeReady checks if the ready condition of the node is true
+func isNodeReady(node apiv1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == apiv1.NodeReady && condition.Status == apiv1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
 
-			return nil
-		})
+// getNodeByName returns the node with the given name from the watched nodes
+func getNodeByName(nodeName string, nodes []apiv1.Node) (*apiv1.Node, error) {
+	for i := range nodes {
+		if nodes[i].Name == nodeName {
+			return &nodes[i], nil
+		}
+	}
+	return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: fmt.Sprintf("{nodeName: %s}", nodeName), Reason: fmt.Sprintf("nodes \"%s\" not found", nodeName)}
 }
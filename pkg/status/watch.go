@@ -0,0 +1,212 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// podCondition validates the pods, it returns error till the pods are not in the expected state
+type podCondition func(pods []v1.Pod) error
+
+// podFilter selects the pods, which should be validated by the condition
+type podFilter func(pod *v1.Pod) bool
+
+// nodeCondition validates the nodes, it returns error till the nodes are not in the expected state
+type nodeCondition func(nodes []v1.Node) error
+
+// waitForPods waits till the pods matching the label and field selectors satisfy the condition
+// the pods are watched instead of being listed periodically, and the condition is re-evaluated on every change
+// it returns the last error of the condition, if it is not satisfied within the timeout
+func waitForPods(ctx context.Context, appNs, labelSelector, fieldSelector string, timeout, resync time.Duration, clients clients.ClientSets, condition podCondition) error {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector, options.FieldSelector = labelSelector, fieldSelector
+			return clients.KubeClient.CoreV1().Pods(appNs).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector, options.FieldSelector = labelSelector, fieldSelector
+			return clients.KubeClient.CoreV1().Pods(appNs).Watch(ctx, options)
+		},
+	}
+	target := fmt.Sprintf("{podLabels: %s, fields: %s, namespace: %s}", labelSelector, fieldSelector, appNs)
+
+	return waitFor(ctx, lw, &v1.Pod{}, target, timeout, resync, func(objects []interface{}) error {
+		pods := make([]v1.Pod, 0, len(objects))
+		for _, obj := range objects {
+			if pod, ok := obj.(*v1.Pod); ok {
+				pods = append(pods, *pod)
+			}
+		}
+		return condition(pods)
+	})
+}
+
+// waitForNodes waits till the nodes matching the field selector satisfy the condition
+func waitForNodes(ctx context.Context, fieldSelector string, timeout, resync time.Duration, clients clients.ClientSets, condition nodeCondition) error {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return clients.KubeClient.CoreV1().Nodes().List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return clients.KubeClient.CoreV1().Nodes().Watch(ctx, options)
+		},
+	}
+	target := fmt.Sprintf("{nodes: %s}", fieldSelector)
+
+	return waitFor(ctx, lw, &v1.Node{}, target, timeout, resync, func(objects []interface{}) error {
+		nodes := make([]v1.Node, 0, len(objects))
+		for _, obj := range objects {
+			if node, ok := obj.(*v1.Node); ok {
+				nodes = append(nodes, *node)
+			}
+		}
+		return condition(nodes)
+	})
+}
+
+// waitFor runs an informer for the given resources and evaluates the condition on its cache,
+// once the cache is synced and on every subsequent change (or resync) of the resources
+// it falls back to polling the resources, if the watch is forbidden (i.e, the watch verb isn't granted to the experiment)
+func waitFor(ctx context.Context, lw cache.ListerWatcher, objType runtime.Object, target string, timeout, resync time.Duration, condition func(objects []interface{}) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		watchErr error
+	)
+	forbidden := make(chan struct{})
+	var forbiddenOnce sync.Once
+	informer := cache.NewSharedInformer(lw, objType, resync)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { notify() },
+		UpdateFunc: func(oldObj, newObj interface{}) { notify() },
+		DeleteFunc: func(obj interface{}) { notify() },
+	})
+	_ = informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		mu.Lock()
+		watchErr = err
+		mu.Unlock()
+		if k8serrors.IsForbidden(err) {
+			forbiddenOnce.Do(func() { close(forbidden) })
+		}
+	})
+	go informer.Run(ctx.Done())
+	go func() {
+		if cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+			notify()
+		}
+	}()
+
+	var err error
+	for {
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if watchErr != nil {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: target, Reason: watchErr.Error()}
+			}
+			if ctx.Err() == context.Canceled {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Target: target, Reason: "cancelled while waiting for the resources"}
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: target, Reason: "timeout while waiting for the resources"}
+		case <-forbidden:
+			mu.Lock()
+			log.Warnf("[Status]: The %v resources can't be watched, falling back to polling them, err: %v", target, watchErr)
+			mu.Unlock()
+			return pollFor(ctx, lw, target, resync, condition)
+		case <-changed:
+			if !informer.HasSynced() {
+				continue
+			}
+			if err = condition(informer.GetStore().List()); err == nil {
+				return nil
+			}
+		}
+	}
+}
+
+// pollFor lists the resources at every interval and evaluates the condition on them, till the context is done
+func pollFor(ctx context.Context, lw cache.ListerWatcher, target string, interval time.Duration, condition func(objects []interface{}) error) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var err error
+	for {
+		if err = evaluateList(lw, target, condition); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// evaluateList lists the resources and evaluates the condition on them
+func evaluateList(lw cache.ListerWatcher, target string, condition func(objects []interface{}) error) error {
+	list, err := lw.List(metav1.ListOptions{})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: target, Reason: err.Error()}
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeStatusChecks, Target: target, Reason: err.Error()}
+	}
+	objects := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		objects = append(objects, item)
+	}
+	return condition(objects)
+}
+
+// nameSelector returns the field selector for the given resource name
+func nameSelector(name string) string {
+	return fields.OneTermEqualSelector("metadata.name", name).String()
+}
+
+// filterPods returns the pods accepted by the filter, all the pods are accepted by the nil filter
+func filterPods(pods []v1.Pod, filter podFilter) []v1.Pod {
+	if filter == nil {
+		return pods
+	}
+	filtered := make([]v1.Pod, 0, len(pods))
+	for i := range pods {
+		if filter(&pods[i]) {
+			filtered = append(filtered, pods[i])
+		}
+	}
+	return filtered
+}
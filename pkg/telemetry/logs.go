@@ -0,0 +1,40 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// OTELExporterOTLPLogsEndpoint is the collector endpoint for the logs
+// the logs are exported over http, unlike the traces & metrics which are exported over grpc,
+// so it should point to the http receiver of the collector and the logs aren't exported if it is not set
+const OTELExporterOTLPLogsEndpoint = "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"
+
+// newLoggerProvider returns the logger provider exporting to the logs endpoint, it returns nil if the endpoint is not set
+func newLoggerProvider(ctx context.Context, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	endpoint := os.Getenv(OTELExporterOTLPLogsEndpoint)
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	logExporter, err := otlploghttp.New(
+		ctx,
+		// TODO: add secure option
+		otlploghttp.WithInsecure(),
+		otlploghttp.WithEndpoint(endpoint),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	loggerProvider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)),
+	)
+
+	return loggerProvider, nil
+}
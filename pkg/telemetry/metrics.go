@@ -0,0 +1,161 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	MeterName = "litmuschaos.io/litmus-go"
+	// metricExportInterval is the interval at which the metrics are pushed to the collector
+	metricExportInterval = 15 * time.Second
+)
+
+// instruments contains all the metric instruments emitted by the experiment and helper binaries
+type instruments struct {
+	phaseDuration           metric.Float64Histogram
+	injectionLatency        metric.Float64Histogram
+	revertLatency           metric.Float64Histogram
+	targets                 metric.Int64Counter
+	probeEvaluations        metric.Int64Counter
+	probeValue              metric.Float64Histogram
+	helperSchedulingLatency metric.Float64Histogram
+	verdicts                metric.Int64Counter
+}
+
+var (
+	meterInstruments     instruments
+	meterInstrumentsOnce sync.Once
+)
+
+func newMeterProvider(ctx context.Context, res *resource.Resource, endpoint string) (*sdkmetric.MeterProvider, error) {
+	metricExporter, err := otlpmetricgrpc.New(
+		ctx,
+		// TODO: add secure option
+		otlpmetricgrpc.WithInsecure(),
+		otlpmetricgrpc.WithEndpoint(endpoint),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(metricExportInterval))),
+	)
+
+	return meterProvider, nil
+}
+
+// getInstruments creates the metric instruments on the first use
+// the global meter provider delegates to the sdk provider, once it is registered
+func getInstruments() *instruments {
+	meterInstrumentsOnce.Do(func() {
+		meter := otel.Meter(MeterName)
+		var err error
+		if meterInstruments.phaseDuration, err = meter.Float64Histogram("litmus.experiment.phase.duration",
+			metric.WithDescription("Time spent by the experiment in each phase"), metric.WithUnit("s")); err != nil {
+			log.Errorf("Failed to create the phase duration metric, err: %v", err)
+		}
+		if meterInstruments.injectionLatency, err = meter.Float64Histogram("litmus.chaos.injection.latency",
+			metric.WithDescription("Time taken to inject the chaos into a target"), metric.WithUnit("s")); err != nil {
+			log.Errorf("Failed to create the injection latency metric, err: %v", err)
+		}
+		if meterInstruments.revertLatency, err = meter.Float64Histogram("litmus.chaos.revert.latency",
+			metric.WithDescription("Time taken to revert the chaos from a target"), metric.WithUnit("s")); err != nil {
+			log.Errorf("Failed to create the revert latency metric, err: %v", err)
+		}
+		if meterInstruments.targets, err = meter.Int64Counter("litmus.chaos.targets",
+			metric.WithDescription("Number of targets affected by the chaos, by status")); err != nil {
+			log.Errorf("Failed to create the targets metric, err: %v", err)
+		}
+		if meterInstruments.probeEvaluations, err = meter.Int64Counter("litmus.probe.evaluations",
+			metric.WithDescription("Number of probe evaluations, by verdict")); err != nil {
+			log.Errorf("Failed to create the probe evaluations metric, err: %v", err)
+		}
+		if meterInstruments.probeValue, err = meter.Float64Histogram("litmus.probe.value",
+			metric.WithDescription("Values measured by the probes")); err != nil {
+			log.Errorf("Failed to create the probe value metric, err: %v", err)
+		}
+		if meterInstruments.helperSchedulingLatency, err = meter.Float64Histogram("litmus.helper.scheduling.latency",
+			metric.WithDescription("Time taken by the helper pods to get scheduled"), metric.WithUnit("s")); err != nil {
+			log.Errorf("Failed to create the helper scheduling latency metric, err: %v", err)
+		}
+		if meterInstruments.verdicts, err = meter.Int64Counter("litmus.experiment.verdicts",
+			metric.WithDescription("Number of experiment runs, by verdict")); err != nil {
+			log.Errorf("Failed to create the verdicts metric, err: %v", err)
+		}
+	})
+	return &meterInstruments
+}
+
+// RecordPhaseDuration records the time spent by the experiment in the given phase
+func RecordPhaseDuration(ctx context.Context, phase string, duration time.Duration) {
+	if m := getInstruments().phaseDuration; m != nil {
+		m.Record(ctx, duration.Seconds(), metric.WithAttributes(attribute.String("phase", phase)))
+	}
+}
+
+// RecordInjectionLatency records the time taken to inject the chaos into the given target
+func RecordInjectionLatency(ctx context.Context, kind string, start time.Time) {
+	if m := getInstruments().injectionLatency; m != nil {
+		m.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attribute.String("target.kind", kind)))
+	}
+}
+
+// RecordRevertLatency records the time taken to revert the chaos from the given target
+func RecordRevertLatency(ctx context.Context, kind string, start time.Time) {
+	if m := getInstruments().revertLatency; m != nil {
+		m.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attribute.String("target.kind", kind)))
+	}
+}
+
+// RecordTarget counts the target against its chaos status
+func RecordTarget(ctx context.Context, kind, status string) {
+	if m := getInstruments().targets; m != nil {
+		m.Add(ctx, 1, metric.WithAttributes(attribute.String("target.kind", kind), attribute.String("target.status", status)))
+	}
+}
+
+// RecordProbeEvaluation counts the probe evaluation against its verdict
+func RecordProbeEvaluation(ctx context.Context, name, probeType, mode, phase, verdict string) {
+	if m := getInstruments().probeEvaluations; m != nil {
+		m.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("probe.name", name),
+			attribute.String("probe.type", probeType),
+			attribute.String("probe.mode", mode),
+			attribute.String("probe.phase", phase),
+			attribute.String("probe.verdict", verdict),
+		))
+	}
+}
+
+// RecordProbeValue records the value measured by the probe
+func RecordProbeValue(ctx context.Context, name, probeType string, value float64) {
+	if m := getInstruments().probeValue; m != nil {
+		m.Record(ctx, value, metric.WithAttributes(attribute.String("probe.name", name), attribute.String("probe.type", probeType)))
+	}
+}
+
+// RecordHelperSchedulingLatency records the time taken by the helper pod to get scheduled
+func RecordHelperSchedulingLatency(ctx context.Context, latency time.Duration) {
+	if m := getInstruments().helperSchedulingLatency; m != nil {
+		m.Record(ctx, latency.Seconds())
+	}
+}
+
+// RecordVerdict counts the experiment run against its verdict
+func RecordVerdict(ctx context.Context, verdict string) {
+	if m := getInstruments().verdicts; m != nil {
+		m.Add(ctx, 1, metric.WithAttributes(attribute.String("verdict", verdict)))
+	}
+}
@@ -3,10 +3,16 @@ package telemetry
 import (
 	"context"
 	"errors"
+	"os"
+	"sync"
+	"time"
 
+	"github.com/litmuschaos/litmus-go/pkg/log"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -17,12 +23,20 @@ const (
 	OTELExperimentJobServiceName       = "chaos_experiment_job"
 	OTELExperimentJobHelperServiceName = "chaos_experiment_job_helper"
 	OTELExporterOTLPEndpoint           = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// exitShutdownTimeout bounds the flush of the providers, if the process exits via log.Fatal or common.Exit
+	exitShutdownTimeout = 5 * time.Second
 )
 
 func InitOTelSDK(ctx context.Context, isExperiment bool, endpoint string) (shutdown func(context.Context) error, err error) {
-	var shutdownFuncs []func(context.Context) error
+	var (
+		shutdownFuncs []func(context.Context) error
+		shutdownLock  sync.Mutex
+	)
 
 	shutdown = func(ctx context.Context) error {
+		shutdownLock.Lock()
+		defer shutdownLock.Unlock()
 		var err error
 		for _, fn := range shutdownFuncs {
 			err = errors.Join(err, fn(ctx))
@@ -35,7 +49,13 @@ func InitOTelSDK(ctx context.Context, isExperiment bool, endpoint string) (shutd
 		err = errors.Join(inErr, shutdown(ctx))
 	}
 
-	tracerProvider, err := newTracerProvider(ctx, isExperiment, endpoint)
+	res, err := newResource(ctx, isExperiment)
+	if err != nil {
+		handleErr(err)
+		return
+	}
+
+	tracerProvider, err := newTracerProvider(ctx, res, endpoint)
 	if err != nil {
 		handleErr(err)
 		return
@@ -47,7 +67,35 @@ func InitOTelSDK(ctx context.Context, isExperiment bool, endpoint string) (shutd
 	shutdownFuncs = append(shutdownFuncs, tracerProvider.Shutdown)
 	otel.SetTracerProvider(tracerProvider)
 
-	// TODO: need to add metrics & logging provider
+	meterProvider, err := newMeterProvider(ctx, res, endpoint)
+	if err != nil {
+		handleErr(err)
+		return
+	}
+	shutdownFuncs = append(shutdownFuncs, meterProvider.Shutdown)
+	otel.SetMeterProvider(meterProvider)
+
+	loggerProvider, err := newLoggerProvider(ctx, res)
+	if err != nil {
+		handleErr(err)
+		return
+	}
+	if loggerProvider != nil {
+		shutdownFuncs = append(shutdownFuncs, loggerProvider.Shutdown)
+		global.SetLoggerProvider(loggerProvider)
+	} else {
+		log.Infof("[Telemetry]: The logs aren't exported, as the %v env is not set", OTELExporterOTLPLogsEndpoint)
+	}
+
+	// flushing the buffered spans, metrics and logs, if the process exits before the deferred shutdown
+	log.RegisterExitHandler(func() {
+		exitCtx, cancel := context.WithTimeout(context.Background(), exitShutdownTimeout)
+		defer cancel()
+		if err := shutdown(exitCtx); err != nil {
+			log.Errorf("[Telemetry]: Unable to flush the telemetry on exit, err: %v", err)
+		}
+	})
+
 	return
 }
 
@@ -58,16 +106,30 @@ func newPropagator() propagation.TextMapPropagator {
 	)
 }
 
-func newTracerProvider(ctx context.Context, isExperiment bool, endpoint string) (*trace.TracerProvider, error) {
+// newResource returns the resource shared by all the signals
+// it identifies the chaos run, so that the signals can be correlated with the chaosresult
+func newResource(ctx context.Context, isExperiment bool) (*resource.Resource, error) {
 	serviceName := OTELExperimentJobHelperServiceName
 	if isExperiment {
 		serviceName = OTELExperimentJobServiceName
 	}
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceNameKey.String(serviceName),
-		),
-	)
+
+	attributes := []attribute.KeyValue{semconv.ServiceNameKey.String(serviceName)}
+	for key, env := range map[string]string{
+		"chaos.experiment": "EXPERIMENT_NAME",
+		"chaos.engine":     "CHAOSENGINE",
+		"chaos.namespace":  "CHAOS_NAMESPACE",
+		"chaos.uid":        "CHAOS_UID",
+	} {
+		if value := os.Getenv(env); value != "" {
+			attributes = append(attributes, attribute.String(key, value))
+		}
+	}
+
+	return resource.New(ctx, resource.WithAttributes(attributes...))
+}
+
+func newTracerProvider(ctx context.Context, res *resource.Resource, endpoint string) (*trace.TracerProvider, error) {
 	traceExporter, err := otlptrace.New(
 		ctx,
 		otlptracegrpc.NewClient(
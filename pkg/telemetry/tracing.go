@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"os"
 
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -54,3 +58,30 @@ func GetMarshalledSpanFromContext(ctx context.Context) string {
 	}
 	return string(marshalled)
 }
+
+// StartSpan starts a child span of the span present in the context
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan records the error, if any, and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		RecordError(span, err)
+	}
+	span.End()
+}
+
+// RecordError records the error along with its error code and marks the span as failed
+func RecordError(span trace.Span, err error) {
+	errorCode := cerrors.GetErrorType(err)
+	classification := cerrors.Classify(err)
+	attributes := []attribute.KeyValue{
+		attribute.String("error.code", string(errorCode)),
+		attribute.String("error.category", string(classification.Category)),
+		attribute.Bool("error.retryable", classification.Retryable),
+	}
+	span.RecordError(err, trace.WithAttributes(attributes...))
+	span.SetAttributes(attributes...)
+	span.SetStatus(codes.Error, err.Error())
+}
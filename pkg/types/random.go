@@ -0,0 +1,71 @@
+package types
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+// RandomSeedEnv contains the seed of the random decisions, it can be set to replay the choices of an earlier run
+const RandomSeedEnv = "RANDOM_SEED"
+
+// lockedSource is a rand.Source, which can be used by the concurrent go routines
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// NewRandom returns the random number generator for the given seed, which is safe for the concurrent use
+func NewRandom(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+// SetRandomSeed seeds the random number generator of the experiment
+// all the random decisions (targets, their order, intervals and tunables) are derived from it,
+// so re-running the experiment with the same seed reproduces them
+func SetRandomSeed(chaosDetails *ChaosDetails, seed int64) {
+	chaosDetails.Seed = seed
+	chaosDetails.Random = NewRandom(seed)
+}
+
+// GetRandom returns the random number generator of the experiment, it is seeded on the first use if it isn't yet
+func (chaosDetails *ChaosDetails) GetRandom() *rand.Rand {
+	if chaosDetails.Random == nil {
+		SetRandomSeed(chaosDetails, time.Now().UnixNano())
+	}
+	return chaosDetails.Random
+}
+
+// getRandomSeed returns the seed from the env, a new seed is generated if it isn't provided or is invalid
+func getRandomSeed() int64 {
+	value := Getenv(RandomSeedEnv, "")
+	if value != "" {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
+			return seed
+		}
+		log.Errorf("Unable to parse the %s env, generating a new seed, err: %v", RandomSeedEnv, err)
+	}
+	return time.Now().UnixNano()
+}
@@ -0,0 +1,580 @@
+package types
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// The TARGETS env contains the list of targets, separated by semicolon
+//
+//	targets     := target { ";" target }
+//	target      := kind ":" namespace [ ":" "[" [ item { "," item } ] "]" ] { ":" clause }
+//	item        := name | requirement { "&" requirement }
+//	requirement := key ( "=" | "==" | "!=" ) value
+//	             | key ( "in" | "notin" ) "(" value { "," value } ")"
+//	             | key "exists" | "!" key
+//	clause      := ( "fields" | "annotations" | "nslabels" ) "{" requirement { "," requirement } "}"
+//
+// The items of the list are either the names of the workloads or the label selectors, which are OR'ed.
+// The requirements joined by "&" inside a label selector and the requirements of a clause are AND'ed.
+// The namespace can be a glob pattern (like "*" or "team-*"), which is matched against all the namespaces.
+// The values containing special characters can be double quoted.
+//
+// Examples:
+//
+//	deployment:default:[app=nginx]
+//	pod:default:[nginx-0,nginx-1]
+//	statefulset:team-*:[app in (kafka,zookeeper)&tier!=cache]:fields{phase=Running}:nslabels{env=prod}
+
+const (
+	// FieldNode selects the targets by the name of the node
+	FieldNode = "node"
+	// FieldPhase selects the targets by the phase of the pod
+	FieldPhase = "phase"
+)
+
+// Operator is the operator of a requirement
+type Operator string
+
+const (
+	OperatorEquals       Operator = "="
+	OperatorNotEquals    Operator = "!="
+	OperatorIn           Operator = "in"
+	OperatorNotIn        Operator = "notin"
+	OperatorExists       Operator = "exists"
+	OperatorDoesNotExist Operator = "!"
+)
+
+// Requirement contains the key, operator and values of a selector requirement
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Requirements is the list of requirements, which are AND'ed
+type Requirements []Requirement
+
+// Matches checks if the requirement is satisfied by the given set
+// the != and notin requirements are satisfied by the absence of the key, like the kubernetes label selectors
+func (r Requirement) Matches(set map[string]string) bool {
+	value, ok := set[r.Key]
+	switch r.Operator {
+	case OperatorEquals, OperatorIn:
+		return ok && contains(r.Values, value)
+	case OperatorNotEquals, OperatorNotIn:
+		return !ok || !contains(r.Values, value)
+	case OperatorExists:
+		return ok
+	case OperatorDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// String returns the requirement in the kubernetes label selector syntax
+func (r Requirement) String() string {
+	switch r.Operator {
+	case OperatorIn, OperatorNotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	case OperatorExists:
+		return r.Key
+	case OperatorDoesNotExist:
+		return "!" + r.Key
+	}
+	return r.Key + string(r.Operator) + r.Values[0]
+}
+
+// Matches checks if all the requirements are satisfied by the given set
+func (r Requirements) Matches(set map[string]string) bool {
+	for _, requirement := range r {
+		if !requirement.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the requirements in the kubernetes label selector syntax
+func (r Requirements) String() string {
+	result := make([]string, 0, len(r))
+	for _, requirement := range r {
+		result = append(result, requirement.String())
+	}
+	return strings.Join(result, ",")
+}
+
+// IsNamespacePattern checks if the target namespace needs to be resolved, as it is a glob pattern or has the namespace labels
+func (target AppDetails) IsNamespacePattern() bool {
+	return strings.ContainsAny(target.Namespace, "*?") || len(target.NamespaceLabels) != 0
+}
+
+// MatchesNamespace checks if the namespace matches the namespace pattern and labels of the target
+func (target AppDetails) MatchesNamespace(name string, labels map[string]string) bool {
+	matched, err := path.Match(target.Namespace, name)
+	return err == nil && matched && target.NamespaceLabels.Matches(labels)
+}
+
+// MatchesPod checks if the pod matches the field and annotation requirements of the target
+func (target AppDetails) MatchesPod(pod *corev1.Pod) bool {
+	fields := map[string]string{
+		FieldNode:  pod.Spec.NodeName,
+		FieldPhase: string(pod.Status.Phase),
+	}
+	return target.Fields.Matches(fields) && target.Annotations.Matches(pod.Annotations)
+}
+
+// TargetsSyntaxError is returned for the malformed TARGETS, it contains the position of the error
+type TargetsSyntaxError struct {
+	Input  string
+	Offset int
+	Reason string
+}
+
+func (e *TargetsSyntaxError) Error() string {
+	return fmt.Sprintf("invalid TARGETS %q at offset %d: %s", e.Input, e.Offset, e.Reason)
+}
+
+// ParseTargets parses the TARGETS env, it returns the error with the offset of the malformed input
+func ParseTargets(targets string) ([]AppDetails, error) {
+	if strings.TrimSpace(targets) == "" {
+		return nil, nil
+	}
+	p := &targetsParser{input: targets}
+	p.next()
+
+	var result []AppDetails
+	for {
+		target, err := p.parseTarget()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, target)
+		if p.tok.kind == tokenEOF {
+			return result, nil
+		}
+		if err := p.expect(";"); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ParseRequirements parses the comma separated requirements, like the requirements of the annotations clause
+func ParseRequirements(selector string) (Requirements, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	p := &targetsParser{input: selector}
+	p.next()
+
+	var requirements Requirements
+	for {
+		requirement, err := p.parseRequirement(false)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, requirement)
+		if p.tok.kind == tokenEOF {
+			return requirements, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	offset int
+}
+
+// targetsParser is a recursive descent parser for the TARGETS grammar
+type targetsParser struct {
+	input string
+	pos   int
+	tok   token
+	err   error
+}
+
+func (p *targetsParser) errorf(offset int, format string, args ...interface{}) error {
+	return &TargetsSyntaxError{Input: p.input, Offset: offset, Reason: fmt.Sprintf(format, args...)}
+}
+
+// next scans the next token, the scan errors are reported by the following expect or parse call
+func (p *targetsParser) next() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.input) {
+		p.tok = token{kind: tokenEOF, offset: start}
+		return
+	}
+
+	c := p.input[p.pos]
+	switch {
+	case c == '!' || c == '=':
+		p.pos++
+		if p.pos < len(p.input) && p.input[p.pos] == '=' {
+			p.pos++
+		}
+		p.tok = token{kind: tokenPunct, value: p.input[start:p.pos], offset: start}
+	case strings.IndexByte(":;[](){},&", c) >= 0:
+		p.pos++
+		p.tok = token{kind: tokenPunct, value: string(c), offset: start}
+	case c == '"':
+		p.pos++
+		for p.pos < len(p.input) && p.input[p.pos] != '"' {
+			if p.input[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.input) {
+			p.err = p.errorf(start, "unterminated quoted string")
+			p.tok = token{kind: tokenEOF, offset: start}
+			return
+		}
+		p.pos++
+		value, err := strconv.Unquote(p.input[start:p.pos])
+		if err != nil {
+			p.err = p.errorf(start, "invalid quoted string: %v", err)
+			p.tok = token{kind: tokenEOF, offset: start}
+			return
+		}
+		p.tok = token{kind: tokenString, value: value, offset: start}
+	case isIdentChar(c):
+		for p.pos < len(p.input) && isIdentChar(p.input[p.pos]) {
+			p.pos++
+		}
+		p.tok = token{kind: tokenIdent, value: p.input[start:p.pos], offset: start}
+	default:
+		p.err = p.errorf(start, "unexpected character %q", c)
+		p.tok = token{kind: tokenEOF, offset: start}
+	}
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-_./*?", c) >= 0
+}
+
+// describe returns the current token for the error messages
+func (p *targetsParser) describe() string {
+	if p.tok.kind == tokenEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", p.tok.value)
+}
+
+// expect consumes the given punctuation
+func (p *targetsParser) expect(punct string) error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.tok.kind != tokenPunct || p.tok.value != punct {
+		return p.errorf(p.tok.offset, "expected %q, found %s", punct, p.describe())
+	}
+	p.next()
+	return nil
+}
+
+// is checks if the current token is the given punctuation
+func (p *targetsParser) is(punct string) bool {
+	return p.err == nil && p.tok.kind == tokenPunct && p.tok.value == punct
+}
+
+// ident consumes an identifier, the quoted strings are accepted only if quoted is true
+func (p *targetsParser) ident(what string, quoted bool) (string, int, error) {
+	if p.err != nil {
+		return "", 0, p.err
+	}
+	tok := p.tok
+	if tok.kind != tokenIdent && !(quoted && tok.kind == tokenString) {
+		return "", 0, p.errorf(tok.offset, "expected %s, found %s", what, p.describe())
+	}
+	p.next()
+	return tok.value, tok.offset, nil
+}
+
+func (p *targetsParser) parseTarget() (AppDetails, error) {
+	var target AppDetails
+	kind, _, err := p.ident("kind", false)
+	if err != nil {
+		return target, err
+	}
+	if err := p.expect(":"); err != nil {
+		return target, err
+	}
+	namespace, offset, err := p.ident("namespace", false)
+	if err != nil {
+		return target, err
+	}
+	if _, err := path.Match(namespace, ""); err != nil {
+		return target, p.errorf(offset, "invalid namespace pattern %q", namespace)
+	}
+	target.Kind, target.Namespace = kind, namespace
+
+	for p.is(":") {
+		p.next()
+		if p.is("[") {
+			if target.Labels != nil || target.Names != nil {
+				return target, p.errorf(p.tok.offset, "duplicate list of names or labels")
+			}
+			if err := p.parseList(&target); err != nil {
+				return target, err
+			}
+			continue
+		}
+		if err := p.parseClause(&target); err != nil {
+			return target, err
+		}
+	}
+	if p.err != nil {
+		return target, p.err
+	}
+	return target, nil
+}
+
+// parseList parses the list of names or label selectors
+func (p *targetsParser) parseList(target *AppDetails) error {
+	offset := p.tok.offset
+	if err := p.expect("["); err != nil {
+		return err
+	}
+	var names, selectors []string
+	for !p.is("]") {
+		name, requirements, err := p.parseItem()
+		if err != nil {
+			return err
+		}
+		if requirements != nil {
+			selectors = append(selectors, requirements.String())
+		} else {
+			names = append(names, name)
+		}
+		if !p.is(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect("]"); err != nil {
+		return err
+	}
+	if len(names) != 0 && len(selectors) != 0 {
+		return p.errorf(offset, "names and label selectors can't be mixed in the same list")
+	}
+	target.Names, target.Labels = names, selectors
+	return nil
+}
+
+// parseItem parses either a name or a label selector
+func (p *targetsParser) parseItem() (string, Requirements, error) {
+	if !p.is("!") {
+		key, offset, err := p.ident("name or label selector", false)
+		if err != nil {
+			return "", nil, err
+		}
+		if p.is(",") || p.is("]") {
+			if errs := validation.IsDNS1123Subdomain(key); len(errs) != 0 {
+				return "", nil, p.errorf(offset, "invalid name %q: %s", key, strings.Join(errs, ", "))
+			}
+			return key, nil, nil
+		}
+		requirement, err := p.parseRequirementOf(key, offset, true)
+		if err != nil {
+			return "", nil, err
+		}
+		requirements, err := p.parseConjunction(Requirements{requirement})
+		return "", requirements, err
+	}
+	requirements, err := p.parseConjunction(nil)
+	return "", requirements, err
+}
+
+// parseConjunction parses the requirements joined by "&"
+func (p *targetsParser) parseConjunction(requirements Requirements) (Requirements, error) {
+	if requirements != nil {
+		if !p.is("&") {
+			return requirements, nil
+		}
+		p.next()
+	}
+	for {
+		requirement, err := p.parseRequirement(true)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, requirement)
+		if !p.is("&") {
+			return requirements, nil
+		}
+		p.next()
+	}
+}
+
+// parseClause parses the fields, annotations or nslabels clause
+func (p *targetsParser) parseClause(target *AppDetails) error {
+	name, offset, err := p.ident("clause", false)
+	if err != nil {
+		return err
+	}
+	var (
+		dest        *Requirements
+		labelValues bool
+	)
+	switch name {
+	case "fields":
+		dest = &target.Fields
+	case "annotations":
+		dest = &target.Annotations
+	case "nslabels":
+		dest, labelValues = &target.NamespaceLabels, true
+	default:
+		return p.errorf(offset, "unknown clause %q, expected one of fields, annotations or nslabels", name)
+	}
+	if *dest != nil {
+		return p.errorf(offset, "duplicate %s clause", name)
+	}
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	requirements := Requirements{}
+	for {
+		keyOffset := p.tok.offset
+		requirement, err := p.parseRequirement(labelValues)
+		if err != nil {
+			return err
+		}
+		if name == "fields" && requirement.Key != FieldNode && requirement.Key != FieldPhase {
+			return p.errorf(keyOffset, "unsupported field %q, expected one of %s or %s", requirement.Key, FieldNode, FieldPhase)
+		}
+		requirements = append(requirements, requirement)
+		if !p.is(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect("}"); err != nil {
+		return err
+	}
+	*dest = requirements
+	return nil
+}
+
+// parseRequirement parses a single requirement, the values are validated as label values if labelValues is true
+func (p *targetsParser) parseRequirement(labelValues bool) (Requirement, error) {
+	if p.is("!") {
+		p.next()
+		key, offset, err := p.ident("key", false)
+		if err != nil {
+			return Requirement{}, err
+		}
+		if err := p.validateKey(key, offset); err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: OperatorDoesNotExist}, nil
+	}
+	key, offset, err := p.ident("key", false)
+	if err != nil {
+		return Requirement{}, err
+	}
+	return p.parseRequirementOf(key, offset, labelValues)
+}
+
+// parseRequirementOf parses the operator and values of the requirement for the already consumed key
+func (p *targetsParser) parseRequirementOf(key string, offset int, labelValues bool) (Requirement, error) {
+	if err := p.validateKey(key, offset); err != nil {
+		return Requirement{}, err
+	}
+	if p.err != nil {
+		return Requirement{}, p.err
+	}
+
+	requirement := Requirement{Key: key}
+	switch {
+	case p.is("=") || p.is("=="):
+		requirement.Operator = OperatorEquals
+	case p.is("!="):
+		requirement.Operator = OperatorNotEquals
+	case p.tok.kind == tokenIdent && (p.tok.value == "in" || p.tok.value == "notin"):
+		requirement.Operator = Operator(p.tok.value)
+	case p.tok.kind == tokenIdent && p.tok.value == "exists":
+		p.next()
+		requirement.Operator = OperatorExists
+		return requirement, nil
+	default:
+		return requirement, p.errorf(p.tok.offset, "expected operator (=, ==, !=, in, notin, exists) after %q, found %s", key, p.describe())
+	}
+	p.next()
+
+	if requirement.Operator == OperatorEquals || requirement.Operator == OperatorNotEquals {
+		value, err := p.parseValue(labelValues)
+		if err != nil {
+			return requirement, err
+		}
+		requirement.Values = []string{value}
+		return requirement, nil
+	}
+
+	if err := p.expect("("); err != nil {
+		return requirement, err
+	}
+	for {
+		value, err := p.parseValue(labelValues)
+		if err != nil {
+			return requirement, err
+		}
+		requirement.Values = append(requirement.Values, value)
+		if !p.is(",") {
+			break
+		}
+		p.next()
+	}
+	return requirement, p.expect(")")
+}
+
+func (p *targetsParser) parseValue(labelValue bool) (string, error) {
+	value, offset, err := p.ident("value", true)
+	if err != nil {
+		return "", err
+	}
+	if labelValue {
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return "", p.errorf(offset, "invalid value %q: %s", value, strings.Join(errs, ", "))
+		}
+	}
+	return value, nil
+}
+
+func (p *targetsParser) validateKey(key string, offset int) error {
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return p.errorf(offset, "invalid key %q: %s", key, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
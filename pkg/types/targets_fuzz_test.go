@@ -0,0 +1,166 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestParseTargets(t *testing.T) {
+	testCases := map[string]struct {
+		targets  string
+		expected []AppDetails
+	}{
+		"labels": {
+			targets:  "deployment:default:[app=nginx]",
+			expected: []AppDetails{{Kind: "deployment", Namespace: "default", Labels: []string{"app=nginx"}}},
+		},
+		"or'ed labels": {
+			targets:  "deployment:default:[app=nginx,app.kubernetes.io/name==redis]",
+			expected: []AppDetails{{Kind: "deployment", Namespace: "default", Labels: []string{"app=nginx", "app.kubernetes.io/name=redis"}}},
+		},
+		"names": {
+			targets: "pod:default:[nginx-0, nginx-1];statefulset:kafka:[kafka]",
+			expected: []AppDetails{
+				{Kind: "pod", Namespace: "default", Names: []string{"nginx-0", "nginx-1"}},
+				{Kind: "statefulset", Namespace: "kafka", Names: []string{"kafka"}},
+			},
+		},
+		"empty list": {
+			targets:  "KIND:litmus:[]",
+			expected: []AppDetails{{Kind: "KIND", Namespace: "litmus"}},
+		},
+		"set based labels": {
+			targets:  "deployment:default:[app in (nginx,redis)&tier notin (cache)&canary exists&!legacy&env!=dev]",
+			expected: []AppDetails{{Kind: "deployment", Namespace: "default", Labels: []string{"app in (nginx,redis),tier notin (cache),canary,!legacy,env!=dev"}}},
+		},
+		"clauses": {
+			targets: `deployment:team-*:[app=nginx]:fields{node=node-1,phase in (Running,Pending)}:annotations{team="payments/core"}:nslabels{env=prod}`,
+			expected: []AppDetails{{
+				Kind:            "deployment",
+				Namespace:       "team-*",
+				Labels:          []string{"app=nginx"},
+				Fields:          Requirements{{Key: FieldNode, Operator: OperatorEquals, Values: []string{"node-1"}}, {Key: FieldPhase, Operator: OperatorIn, Values: []string{"Running", "Pending"}}},
+				Annotations:     Requirements{{Key: "team", Operator: OperatorEquals, Values: []string{"payments/core"}}},
+				NamespaceLabels: Requirements{{Key: "env", Operator: OperatorEquals, Values: []string{"prod"}}},
+			}},
+		},
+		"without list": {
+			targets:  "deployment:*:nslabels{env exists}",
+			expected: []AppDetails{{Kind: "deployment", Namespace: "*", NamespaceLabels: Requirements{{Key: "env", Operator: OperatorExists}}}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			targets, err := ParseTargets(tc.targets)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, targets)
+		})
+	}
+}
+
+func TestParseTargetsErrors(t *testing.T) {
+	testCases := map[string]struct {
+		targets string
+		offset  int
+		reason  string
+	}{
+		"missing namespace": {targets: "deployment", offset: 10, reason: `expected ":", found end of input`},
+		"missing list":      {targets: "deployment:default:", offset: 19, reason: "expected clause, found end of input"},
+		"unterminated list": {targets: "deployment:default:[app=nginx", offset: 29, reason: `expected "]", found end of input`},
+		"mixed list":        {targets: "deployment:default:[nginx,app=nginx]", offset: 19, reason: "names and label selectors can't be mixed"},
+		"missing operator":  {targets: "deployment:default:[app&tier=cache]", offset: 23, reason: `expected operator`},
+		"invalid key":       {targets: "deployment:default:[-app=nginx]", offset: 20, reason: `invalid key "-app"`},
+		"invalid value":     {targets: `deployment:default:[app="nginx proxy"]`, offset: 24, reason: `invalid value "nginx proxy"`},
+		"unknown clause":    {targets: "deployment:default:[app=nginx]:labels{app=nginx}", offset: 31, reason: `unknown clause "labels"`},
+		"unknown field":     {targets: "deployment:default:[app=nginx]:fields{ip=10.0.0.1}", offset: 38, reason: `unsupported field "ip"`},
+		"empty set":         {targets: "deployment:default:[app in ()]", offset: 28, reason: "expected value"},
+		"trailing target":   {targets: "deployment:default:[app=nginx];", offset: 31, reason: "expected kind"},
+		"unexpected char":   {targets: "deployment:default:[app=nginx]#", offset: 30, reason: "unexpected character"},
+		"unterminated":      {targets: `deployment:default:[app=nginx]:annotations{team="payments}`, offset: 48, reason: "unterminated quoted string"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			targets, err := ParseTargets(tc.targets)
+			require.Error(t, err)
+			assert.Nil(t, targets)
+
+			var syntaxErr *TargetsSyntaxError
+			require.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, tc.offset, syntaxErr.Offset)
+			assert.Contains(t, syntaxErr.Reason, tc.reason)
+		})
+	}
+}
+
+func TestAppDetailsMatches(t *testing.T) {
+	targets, err := ParseTargets(`deployment:team-*:[app=nginx]:fields{node!=node-2,phase=Running}:annotations{!skip}:nslabels{env in (prod,staging)}`)
+	require.NoError(t, err)
+	target := targets[0]
+
+	assert.True(t, target.IsNamespacePattern())
+	assert.True(t, target.MatchesNamespace("team-a", map[string]string{"env": "prod"}))
+	assert.False(t, target.MatchesNamespace("team-a", map[string]string{"env": "dev"}))
+	assert.False(t, target.MatchesNamespace("default", map[string]string{"env": "prod"}))
+
+	pod := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"team": "payments"}},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	assert.True(t, target.MatchesPod(pod))
+	pod.Annotations["skip"] = "true"
+	assert.False(t, target.MatchesPod(pod))
+}
+
+func FuzzParseTargets(f *testing.F) {
+	testCases := []string{
+		"deployment:default:[app=nginx]",
+		"pod:default:[nginx-0,nginx-1];KIND:litmus:[]",
+		"deployment:team-*:[app in (nginx,redis)&!legacy]:fields{phase=Running}:nslabels{env=prod}",
+		`deployment:default:[app=nginx]:annotations{team="payments"}`,
+		"deployment:default:[app",
+	}
+	for _, tc := range testCases {
+		f.Add(tc)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		targets, err := ParseTargets(input)
+		if err != nil {
+			var syntaxErr *TargetsSyntaxError
+			require.True(t, errors.As(err, &syntaxErr))
+			require.GreaterOrEqual(t, syntaxErr.Offset, 0)
+			require.LessOrEqual(t, syntaxErr.Offset, len(input))
+			require.Nil(t, targets)
+			return
+		}
+		if strings.TrimSpace(input) == "" {
+			require.Nil(t, targets)
+			return
+		}
+		require.NotEmpty(t, targets)
+		for _, target := range targets {
+			require.NotEmpty(t, target.Kind)
+			require.NotEmpty(t, target.Namespace)
+			require.False(t, target.Labels != nil && target.Names != nil)
+			// the label selectors should be valid kubernetes label selectors
+			for _, label := range target.Labels {
+				_, err := labels.Parse(label)
+				require.NoError(t, err, label)
+			}
+			if target.NamespaceLabels != nil {
+				_, err := labels.Parse(target.NamespaceLabels.String())
+				require.NoError(t, err)
+			}
+		}
+	})
+}
@@ -3,13 +3,17 @@ package types
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/litmuschaos/litmus-go/pkg/utils/stringutils"
 	"github.com/palantir/stacktrace"
@@ -36,6 +40,10 @@ const (
 	Summary string = "Summary"
 	// ChaosInject this stage refer to the main chaos injection
 	ChaosInject string = "ChaosInject"
+	// AwaitingApproval this stage refer to the wait for the manual approval
+	AwaitingApproval string = "AwaitingApproval"
+	// Approved this stage refer to the approval of a gate
+	Approved string = "Approved"
 	// AwaitedVerdict marked the start of test
 	AwaitedVerdict string = "Awaited"
 	// PassVerdict marked the verdict as passed in the end of experiment
@@ -54,6 +62,7 @@ const (
 	PreChaosPhase    ExperimentPhase = "PreChaos"
 	PostChaosPhase   ExperimentPhase = "PostChaos"
 	ChaosInjectPhase ExperimentPhase = "ChaosInject"
+	BaselinePhase    ExperimentPhase = "Baseline"
 )
 
 // ResultDetails is for collecting all the chaos-result-related details
@@ -91,6 +100,18 @@ type ProbeDetails struct {
 	RunCount               int
 	Stopped                bool
 	Timeouts               ProbeTimeouts
+	// MeasuredValue is the last value measured by the probe
+	MeasuredValue string
+	// Timeline contains the evaluations of the probe, in the order of execution
+	Timeline []ProbeEvaluation
+}
+
+// ProbeEvaluation contains the outcome of a single execution of the probe
+type ProbeEvaluation struct {
+	Phase         string
+	Time          time.Time
+	Verdict       v1alpha1.ProbeVerdict
+	MeasuredValue string
 }
 
 type ProbeTimeouts struct {
@@ -121,6 +142,14 @@ type ChaosDetails struct {
 	Timeout              int
 	Delay                int
 	AppDetail            []AppDetails
+	TargetsError         error
+	Sampling             Sampling
+	NodeSelection        NodeSelection
+	Sequencing           Sequencing
+	Approval             Approval
+	Baseline             Baseline
+	Seed                 int64
+	Random               *rand.Rand
 	ChaosDuration        int
 	JobCleanupPolicy     string
 	ProbeImagePullPolicy string
@@ -133,10 +162,62 @@ type ChaosDetails struct {
 	ImagePullSecrets     []corev1.LocalObjectReference
 	Labels               map[string]string
 	Phase                ExperimentPhase
+	PhaseStartTime       time.Time
+	PhaseDurations       map[ExperimentPhase]time.Duration
+	PhaseTimeline        []PhaseRecord
+	StepResults          []StepResult
+	FaultResults         []FaultResult
+	BaselineResults      []BaselineResult
 	ProbeContext         ProbeContext
 	SideCar              []SideCar
 }
 
+// PhaseRecord contains the start and end time of an experiment phase
+type PhaseRecord struct {
+	Phase     ExperimentPhase
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// StepResult contains the outcome of a step of the stepped intensity
+type StepResult struct {
+	Index     int
+	Parameter string
+	Value     string
+	Tolerated bool
+	Reason    string
+}
+
+// GetHighestToleratedStep returns the last step, which was tolerated by the system
+// it returns nil if none of the steps were tolerated
+func (chaosDetails *ChaosDetails) GetHighestToleratedStep() *StepResult {
+	var highest *StepResult
+	for i := range chaosDetails.StepResults {
+		if !chaosDetails.StepResults[i].Tolerated {
+			break
+		}
+		highest = &chaosDetails.StepResults[i]
+	}
+	return highest
+}
+
+// FaultResult contains the outcome of a fault of the scenario
+type FaultResult struct {
+	Name  string
+	Fault string
+	// StartOffset and Duration are the position of the fault in the timeline of the scenario, in seconds
+	StartOffset int
+	Duration    int
+	Status      string
+	Reason      string
+}
+
+const (
+	FaultCompleted string = "Completed"
+	FaultFailed    string = "Failed"
+	FaultSkipped   string = "Skipped"
+)
+
 type SideCar struct {
 	ENV             []corev1.EnvVar
 	Image           string
@@ -155,6 +236,8 @@ type ParentResource struct {
 type ProbeContext struct {
 	Ctx        context.Context
 	CancelFunc context.CancelFunc
+	// OnChaosStarted is set once the onchaos probes are triggered, they run for the entire chaos duration
+	OnChaosStarted bool
 }
 
 // AppDetails contains all the application related envs
@@ -163,44 +246,191 @@ type AppDetails struct {
 	Labels    []string
 	Kind      string
 	Names     []string
+	// Fields, Annotations and NamespaceLabels contain the additional requirements, which should be satisfied by the targets
+	Fields          Requirements
+	Annotations     Requirements
+	NamespaceLabels Requirements
 }
 
-func GetTargets(targets string) []AppDetails {
-	var result []AppDetails
-	if targets == "" {
-		return nil
+const (
+	// SamplingRandom selects the targets uniformly at random
+	SamplingRandom = "random"
+	// SamplingPerNode selects at most one target pod per node
+	SamplingPerNode = "per-node"
+	// SamplingSpreadZones spreads the targets evenly across the zones
+	SamplingSpreadZones = "spread-zones"
+	// SamplingSingleZone selects all the targets of a single zone, to simulate the zone outage
+	SamplingSingleZone = "single-zone"
+	// SamplingPerWorkload selects at most MaxPerWorkload target pods per workload
+	SamplingPerWorkload = "per-workload"
+	// SamplingLeader selects the target pods matching the leader selector
+	SamplingLeader = "leader"
+)
+
+// Sampling contains the details of the strategy used to sample the targets
+type Sampling struct {
+	Strategy       string
+	MaxPerWorkload int
+	LeaderSelector string
+}
+
+// GetStrategy returns the sampling strategy along with its parameters
+func (sampling Sampling) GetStrategy() string {
+	switch sampling.Strategy {
+	case SamplingPerWorkload:
+		return fmt.Sprintf("%s(max=%d)", sampling.Strategy, sampling.MaxPerWorkload)
+	case SamplingLeader:
+		return fmt.Sprintf("%s(%s)", sampling.Strategy, sampling.LeaderSelector)
 	}
-	t := strings.Split(targets, ";")
-	for _, k := range t {
-		val := strings.Split(strings.TrimSpace(k), ":")
-		data := AppDetails{
-			Kind:      val[0],
-			Namespace: val[1],
-		}
-		if strings.Contains(val[2], "=") {
-			data.Labels = parse(val[2])
-		} else {
-			data.Names = parse(val[2])
+	return sampling.Strategy
+}
+
+const (
+	// MostAllocatable orders the target nodes by their allocatable capacity, in the descending order
+	MostAllocatable = "most-allocatable"
+	// LeastAllocatable orders the target nodes by their allocatable capacity, in the ascending order
+	LeastAllocatable = "least-allocatable"
+)
+
+// NodeSelection contains the strategies used to select the target nodes of the node level experiments
+type NodeSelection struct {
+	// WorkloadTargets selects the nodes hosting the pods of the workloads, in the TARGETS format
+	WorkloadTargets     string
+	ExcludeControlPlane bool
+	Zones               []string
+	CapacityOrder       string
+	MaxPerNodePool      int
+	NodePoolLabel       string
+}
+
+// IsSet returns true if any of the node selection strategies is provided
+func (selection NodeSelection) IsSet() bool {
+	return selection.WorkloadTargets != "" || selection.ExcludeControlPlane || len(selection.Zones) != 0 ||
+		selection.CapacityOrder != "" || selection.MaxPerNodePool > 0
+}
+
+const (
+	// SequenceSerial injects the chaos in the targets one by one
+	SequenceSerial = "serial"
+	// SequenceParallel injects the chaos in all the targets at once
+	SequenceParallel = "parallel"
+	// SequenceRolling injects the chaos in batches of the targets, with a wait and a health check between the batches
+	SequenceRolling = "rolling"
+	// SequenceRandom injects the chaos either in serial or in parallel, picked at random
+	// it keeps the meaning it had before the other sequences were added, for the backward compatibility
+	// of the existing experiments, the serial injection in a random order is provided by SequenceShuffled
+	SequenceRandom = "random"
+	// SequenceShuffled injects the chaos in the targets one by one, in a shuffled order
+	SequenceShuffled = "shuffled"
+	// SequenceStaggered injects the chaos in all the targets, with random start offsets
+	SequenceStaggered = "staggered"
+)
+
+// Sequencing contains the tunables of the rolling and staggered sequences
+type Sequencing struct {
+	// BatchSize is the number of targets in every batch of the rolling sequence
+	BatchSize int
+	// BatchInterval is the wait in seconds between the batches of the rolling sequence
+	BatchInterval int
+	// MaxStartOffset is the upper limit in seconds of the start offsets of the staggered sequence
+	MaxStartOffset int
+}
+
+const (
+	// ApprovalGateInject waits for the approval before injecting the chaos
+	ApprovalGateInject = "inject"
+	// ApprovalGateRevert waits for the approval before reverting the chaos
+	ApprovalGateRevert = "revert"
+)
+
+// Approval contains the tunables of the manual approval gates
+type Approval struct {
+	// Gates are the gates, which wait for the approval before moving ahead
+	Gates []string
+	// Timeout is the wait in seconds for the approval of every gate
+	Timeout int
+	// ConfigMap grants the approval, instead of the chaosengine, if provided
+	ConfigMap string
+}
+
+// IsEnabled returns true if the gate waits for the approval
+func (approval Approval) IsEnabled(gate string) bool {
+	for _, g := range approval.Gates {
+		if strings.EqualFold(strings.TrimSpace(g), gate) {
+			return true
 		}
-		result = append(result, data)
 	}
-	return result
+	return false
 }
 
-func parse(val string) []string {
-	val = strings.TrimSpace(val)
-	val = strings.TrimPrefix(val, "[")
-	val = strings.TrimSuffix(val, "]")
-	if val == "" {
-		return nil
+// TimeoutOf returns the wait in seconds for the approval of the gate, it is zero if the gate isn't enabled
+func (approval Approval) TimeoutOf(gate string) int {
+	if !approval.IsEnabled(gate) {
+		return 0
 	}
-	return strings.Split(val, ",")
+	return approval.Timeout
+}
+
+// Baseline contains the tunables of the steady state baseline, which samples the probes before the chaos
+type Baseline struct {
+	// Samples is the number of samples of every probe, the baseline is skipped if it is zero
+	Samples int
+	// Interval is the wait in seconds between the samples
+	Interval int
+	// MaxFlakiness is the percentage of the failed samples of a probe, which is tolerated in the steady state
+	MaxFlakiness float64
+}
+
+// BaselineResult contains the statistics of the samples of a probe, in the steady state baseline
+type BaselineResult struct {
+	Probe   string
+	Samples int
+	Failed  int
+	// Flakiness is the percentage of the failed samples
+	Flakiness float64
+	// Measured is the number of samples with a numeric measured value, Min, Max and Mean are computed from them
+	Measured int
+	Min      float64
+	Max      float64
+	Mean     float64
 }
 
 // InitialiseChaosVariables initialise all the global variables
 func InitialiseChaosVariables(chaosDetails *ChaosDetails) {
 	targets := Getenv("TARGETS", "")
-	chaosDetails.AppDetail = GetTargets(strings.TrimSpace(targets))
+	chaosDetails.AppDetail, chaosDetails.TargetsError = ParseTargets(strings.TrimSpace(targets))
+	if chaosDetails.TargetsError != nil {
+		log.Errorf("Unable to parse the TARGETS env, err: %v", chaosDetails.TargetsError)
+	}
+
+	SetRandomSeed(chaosDetails, getRandomSeed())
+	log.Infof("[Info]: The random seed of the experiment is %d, set the %s env to replay its random choices", chaosDetails.Seed, RandomSeedEnv)
+
+	chaosDetails.Sampling.Strategy = Getenv("TARGET_SAMPLING_STRATEGY", SamplingRandom)
+	chaosDetails.Sampling.MaxPerWorkload, _ = strconv.Atoi(Getenv("TARGET_SAMPLING_MAX_PER_WORKLOAD", "1"))
+	chaosDetails.Sampling.LeaderSelector = Getenv("TARGET_SAMPLING_LEADER_SELECTOR", "")
+
+	chaosDetails.NodeSelection.WorkloadTargets = Getenv("NODE_WORKLOAD_TARGETS", "")
+	chaosDetails.NodeSelection.ExcludeControlPlane, _ = strconv.ParseBool(Getenv("EXCLUDE_CONTROL_PLANE_NODES", "false"))
+	if zones := Getenv("NODE_ZONES", ""); zones != "" {
+		chaosDetails.NodeSelection.Zones = strings.Split(zones, ",")
+	}
+	chaosDetails.NodeSelection.CapacityOrder = Getenv("NODE_CAPACITY_ORDER", "")
+	chaosDetails.NodeSelection.MaxPerNodePool, _ = strconv.Atoi(Getenv("MAX_NODES_PER_POOL", "0"))
+	chaosDetails.NodeSelection.NodePoolLabel = Getenv("NODE_POOL_LABEL", "")
+
+	chaosDetails.Sequencing.BatchSize, _ = strconv.Atoi(Getenv("ROLLING_BATCH_SIZE", "1"))
+	chaosDetails.Sequencing.BatchInterval, _ = strconv.Atoi(Getenv("ROLLING_BATCH_INTERVAL", "0"))
+	chaosDetails.Sequencing.MaxStartOffset, _ = strconv.Atoi(Getenv("STAGGER_MAX_OFFSET", "0"))
+
+	if gates := Getenv("APPROVAL_GATES", ""); gates != "" {
+		chaosDetails.Approval.Gates = strings.Split(gates, ",")
+	}
+	chaosDetails.Approval.Timeout, _ = strconv.Atoi(Getenv("APPROVAL_TIMEOUT", "3600"))
+	chaosDetails.Approval.ConfigMap = Getenv("APPROVAL_CONFIGMAP", "")
+	chaosDetails.Baseline.Samples, _ = strconv.Atoi(Getenv("BASELINE_SAMPLES", "0"))
+	chaosDetails.Baseline.Interval, _ = strconv.Atoi(Getenv("BASELINE_INTERVAL", "10"))
+	chaosDetails.Baseline.MaxFlakiness, _ = strconv.ParseFloat(Getenv("BASELINE_MAX_FLAKINESS", "0"), 64)
 
 	chaosDetails.ChaosNamespace = Getenv("CHAOS_NAMESPACE", "")
 	chaosDetails.ChaosPodName = Getenv("POD_NAME", "")
@@ -218,10 +448,35 @@ func InitialiseChaosVariables(chaosDetails *ChaosDetails) {
 	chaosDetails.ParentsResources = []ParentResource{}
 	chaosDetails.Targets = []v1alpha1.TargetDetails{}
 	chaosDetails.Phase = PreChaosPhase
+	chaosDetails.PhaseStartTime = time.Now()
+	chaosDetails.PhaseDurations = map[ExperimentPhase]time.Duration{}
 	chaosDetails.ProbeContext.Ctx, chaosDetails.ProbeContext.CancelFunc = context.WithCancel(context.Background())
 	chaosDetails.Labels = map[string]string{}
 }
 
+// SetExperimentPhase moves the experiment to the given phase
+// it records the time spent in the current phase, before switching to the new one
+func SetExperimentPhase(chaosDetails *ChaosDetails, phase ExperimentPhase) {
+	EndExperimentPhase(chaosDetails)
+	chaosDetails.Phase = phase
+	chaosDetails.PhaseStartTime = time.Now()
+	log.SetPhase(string(phase))
+}
+
+// EndExperimentPhase records the time spent in the current phase
+func EndExperimentPhase(chaosDetails *ChaosDetails) {
+	if chaosDetails.PhaseStartTime.IsZero() {
+		return
+	}
+	if chaosDetails.PhaseDurations == nil {
+		chaosDetails.PhaseDurations = map[ExperimentPhase]time.Duration{}
+	}
+	now := time.Now()
+	chaosDetails.PhaseDurations[chaosDetails.Phase] += now.Sub(chaosDetails.PhaseStartTime)
+	chaosDetails.PhaseTimeline = append(chaosDetails.PhaseTimeline, PhaseRecord{Phase: chaosDetails.Phase, StartTime: chaosDetails.PhaseStartTime, EndTime: now})
+	chaosDetails.PhaseStartTime = time.Time{}
+}
+
 // SetResultAttributes initialise all the chaos result ENV
 func SetResultAttributes(resultDetails *ResultDetails, chaosDetails ChaosDetails) {
 	resultDetails.Verdict = "Awaited"
@@ -283,14 +538,38 @@ func GetChaosResultVerdictEvent(verdict v1alpha1.ResultVerdict) (string, string)
 }
 
 // Getenv fetch the env and set the default value, if any
+// the env overridden by WithEnv takes precedence over the env of the process
 func Getenv(key string, defaultValue string) string {
 	value := os.Getenv(key)
+	if overrides := envOverrides.Load(); overrides != nil {
+		if override, ok := (*overrides)[key]; ok {
+			value = override
+		}
+	}
 	if value == "" {
 		value = defaultValue
 	}
 	return value
 }
 
+var (
+	// envOverrides contains the env, which overrides the env of the process inside WithEnv
+	envOverrides atomic.Pointer[map[string]string]
+	// envOverridesMu serialises the WithEnv calls
+	envOverridesMu sync.Mutex
+)
+
+// WithEnv runs fn with the given env overriding the env of the process, for the reads through Getenv
+// it is used to read the tunables of the faults of a scenario, each fault has its own env on top of the scenario env
+func WithEnv(env map[string]string, fn func()) {
+	envOverridesMu.Lock()
+	defer envOverridesMu.Unlock()
+
+	envOverrides.Store(&env)
+	defer envOverrides.Store(nil)
+	fn()
+}
+
 // GetChaosEngine fetches the chaosengine instance
 func GetChaosEngine(chaosDetails *ChaosDetails, clients clients.ClientSets) (*v1alpha1.ChaosEngine, error) {
 	var engine *v1alpha1.ChaosEngine
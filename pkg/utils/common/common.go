@@ -2,26 +2,27 @@ package common
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/palantir/stacktrace"
 	"math/rand"
-	"os"
 	"os/exec"
-	"os/signal"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
-	"syscall"
-	"time"
 
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -31,12 +32,18 @@ type ENVDetails struct {
 }
 
 // WaitForDuration waits for the given time duration (in seconds)
-func WaitForDuration(duration int) {
-	time.Sleep(time.Duration(duration) * time.Second)
+// it returns early if the run is cancelled, and returns the abort error if it is aborted
+// the callers should unwind on the error, the chaos is reverted by the revert phase before the process exits
+func WaitForDuration(duration int) error {
+	if err := WaitForDurationWithContext(rootCtx, duration); err != nil && Aborted() {
+		log.Infof("[Wait]: Wait interrupted, err: %v", err)
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: fmt.Sprintf("experiment aborted while waiting, err: %v", err)}
+	}
+	return nil
 }
 
 // RandomInterval wait for the random interval lies between lower & upper bounds
-func RandomInterval(interval string) error {
+func RandomInterval(interval string, chaosDetails *types.ChaosDetails) error {
 	re := regexp.MustCompile(`^\d+(-\d+)?$`)
 	if re.MatchString(interval) == false {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "could not parse CHAOS_INTERVAL env, bad input"}
@@ -53,38 +60,52 @@ func RandomInterval(interval string) error {
 	default:
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "could not parse CHAOS_INTERVAL env, invalid format"}
 	}
-	rand.Seed(time.Now().UnixNano())
 	if upperBound < 1 {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: "invalid CHAOS_INTERVAL env value, value below lower limit"}
 	}
-	waitTime := lowerBound + rand.Intn(upperBound-lowerBound)
+	waitTime := lowerBound + chaosDetails.GetRandom().Intn(upperBound-lowerBound)
 	log.Infof("[Wait]: Wait for the random chaos interval %vs", waitTime)
-	WaitForDuration(waitTime)
-	return nil
+	return WaitForDuration(waitTime)
 }
 
 // AbortWatcher continuously watch for the abort signals
 // it will update chaosresult w/ failed step and create an abort event, if it received abort signal during chaos
 func AbortWatcher(expname string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
 	AbortWatcherWithoutExit(expname, clients, resultDetails, chaosDetails, eventsDetails)
-	os.Exit(1)
+	if Aborted() {
+		Exit(1)
+	}
 }
 
-// AbortWatcherWithoutExit continuously watch for the abort signals
+// AbortWatcherWithoutExit continuously watch for the abort of the root context
+// it waits for the pending reverts before recording the abort, the process is terminated by the revert phase
 func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
 
-	// signChan channel is used to transmit signal notifications.
-	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	watcherDone := abortWatchers.add()
+	defer watcherDone()
+
+	// waiting until the abort signal or the deadline is received
+	<-rootCtx.Done()
+	if !Aborted() {
+		return
+	}
+
+	log.Infof("[Chaos]: Chaos Experiment Abortion started, err: %v", AbortCause())
 
-	// waiting until the abort signal received
-	<-signChan
+	// waiting for the pending reverts, before marking the experiment as stopped
+	WaitForReverts()
 
-	log.Info("[Chaos]: Chaos Experiment Abortion started because of terminated signal received")
 	// updating the chaosresult after stopped
-	failStep := "Chaos injection stopped!"
-	types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, cerrors.ErrorTypeExperimentAborted)
+	switch {
+	case errors.Is(AbortCause(), ErrFaultFailed):
+		// the failure of a fault fails the whole scenario, once the other faults are reverted
+		failStep, errorCode := cerrors.GetRootCauseAndErrorCode(errors.Unwrap(AbortCause()), string(chaosDetails.Phase))
+		types.SetResultAfterCompletion(resultDetails, v1alpha1.ResultVerdictError, v1alpha1.ResultPhaseError, failStep, errorCode)
+	case errors.Is(AbortCause(), ErrDeadlineExceeded):
+		types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", "Chaos injection stopped, experiment deadline exceeded!", cerrors.ErrorTypeExperimentAborted)
+	default:
+		types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", "Chaos injection stopped!", cerrors.ErrorTypeExperimentAborted)
+	}
 	if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
 		log.Errorf("[ABORT]: Failed to update result, err: %v", err)
 	}
@@ -107,15 +128,14 @@ func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultD
 }
 
 // FilterBasedOnPercentage return the slice of list based on the the provided percentage
-func FilterBasedOnPercentage(percentage int, list []string) []string {
+func FilterBasedOnPercentage(percentage int, list []string, chaosDetails *types.ChaosDetails) []string {
 
 	var finalList []string
 	newInstanceListLength := math.Maximum(1, math.Adjustment(percentage, len(list)))
-	rand.Seed(time.Now().UnixNano())
 
 	// it will generate the random instanceList
 	// it starts from the random index and choose requirement no of volumeID next to that index in a circular way.
-	index := rand.Intn(len(list))
+	index := chaosDetails.GetRandom().Intn(len(list))
 	for i := 0; i < newInstanceListLength; i++ {
 		finalList = append(finalList, list[index])
 		index = (index + 1) % len(list)
@@ -184,18 +204,17 @@ func GetStatusMessage(defaultCheck bool, defaultMsg, probeStatus string) string
 }
 
 // GetRandomSequence will gives a random value for sequence
-func GetRandomSequence(sequence string) string {
+func GetRandomSequence(sequence string, chaosDetails *types.ChaosDetails) string {
 	if strings.ToLower(sequence) == "random" {
-		rand.Seed(time.Now().UnixNano())
 		seq := []string{"serial", "parallel"}
-		randomIndex := rand.Intn(len(seq))
+		randomIndex := chaosDetails.GetRandom().Intn(len(seq))
 		return seq[randomIndex]
 	}
 	return sequence
 }
 
 // ValidateRange validates the given range of numbers
-func ValidateRange(a string) string {
+func ValidateRange(a string, chaosDetails *types.ChaosDetails) string {
 	var lb, ub int
 	intervals := strings.Split(a, "-")
 
@@ -205,7 +224,7 @@ func ValidateRange(a string) string {
 	case 2:
 		lb, _ = strconv.Atoi(intervals[0])
 		ub, _ = strconv.Atoi(intervals[1])
-		return strconv.Itoa(getRandomValue(lb, ub))
+		return strconv.Itoa(getRandomValue(lb, ub, chaosDetails.GetRandom()))
 	default:
 		log.Errorf("unable to parse the value, please provide in valid format")
 		return "0"
@@ -213,9 +232,8 @@ func ValidateRange(a string) string {
 }
 
 // getRandomValue gives a random value between two integers
-func getRandomValue(a, b int) int {
-	rand.Seed(time.Now().Unix())
-	return (a + rand.Intn(b-a+1))
+func getRandomValue(a, b int, random *rand.Rand) int {
+	return (a + random.Intn(b-a+1))
 }
 
 // SubStringExistsInSlice checks the existence of sub string in slice
@@ -245,6 +263,14 @@ func RunBashCommand(command string, failMsg string, source string) error {
 	return RunCLICommands(cmd, source, "", failMsg, cerrors.ErrorTypeHelper)
 }
 
+// RunBashCommandWithContext runs the bash command inside a child span of the context
+func RunBashCommandWithContext(ctx context.Context, command string, failMsg string, source string) (err error) {
+	_, span := telemetry.StartSpan(ctx, "RunBashCommand", attribute.String("process.command_line", command))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return RunBashCommand(command, failMsg, source)
+}
+
 func RunCLICommands(cmd *exec.Cmd, source, target, failMsg string, errorCode cerrors.ErrorType) error {
 	var out, stdErr bytes.Buffer
 	cmd.Stdout = &out
@@ -44,7 +44,7 @@ func FuzzRandomInterval(f *testing.F) {
 		}
 
 		if !skip {
-			err := RandomInterval(interval)
+			err := RandomInterval(interval, &types.ChaosDetails{})
 			if re.MatchString(interval) == false {
 				assert.Error(t, err, "{\"errorCode\":\"GENERIC_ERROR\",\"reason\":\"could not parse CHAOS_INTERVAL env, bad input\"}")
 				return
@@ -161,7 +161,7 @@ func FuzzGetRandomSequence(f *testing.F) {
 	f.Add("random")
 
 	f.Fuzz(func(t *testing.T, sequence string) {
-		val := GetRandomSequence(sequence)
+		val := GetRandomSequence(sequence, &types.ChaosDetails{})
 		if strings.ToLower(sequence) == "random" {
 			require.Contains(t, []string{"serial", "parallel"}, val)
 			return
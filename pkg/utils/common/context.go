@@ -0,0 +1,204 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+)
+
+var (
+	// ErrChaosAborted is the cancellation cause of the root context when a termination signal is received
+	ErrChaosAborted = errors.New("chaos aborted by the termination signal")
+	// ErrDeadlineExceeded is the cancellation cause of the root context when the experiment runs past its deadline
+	ErrDeadlineExceeded = errors.New("chaos experiment deadline exceeded")
+	// ErrFaultFailed is the cancellation cause of the root context when a fault of the scenario fails
+	ErrFaultFailed = errors.New("chaos aborted as a fault of the scenario failed")
+)
+
+// revertPhaseTimeout is the upper bound for the revert phase, after which the process exits anyway
+const revertPhaseTimeout = 5 * time.Minute
+
+var (
+	// rootCtx is the context of the whole run, it is cancelled upon abort or deadline
+	rootCtx = context.Background()
+	// rootCancel cancels the root context with the given cause
+	rootCancel context.CancelCauseFunc = func(error) {}
+	// pendingReverts tracks the reverts, which must complete before the abort is recorded
+	pendingReverts = &phaseTracker{}
+	// abortWatchers tracks the abort watchers, which must complete before the process exits
+	abortWatchers = &phaseTracker{}
+)
+
+// phaseTracker tracks the in-flight members of an abort phase
+// unlike sync.WaitGroup, members can be added while someone is waiting and the wait can time out
+type phaseTracker struct {
+	mu      sync.Mutex
+	pending int
+	idle    chan struct{}
+}
+
+// add registers a member, the returned function marks it as completed
+func (t *phaseTracker) add() func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pending == 0 {
+		t.idle = make(chan struct{})
+	}
+	t.pending++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.pending--
+			if t.pending == 0 {
+				close(t.idle)
+			}
+		})
+	}
+}
+
+// wait blocks until all the members are completed or the timeout is elapsed
+func (t *phaseTracker) wait(timeout time.Duration) {
+	t.mu.Lock()
+	if t.pending == 0 {
+		t.mu.Unlock()
+		return
+	}
+	idle := t.idle
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+	case <-time.After(timeout):
+		log.Warn("[Abort]: Timed out while waiting for the revert phase to complete")
+	}
+}
+
+// InitRootContext derives the root context of the run from the parent context.
+// It is cancelled upon SIGINT/SIGTERM or once EXPERIMENT_DEADLINE (in seconds) has elapsed, whichever comes first.
+// Once cancelled, the revert phase runs and the process exits only after all the pending reverts are completed
+func InitRootContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	// signChan channel is used to transmit signal notifications.
+	signChan := make(chan os.Signal, 1)
+	// Catch and relay certain signal(s) to signChan channel.
+	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+
+	if deadline, _ := strconv.Atoi(os.Getenv("EXPERIMENT_DEADLINE")); deadline > 0 {
+		timer := time.AfterFunc(time.Duration(deadline)*time.Second, func() { cancel(ErrDeadlineExceeded) })
+		context.AfterFunc(ctx, func() { timer.Stop() })
+	}
+
+	rootCtx, rootCancel = ctx, cancel
+
+	go func() {
+		select {
+		case <-signChan:
+			cancel(ErrChaosAborted)
+		case <-ctx.Done():
+		}
+		signal.Stop(signChan)
+		if !Aborted() {
+			return
+		}
+		log.Infof("[Abort]: Root context cancelled, err: %v", context.Cause(ctx))
+		Exit(1)
+	}()
+
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// Aborted returns true if the run is aborted, either by the termination signal, the deadline or the failure of a fault
+func Aborted() bool {
+	cause := context.Cause(rootCtx)
+	return errors.Is(cause, ErrChaosAborted) || errors.Is(cause, ErrDeadlineExceeded) || errors.Is(cause, ErrFaultFailed)
+}
+
+// AbortOnFaultFailure aborts the run after the failure of a fault of the scenario.
+// The remaining faults are reverted through the revert phase, in the same way as the termination signal
+func AbortOnFaultFailure(err error) {
+	rootCancel(faultFailure{err: err})
+}
+
+// faultFailure is the cancellation cause of the root context, when a fault of the scenario fails
+// it unwraps to the error of the fault, which is recorded in the chaosresult
+type faultFailure struct {
+	err error
+}
+
+func (f faultFailure) Error() string {
+	return fmt.Sprintf("%v: %v", ErrFaultFailed, f.err)
+}
+
+func (f faultFailure) Is(target error) bool {
+	return target == ErrFaultFailed
+}
+
+func (f faultFailure) Unwrap() error {
+	return f.err
+}
+
+// AbortCause returns the reason behind the abort of the run, if any
+func AbortCause() error {
+	if !Aborted() {
+		return nil
+	}
+	return context.Cause(rootCtx)
+}
+
+// NotifyAbort relays the abort of the run to the given channel.
+// It replaces the per-lib signal notifications, so that the deadline aborts the chaos in the same way as the signals
+func NotifyAbort(ch chan os.Signal) {
+	go func() {
+		<-rootCtx.Done()
+		if Aborted() {
+			ch <- syscall.SIGTERM
+		}
+	}()
+}
+
+// TrackRevert registers a pending revert with the revert phase.
+// The returned function must be called once the revert is completed, it is safe to call it more than once
+func TrackRevert() func() {
+	return pendingReverts.add()
+}
+
+// WaitForReverts blocks until all the pending reverts are completed or the revert phase times out
+func WaitForReverts() {
+	pendingReverts.wait(revertPhaseTimeout)
+}
+
+// Exit terminates the process with the given exit code.
+// If the run is aborted, it waits for the revert phase and the abort watchers to complete before exiting
+// the exit handlers registered with the logger (e.g, the flush of the notifications) are run before the exit
+func Exit(code int) {
+	if Aborted() {
+		deadline := time.Now().Add(revertPhaseTimeout)
+		pendingReverts.wait(time.Until(deadline))
+		abortWatchers.wait(time.Until(deadline))
+	}
+	log.Exit(code)
+}
+
+// WaitForDurationWithContext waits for the given time duration (in seconds) or until the context is cancelled
+func WaitForDurationWithContext(ctx context.Context, duration int) error {
+	timer := time.NewTimer(time.Duration(duration) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
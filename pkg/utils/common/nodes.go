@@ -5,14 +5,13 @@ import (
 	"fmt"
 	"github.com/litmuschaos/litmus-go/pkg/cerrors"
 	"github.com/palantir/stacktrace"
-	"math/rand"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -21,7 +20,7 @@ var err error
 
 // GetNodeList check for the availability of the application node for the chaos execution
 // if the application node is not defined it will derive the random target node list using node affected percentage
-func GetNodeList(nodeNames, nodeLabel string, nodeAffPerc int, clients clients.ClientSets) ([]string, error) {
+func GetNodeList(nodeNames, nodeLabel string, nodeAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, error) {
 
 	var nodeList []string
 	var nodes *apiv1.NodeList
@@ -44,24 +43,39 @@ func GetNodeList(nodeNames, nodeLabel string, nodeAffPerc int, clients clients.C
 		}
 	}
 
-	newNodeListLength := math.Maximum(1, math.Adjustment(nodeAffPerc, len(nodes.Items)))
+	candidates := nodes.Items
+	if chaosDetails.NodeSelection.IsSet() {
+		if candidates, err = filterNodes(candidates, clients, chaosDetails); err != nil {
+			return nil, stacktrace.Propagate(err, "could not filter nodes")
+		}
+	}
+
+	newNodeListLength := math.Maximum(1, math.Adjustment(nodeAffPerc, len(candidates)))
 
-	// it will generate the random nodelist
-	// it starts from the random index and choose requirement no of pods next to that index in a circular way.
-	rand.Seed(time.Now().UnixNano())
-	index := rand.Intn(len(nodes.Items))
-	for i := 0; i < newNodeListLength; i++ {
-		nodeList = append(nodeList, nodes.Items[index].Name)
-		index = (index + 1) % len(nodes.Items)
+	selected, err := selectNodes(candidates, newNodeListLength, chaosDetails)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "could not select nodes")
+	}
+	for _, node := range selected {
+		nodeList = append(nodeList, node.Name)
 	}
 
-	log.Infof("[Chaos]:Number of nodes targeted: %v", strconv.Itoa(newNodeListLength))
+	log.Infof("[Chaos]:Number of nodes targeted: %v", strconv.Itoa(len(nodeList)))
 
 	return nodeList, nil
 }
 
 // GetNodeName will select a random replica of application pod and return the node name of that application pod
-func GetNodeName(namespace, labels, nodeLabel string, clients clients.ClientSets) (string, error) {
+// if the node selection strategies are provided, the node is selected from the matching nodes instead
+func GetNodeName(namespace, labels, nodeLabel string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (string, error) {
+
+	if chaosDetails.NodeSelection.IsSet() {
+		nodeList, err := GetNodeList("", nodeLabel, 0, clients, chaosDetails)
+		if err != nil {
+			return "", err
+		}
+		return nodeList[0], nil
+	}
 
 	switch nodeLabel {
 	case "":
@@ -72,16 +86,14 @@ func GetNodeName(namespace, labels, nodeLabel string, clients clients.ClientSets
 			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", labels, namespace), Reason: "no pod found with matching labels"}
 		}
 
-		rand.Seed(time.Now().Unix())
-		randomIndex := rand.Intn(len(podList.Items))
+		randomIndex := chaosDetails.GetRandom().Intn(len(podList.Items))
 		return podList.Items[randomIndex].Spec.NodeName, nil
 	default:
 		nodeList, err := getNodesByLabels(nodeLabel, clients)
 		if err != nil {
 			return "", stacktrace.Propagate(err, "could not get nodes by labels")
 		}
-		rand.Seed(time.Now().Unix())
-		randomIndex := rand.Intn(len(nodeList.Items))
+		randomIndex := chaosDetails.GetRandom().Intn(len(nodeList.Items))
 		return nodeList.Items[randomIndex].Name, nil
 	}
 }
@@ -0,0 +1,168 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/palantir/stacktrace"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+var (
+	// controlPlaneLabels contains the role labels of the control plane nodes
+	controlPlaneLabels = []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"}
+	// nodePoolLabels contains the node pool labels of the common providers, in the order of preference
+	nodePoolLabels = []string{"cloud.google.com/gke-nodepool", "eks.amazonaws.com/nodegroup", "alpha.eksctl.io/nodegroup-name", "agentpool", "karpenter.sh/nodepool"}
+)
+
+// filterNodes returns the nodes, which satisfy the node selection filters (workload placement, role and zone)
+func filterNodes(nodes []apiv1.Node, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]apiv1.Node, error) {
+	selection := chaosDetails.NodeSelection
+
+	var workloadNodes map[string]bool
+	if selection.WorkloadTargets != "" {
+		var err error
+		if workloadNodes, err = getWorkloadNodes(selection.WorkloadTargets, clients, chaosDetails); err != nil {
+			return nil, err
+		}
+	}
+
+	var filtered []apiv1.Node
+	for _, node := range nodes {
+		switch {
+		case workloadNodes != nil && !workloadNodes[node.Name]:
+		case selection.ExcludeControlPlane && isControlPlaneNode(node):
+		case len(selection.Zones) != 0 && !Contains(getNodeZone(node), selection.Zones):
+		default:
+			filtered = append(filtered, node)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: getNodeSelectionForLogging(selection), Reason: "no node found matching the node selection"}
+	}
+	return filtered, nil
+}
+
+// selectNodes selects the given number of nodes, based on the capacity order and the node pool limit
+// the sampling strategy is used, if neither of them is provided
+func selectNodes(nodes []apiv1.Node, count int, chaosDetails *types.ChaosDetails) ([]apiv1.Node, error) {
+	selection := chaosDetails.NodeSelection
+	// the count can exceed the nodes, if the nodes affected percentage is above 100
+	if count = math.Minimum(count, len(nodes)); count <= 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: getNodeSelectionForLogging(selection), Reason: "no node available to select"}
+	}
+	if selection.CapacityOrder == "" && selection.MaxPerNodePool <= 0 {
+		return sampleNodes(nodes, count, chaosDetails), nil
+	}
+
+	// the nodes are shuffled first, so that the nodes with the same capacity are picked at random
+	ordered := sampleRandom(nodes, len(nodes), chaosDetails.GetRandom())
+	switch selection.CapacityOrder {
+	case "":
+	case types.MostAllocatable, types.LeastAllocatable:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			if selection.CapacityOrder == types.MostAllocatable {
+				return compareAllocatable(ordered[i], ordered[j]) > 0
+			}
+			return compareAllocatable(ordered[i], ordered[j]) < 0
+		})
+	default:
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{capacityOrder: %s}", selection.CapacityOrder), Reason: fmt.Sprintf("unsupported capacity order, expected one of %s or %s", types.MostAllocatable, types.LeastAllocatable)}
+	}
+
+	if selection.MaxPerNodePool <= 0 {
+		return ordered[:count], nil
+	}
+	selected := takePerGroup(ordered, count, selection.MaxPerNodePool, func(node apiv1.Node) string {
+		return getNodePool(node, selection.NodePoolLabel)
+	})
+	if len(selected) < count {
+		log.Warnf("[Chaos]: Only %v nodes can be selected with at most %v nodes per node pool", len(selected), selection.MaxPerNodePool)
+	}
+	return selected, nil
+}
+
+// getWorkloadNodes returns the nodes hosting the pods of the given workloads
+func getWorkloadNodes(workloadTargets string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (map[string]bool, error) {
+	targets, err := types.ParseTargets(workloadTargets)
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{nodeWorkloadTargets: %s}", workloadTargets), Reason: err.Error()}
+	}
+
+	// all the pods of the workloads are considered, irrespective of the pods affected percentage and the sampling strategy
+	workloadDetails := *chaosDetails
+	workloadDetails.AppDetail, workloadDetails.TargetsError = targets, nil
+	workloadDetails.Sampling = types.Sampling{Strategy: types.SamplingRandom}
+	pods, err := GetTargetPodsWhenTargetPodsENVNotSet(100, clients, &workloadDetails)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "could not get the pods of the node workload targets")
+	}
+
+	nodes := map[string]bool{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" {
+			nodes[pod.Spec.NodeName] = true
+		}
+	}
+	return nodes, nil
+}
+
+// isControlPlaneNode checks whether the node is a control plane node, by its role labels or taints
+func isControlPlaneNode(node apiv1.Node) bool {
+	for _, label := range controlPlaneLabels {
+		if _, ok := node.Labels[label]; ok {
+			return true
+		}
+	}
+	for _, taint := range node.Spec.Taints {
+		if Contains(taint.Key, controlPlaneLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareAllocatable compares the allocatable cpu of the nodes, followed by their allocatable memory
+func compareAllocatable(a, b apiv1.Node) int {
+	if c := a.Status.Allocatable.Cpu().Cmp(*b.Status.Allocatable.Cpu()); c != 0 {
+		return c
+	}
+	return a.Status.Allocatable.Memory().Cmp(*b.Status.Allocatable.Memory())
+}
+
+// getNodePool returns the node pool of the node, from the given label or the well known node pool labels
+// the nodes without any node pool are considered as separate pools
+func getNodePool(node apiv1.Node, poolLabel string) string {
+	labels := nodePoolLabels
+	if poolLabel != "" {
+		labels = []string{poolLabel}
+	}
+	for _, label := range labels {
+		if pool := node.Labels[label]; pool != "" {
+			return pool
+		}
+	}
+	return "node/" + node.Name
+}
+
+// getNodeSelectionForLogging returns the node selection details for the logs and errors
+func getNodeSelectionForLogging(selection types.NodeSelection) string {
+	var details []string
+	if selection.WorkloadTargets != "" {
+		details = append(details, fmt.Sprintf("workloads: %s", selection.WorkloadTargets))
+	}
+	if selection.ExcludeControlPlane {
+		details = append(details, "excludeControlPlane: true")
+	}
+	if len(selection.Zones) != 0 {
+		details = append(details, fmt.Sprintf("zones: %v", selection.Zones))
+	}
+	return "{" + strings.Join(details, ", ") + "}"
+}
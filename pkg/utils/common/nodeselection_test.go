@@ -0,0 +1,109 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testNode(name, zone, pool, cpu string, labels ...string) *core_v1.Node {
+	node := &core_v1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{"topology.kubernetes.io/zone": zone, "agentpool": pool}},
+		Status:     core_v1.NodeStatus{Allocatable: core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse(cpu)}},
+	}
+	for _, label := range labels {
+		node.Labels[label] = ""
+	}
+	return node
+}
+
+func testNodeClients() clients.ClientSets {
+	return clients.ClientSets{KubeClient: fake.NewSimpleClientset(
+		testNode("control-plane", "zone-a", "system", "2", "node-role.kubernetes.io/control-plane"),
+		testNode("node-1", "zone-a", "pool-a", "4"),
+		testNode("node-2", "zone-a", "pool-a", "8"),
+		testNode("node-3", "zone-b", "pool-b", "16"),
+		testNode("node-4", "zone-b", "pool-b", "1"),
+		&core_v1.Pod{ObjectMeta: v1.ObjectMeta{Name: "nginx-0", Namespace: "default"}, Spec: core_v1.PodSpec{NodeName: "node-4"}},
+	)}
+}
+
+func TestGetNodeListWithNodeSelection(t *testing.T) {
+	clientSets := testNodeClients()
+
+	testCases := map[string]struct {
+		selection   types.NodeSelection
+		nodeAffPerc int
+		expected    []string
+	}{
+		"exclude control plane": {
+			selection:   types.NodeSelection{ExcludeControlPlane: true, CapacityOrder: types.LeastAllocatable},
+			nodeAffPerc: 100,
+			expected:    []string{"node-4", "node-1", "node-2", "node-3"},
+		},
+		"zone": {
+			selection:   types.NodeSelection{Zones: []string{"zone-b"}, CapacityOrder: types.MostAllocatable},
+			nodeAffPerc: 100,
+			expected:    []string{"node-3", "node-4"},
+		},
+		"most allocatable": {
+			selection:   types.NodeSelection{CapacityOrder: types.MostAllocatable},
+			nodeAffPerc: 40,
+			expected:    []string{"node-3", "node-2"},
+		},
+		"max per node pool": {
+			selection:   types.NodeSelection{ExcludeControlPlane: true, CapacityOrder: types.MostAllocatable, MaxPerNodePool: 1},
+			nodeAffPerc: 100,
+			expected:    []string{"node-3", "node-2"},
+		},
+		"nodes affected above 100": {
+			selection:   types.NodeSelection{ExcludeControlPlane: true, CapacityOrder: types.MostAllocatable},
+			nodeAffPerc: 150,
+			expected:    []string{"node-3", "node-2", "node-1", "node-4"},
+		},
+		"nodes affected above 100 with max per node pool": {
+			selection:   types.NodeSelection{CapacityOrder: types.LeastAllocatable, MaxPerNodePool: 1},
+			nodeAffPerc: 150,
+			expected:    []string{"node-4", "control-plane", "node-1"},
+		},
+		"workload placement": {
+			selection:   types.NodeSelection{WorkloadTargets: "pod:default:[nginx-0]"},
+			nodeAffPerc: 100,
+			expected:    []string{"node-4"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			nodes, err := GetNodeList("", "", tc.nodeAffPerc, clientSets, &types.ChaosDetails{NodeSelection: tc.selection})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, nodes)
+		})
+	}
+}
+
+func TestSelectNodesWithoutNodes(t *testing.T) {
+	_, err := selectNodes(nil, 1, &types.ChaosDetails{NodeSelection: types.NodeSelection{CapacityOrder: types.MostAllocatable}})
+	assert.ErrorContains(t, err, "no node available to select")
+}
+
+func TestGetNodeNameWithNodeSelection(t *testing.T) {
+	clientSets := testNodeClients()
+
+	node, err := GetNodeName("default", "app=nginx", "", clientSets, &types.ChaosDetails{NodeSelection: types.NodeSelection{Zones: []string{"zone-a"}, CapacityOrder: types.MostAllocatable}})
+	require.NoError(t, err)
+	assert.Equal(t, "node-2", node)
+
+	_, err = GetNodeName("default", "app=nginx", "", clientSets, &types.ChaosDetails{NodeSelection: types.NodeSelection{Zones: []string{"zone-c"}}})
+	assert.ErrorContains(t, err, "no node found matching the node selection")
+
+	_, err = GetNodeName("default", "app=nginx", "", clientSets, &types.ChaosDetails{NodeSelection: types.NodeSelection{CapacityOrder: "largest"}})
+	assert.ErrorContains(t, err, "unsupported capacity order")
+}
@@ -3,7 +3,6 @@ package common
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"os"
 	"os/exec"
 	"strconv"
@@ -16,7 +15,7 @@ import (
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/telemetry"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/litmuschaos/litmus-go/pkg/workloads"
@@ -229,10 +228,14 @@ func SetTargets(target, chaosStatus, kind string, chaosDetails *types.ChaosDetai
 
 	for i := range chaosDetails.Targets {
 		if chaosDetails.Targets[i].Name == target {
+			if chaosDetails.Targets[i].ChaosStatus != chaosStatus {
+				telemetry.RecordTarget(context.Background(), kind, chaosStatus)
+			}
 			chaosDetails.Targets[i].ChaosStatus = chaosStatus
 			return
 		}
 	}
+	telemetry.RecordTarget(context.Background(), kind, chaosStatus)
 	newTarget := v1alpha1.TargetDetails{
 		Name:        target,
 		Kind:        kind,
@@ -262,44 +265,76 @@ func GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc int, clients clients.Client
 
 	var finalPods core_v1.PodList
 	var podKind = false
+	if chaosDetails.TargetsError != nil {
+		return finalPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: chaosDetails.TargetsError.Error()}
+	}
+
 	if len(chaosDetails.AppDetail) == 1 && chaosDetails.AppDetail[0].Kind == "KIND" {
 		// select random pod from ns
-		pods, err := FilterNonChaosPods(chaosDetails.AppDetail[0].Namespace, "", clients, chaosDetails)
+		target := chaosDetails.AppDetail[0]
+		namespaces, err := workloads.GetTargetNamespaces(target, clients)
 		if err != nil {
-			return finalPods, stacktrace.Propagate(err, "could not filter non chaos pods")
+			return finalPods, stacktrace.Propagate(err, "could not get target namespaces")
+		}
+		for _, ns := range namespaces {
+			pods, err := FilterNonChaosPods(ns, "", clients, chaosDetails)
+			if err != nil {
+				return finalPods, stacktrace.Propagate(err, "could not filter non chaos pods")
+			}
+			finalPods.Items = append(finalPods.Items, filterPodsByTarget(pods.Items, target)...)
 		}
-		return filterPodsByPercentage(pods, podAffPerc), nil
+		if len(finalPods.Items) == 0 {
+			return finalPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: GetAppDetailsForLogging(chaosDetails.AppDetail), Reason: "no target pods found"}
+		}
+		return samplePods(finalPods, podAffPerc, clients, chaosDetails)
 	}
 
-	for _, target := range chaosDetails.AppDetail {
-		switch target.Kind {
-		case "pod":
-			for _, name := range target.Names {
-				pod, err := clients.KubeClient.CoreV1().Pods(target.Namespace).Get(context.Background(), name, v1.GetOptions{})
-				if err != nil {
-					return finalPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{podName: %s, namespace: %s}", name, target.Namespace), Reason: err.Error()}
-				}
-				finalPods.Items = append(finalPods.Items, *pod)
-			}
-			podKind = true
-		default:
-			if target.Names != nil {
-				pods, err := workloads.GetPodsFromWorkloads(target, clients)
-				if err != nil {
-					return finalPods, stacktrace.Propagate(err, "could not get pods from workloads")
-				}
-				finalPods.Items = append(finalPods.Items, pods.Items...)
-			} else {
-				for _, label := range target.Labels {
-					pods, err := clients.KubeClient.CoreV1().Pods(target.Namespace).List(context.Background(), v1.ListOptions{LabelSelector: label})
+	for _, appDetail := range chaosDetails.AppDetail {
+		namespaces, err := workloads.GetTargetNamespaces(appDetail, clients)
+		if err != nil {
+			return finalPods, stacktrace.Propagate(err, "could not get target namespaces")
+		}
+		// the names are looked up inside all the matching namespaces, if the namespace is a pattern
+		// so the targets missing in some of the namespaces are skipped
+		isPattern := appDetail.IsNamespacePattern()
+		for _, ns := range namespaces {
+			target := appDetail
+			target.Namespace = ns
+			switch target.Kind {
+			case "pod":
+				for _, name := range target.Names {
+					pod, err := clients.KubeClient.CoreV1().Pods(target.Namespace).Get(context.Background(), name, v1.GetOptions{})
 					if err != nil {
-						return finalPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", label, target.Namespace), Reason: err.Error()}
+						if isPattern && k8serrors.IsNotFound(err) {
+							continue
+						}
+						return finalPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{podName: %s, namespace: %s}", name, target.Namespace), Reason: err.Error()}
 					}
-					filteredPods, err := filterPodsByOwnerKind(pods.Items, target, clients)
+					finalPods.Items = append(finalPods.Items, filterPodsByTarget([]core_v1.Pod{*pod}, target)...)
+				}
+				podKind = true
+			default:
+				if target.Names != nil {
+					pods, err := workloads.GetPodsFromWorkloads(target, clients)
 					if err != nil {
-						return finalPods, stacktrace.Propagate(err, "could not identify parent type from pod")
+						if isPattern && cerrors.GetErrorType(err) == cerrors.ErrorTypeTargetSelection {
+							continue
+						}
+						return finalPods, stacktrace.Propagate(err, "could not get pods from workloads")
+					}
+					finalPods.Items = append(finalPods.Items, pods.Items...)
+				} else {
+					for _, label := range target.Labels {
+						pods, err := clients.KubeClient.CoreV1().Pods(target.Namespace).List(context.Background(), v1.ListOptions{LabelSelector: label})
+						if err != nil {
+							return finalPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{podLabel: %s, namespace: %s}", label, target.Namespace), Reason: err.Error()}
+						}
+						filteredPods, err := filterPodsByOwnerKind(filterPodsByTarget(pods.Items, target), target, clients)
+						if err != nil {
+							return finalPods, stacktrace.Propagate(err, "could not identify parent type from pod")
+						}
+						finalPods.Items = append(finalPods.Items, filteredPods...)
 					}
-					finalPods.Items = append(finalPods.Items, filteredPods...)
 				}
 			}
 		}
@@ -312,13 +347,24 @@ func GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc int, clients clients.Client
 	if podKind {
 		return finalPods, nil
 	}
-	return filterPodsByPercentage(finalPods, podAffPerc), nil
+	return samplePods(finalPods, podAffPerc, clients, chaosDetails)
+}
+
+// filterPodsByTarget filters the pods by the field and annotation requirements of the target
+func filterPodsByTarget(pods []core_v1.Pod, target types.AppDetails) []core_v1.Pod {
+	var filteredPods []core_v1.Pod
+	for i := range pods {
+		if target.MatchesPod(&pods[i]) {
+			filteredPods = append(filteredPods, pods[i])
+		}
+	}
+	return filteredPods
 }
 
 func filterPodsByOwnerKind(pods []core_v1.Pod, target types.AppDetails, clients clients.ClientSets) ([]core_v1.Pod, error) {
 	var filteredPods []core_v1.Pod
 	for _, pod := range pods {
-		parentType, _, err := workloads.GetPodOwnerTypeAndName(&pod, clients.DynamicClient)
+		parentType, _, err := workloads.GetPodOwnerTypeAndName(&pod, clients)
 		if err != nil {
 			return nil, err
 		}
@@ -329,23 +375,6 @@ func filterPodsByOwnerKind(pods []core_v1.Pod, target types.AppDetails, clients
 	return filteredPods, nil
 }
 
-func filterPodsByPercentage(finalPods core_v1.PodList, podAffPerc int) core_v1.PodList {
-	finalPods = removeDuplicatePods(finalPods)
-
-	newPodListLength := math.Maximum(1, math.Adjustment(math.Minimum(podAffPerc, 100), len(finalPods.Items)))
-	rand.Seed(time.Now().UnixNano())
-
-	var realPods core_v1.PodList
-	// it will generate the random podlist
-	// it starts from the random index and choose requirement no of pods next to that index in a circular way.
-	index := rand.Intn(len(finalPods.Items))
-	for i := 0; i < newPodListLength; i++ {
-		realPods.Items = append(realPods.Items, finalPods.Items[index])
-		index = (index + 1) % len(finalPods.Items)
-	}
-	return realPods
-}
-
 // DeleteHelperPodBasedOnJobCleanupPolicy deletes specific helper pod based on jobCleanupPolicy
 func DeleteHelperPodBasedOnJobCleanupPolicy(podName, podLabel string, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
 
@@ -477,16 +506,19 @@ func GetPodListFromSpecifiedNodes(podAffPerc int, nodeLabel string, clients clie
 
 	log.Infof("[Chaos]:Looking for pods with specified attributes on nodes targeted: %v", nodeNames)
 
-	pods, err := GetPodList("", 100, clients, chaosDetails)
+	// all the pods are listed first, the sampling strategy is applied only on the pods of the targeted nodes
+	allPodsDetails := *chaosDetails
+	allPodsDetails.Sampling = types.Sampling{Strategy: types.SamplingRandom}
+	pods, err := GetPodList("", 100, clients, &allPodsDetails)
 	if err != nil {
 		return core_v1.PodList{}, err
 	}
 
-	return getTargetPodsWhenNodeFilterSet(podAffPerc, pods, nodeNames)
+	return getTargetPodsWhenNodeFilterSet(podAffPerc, pods, nodeNames, clients, chaosDetails)
 }
 
 // getTargetPodsWhenNodeFilterSet will give the target pod when the node filter is setup
-func getTargetPodsWhenNodeFilterSet(podAffPerc int, pods core_v1.PodList, nodes []string) (core_v1.PodList, error) {
+func getTargetPodsWhenNodeFilterSet(podAffPerc int, pods core_v1.PodList, nodes []string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
 	nodeFilteredPods := core_v1.PodList{}
 
 	// add filter for pods which are on derived node list
@@ -504,7 +536,7 @@ func getTargetPodsWhenNodeFilterSet(podAffPerc int, pods core_v1.PodList, nodes
 		return nodeFilteredPods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{nodes: %v}", nodes), Reason: "no pod found on specified node(s)"}
 	}
 
-	return filterPodsByPercentage(nodeFilteredPods, podAffPerc), nil
+	return samplePods(nodeFilteredPods, podAffPerc, clients, chaosDetails)
 }
 
 func GetTargetPods(nodeLabel, targetPods, podsAffectedPerc string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
@@ -630,11 +662,21 @@ func ParseTargets(source string) (*TargetsDetails, error) {
 func GetAppDetailsForLogging(appDetails []types.AppDetails) string {
 	var result []string
 	for _, k := range appDetails {
+		var clauses string
+		if k.Fields != nil {
+			clauses += fmt.Sprintf(", fields: %s", k.Fields.String())
+		}
+		if k.Annotations != nil {
+			clauses += fmt.Sprintf(", annotations: %s", k.Annotations.String())
+		}
+		if k.NamespaceLabels != nil {
+			clauses += fmt.Sprintf(", namespaceLabels: %s", k.NamespaceLabels.String())
+		}
 		if k.Labels != nil {
-			result = append(result, fmt.Sprintf("{namespace: %s, kind: %s, labels: %s}", k.Namespace, k.Kind, k.Labels))
+			result = append(result, fmt.Sprintf("{namespace: %s, kind: %s, labels: %s%s}", k.Namespace, k.Kind, k.Labels, clauses))
 			continue
 		}
-		result = append(result, fmt.Sprintf("{namespace: %s, kind: %s, names: %s}", k.Namespace, k.Kind, k.Names))
+		result = append(result, fmt.Sprintf("{namespace: %s, kind: %s, names: %s%s}", k.Namespace, k.Kind, k.Names, clauses))
 	}
 	if len(result) != 0 {
 		return fmt.Sprintf("[%v]", strings.Join(result, ","))
@@ -0,0 +1,214 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// zoneLabels contains the topology labels of the zone, in the order of preference
+var zoneLabels = []string{"topology.kubernetes.io/zone", "failure-domain.beta.kubernetes.io/zone"}
+
+// samplePods selects the target pods from the given pods, based on the sampling strategy and the pods affected percentage
+func samplePods(pods core_v1.PodList, podAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
+	pods = removeDuplicatePods(pods)
+	count := math.Maximum(1, math.Adjustment(math.Minimum(podAffPerc, 100), len(pods.Items)))
+
+	var (
+		sampled []core_v1.Pod
+		err     error
+		random  = chaosDetails.GetRandom()
+	)
+	switch chaosDetails.Sampling.Strategy {
+	case types.SamplingRandom, "":
+		sampled = sampleRandom(pods.Items, count, random)
+	case types.SamplingPerNode:
+		sampled = samplePerGroup(pods.Items, count, 1, random, func(pod core_v1.Pod) string { return pod.Spec.NodeName })
+	case types.SamplingPerWorkload:
+		sampled = samplePerGroup(pods.Items, count, math.Maximum(1, chaosDetails.Sampling.MaxPerWorkload), random, getWorkloadKey)
+	case types.SamplingSpreadZones, types.SamplingSingleZone:
+		zones, err := getNodeZones(clients)
+		if err != nil {
+			return core_v1.PodList{}, err
+		}
+		zoneOf := func(pod core_v1.Pod) string { return zones[pod.Spec.NodeName] }
+		if chaosDetails.Sampling.Strategy == types.SamplingSpreadZones {
+			sampled = spreadAcrossGroups(pods.Items, count, random, zoneOf)
+		} else {
+			sampled = pickGroup(pods.Items, random, zoneOf)
+		}
+	case types.SamplingLeader:
+		sampled, err = sampleLeaders(pods.Items, chaosDetails.Sampling.LeaderSelector)
+		if err != nil {
+			return core_v1.PodList{}, err
+		}
+	default:
+		return core_v1.PodList{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{samplingStrategy: %s}", chaosDetails.Sampling.Strategy), Reason: "unsupported sampling strategy"}
+	}
+
+	if len(sampled) == 0 {
+		return core_v1.PodList{}, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{samplingStrategy: %s}", chaosDetails.Sampling.Strategy), Reason: "no target pods found with the sampling strategy"}
+	}
+	log.Infof("[Chaos]: %v of %v pods are selected using %v sampling strategy", len(sampled), len(pods.Items), chaosDetails.Sampling.GetStrategy())
+	return core_v1.PodList{Items: sampled}, nil
+}
+
+// sampleNodes selects the target nodes from the given nodes, based on the sampling strategy
+// the pod specific strategies aren't applicable for the nodes, so the random sampling is used for them
+func sampleNodes(nodes []core_v1.Node, count int, chaosDetails *types.ChaosDetails) []core_v1.Node {
+	random := chaosDetails.GetRandom()
+	switch chaosDetails.Sampling.Strategy {
+	case types.SamplingSpreadZones:
+		return spreadAcrossGroups(nodes, count, random, getNodeZone)
+	case types.SamplingSingleZone:
+		return pickGroup(nodes, random, getNodeZone)
+	case types.SamplingRandom, "":
+	default:
+		log.Warnf("[Chaos]: %v sampling strategy isn't applicable for the nodes, using random sampling", chaosDetails.Sampling.Strategy)
+	}
+	return sampleRandom(nodes, count, random)
+}
+
+// sampleRandom selects the given number of items uniformly at random
+func sampleRandom[T any](items []T, count int, random *rand.Rand) []T {
+	count = math.Minimum(count, len(items))
+	sampled := make([]T, 0, count)
+	for _, index := range random.Perm(len(items))[:count] {
+		sampled = append(sampled, items[index])
+	}
+	return sampled
+}
+
+// samplePerGroup selects the given number of items uniformly at random, with at most max items from each group
+func samplePerGroup[T any](items []T, count, max int, random *rand.Rand, groupOf func(T) string) []T {
+	return takePerGroup(sampleRandom(items, len(items), random), count, max, groupOf)
+}
+
+// takePerGroup selects the given number of items in their order, with at most max items from each group
+func takePerGroup[T any](items []T, count, max int, groupOf func(T) string) []T {
+	var sampled []T
+	selected := map[string]int{}
+	for _, item := range items {
+		if len(sampled) == count {
+			break
+		}
+		group := groupOf(item)
+		if selected[group] < max {
+			selected[group]++
+			sampled = append(sampled, item)
+		}
+	}
+	return sampled
+}
+
+// spreadAcrossGroups selects the given number of items, picking them from the groups in a round robin way
+// so that the selected items are spread evenly across the groups
+func spreadAcrossGroups[T any](items []T, count int, random *rand.Rand, groupOf func(T) string) []T {
+	groups, names := groupItems(sampleRandom(items, len(items), random), groupOf)
+	random.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+
+	total := 0
+	for _, name := range names {
+		total += len(groups[name])
+	}
+
+	var sampled []T
+	for len(sampled) < math.Minimum(count, total) {
+		for _, name := range names {
+			if len(groups[name]) != 0 && len(sampled) < count {
+				sampled = append(sampled, groups[name][0])
+				groups[name] = groups[name][1:]
+			}
+		}
+	}
+	return sampled
+}
+
+// pickGroup selects all the items of a random group, like all the replicas in one zone for the zone outage
+func pickGroup[T any](items []T, random *rand.Rand, groupOf func(T) string) []T {
+	groups, names := groupItems(items, groupOf)
+	if len(names) == 0 {
+		return nil
+	}
+	name := names[random.Intn(len(names))]
+	log.Infof("[Chaos]: Selecting all the targets in the %v zone", name)
+	return groups[name]
+}
+
+// groupItems groups the items, it returns the groups along with their sorted names
+// the items without any group are ignored
+func groupItems[T any](items []T, groupOf func(T) string) (map[string][]T, []string) {
+	groups := map[string][]T{}
+	var names []string
+	for _, item := range items {
+		group := groupOf(item)
+		if group == "" {
+			continue
+		}
+		if _, ok := groups[group]; !ok {
+			names = append(names, group)
+		}
+		groups[group] = append(groups[group], item)
+	}
+	sort.Strings(names)
+	return groups, names
+}
+
+// sampleLeaders selects the pods, whose labels or annotations match the leader selector
+func sampleLeaders(pods []core_v1.Pod, selector string) ([]core_v1.Pod, error) {
+	requirements, err := types.ParseRequirements(selector)
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{leaderSelector: %s}", selector), Reason: err.Error()}
+	}
+	if len(requirements) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{leaderSelector: %s}", selector), Reason: "leader selector is required for the leader sampling strategy"}
+	}
+
+	var leaders []core_v1.Pod
+	for _, pod := range pods {
+		if requirements.Matches(pod.Labels) || requirements.Matches(pod.Annotations) {
+			leaders = append(leaders, pod)
+		}
+	}
+	return leaders, nil
+}
+
+// getWorkloadKey returns the controller of the pod, the pods without any controller are considered as separate workloads
+func getWorkloadKey(pod core_v1.Pod) string {
+	if owner := v1.GetControllerOf(&pod); owner != nil {
+		return pod.Namespace + "/" + owner.Kind + "/" + owner.Name
+	}
+	return pod.Namespace + "/Pod/" + pod.Name
+}
+
+// getNodeZones returns the zones of all the nodes
+func getNodeZones(clients clients.ClientSets) (map[string]string, error) {
+	nodes, err := clients.KubeClient.CoreV1().Nodes().List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Reason: fmt.Sprintf("failed to list the nodes: %s", err.Error())}
+	}
+	zones := map[string]string{}
+	for _, node := range nodes.Items {
+		zones[node.Name] = getNodeZone(node)
+	}
+	return zones, nil
+}
+
+// getNodeZone returns the zone of the node from its topology labels
+func getNodeZone(node core_v1.Node) string {
+	for _, label := range zoneLabels {
+		if zone := node.Labels[label]; zone != "" {
+			return zone
+		}
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// testTopology returns 6 pods of two workloads, spread across 3 nodes in 2 zones
+func testTopology() (core_v1.PodList, clients.ClientSets) {
+	zones := map[string]string{"node-1": "zone-a", "node-2": "zone-a", "node-3": "zone-b"}
+	var nodes []runtime.Object
+	for name, zone := range zones {
+		nodes = append(nodes, &core_v1.Node{ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{"topology.kubernetes.io/zone": zone}}})
+	}
+
+	var pods core_v1.PodList
+	for i := 0; i < 6; i++ {
+		controller := true
+		pods.Items = append(pods.Items, core_v1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:            fmt.Sprintf("pod-%d", i),
+				Namespace:       "default",
+				Labels:          map[string]string{"role": map[bool]string{true: "leader", false: "follower"}[i == 0]},
+				OwnerReferences: []v1.OwnerReference{{Kind: "ReplicaSet", Name: fmt.Sprintf("app-%d", i%2), Controller: &controller}},
+			},
+			Spec: core_v1.PodSpec{NodeName: fmt.Sprintf("node-%d", i%3+1)},
+		})
+	}
+	return pods, clients.ClientSets{KubeClient: fake.NewSimpleClientset(nodes...)}
+}
+
+func TestSamplePods(t *testing.T) {
+	pods, clientSets := testTopology()
+	zoneOf := map[string]string{"node-1": "zone-a", "node-2": "zone-a", "node-3": "zone-b"}
+
+	testCases := map[string]struct {
+		sampling   types.Sampling
+		podAffPerc int
+		verify     func(t *testing.T, sampled []core_v1.Pod)
+	}{
+		"random": {
+			sampling:   types.Sampling{Strategy: types.SamplingRandom},
+			podAffPerc: 50,
+			verify:     func(t *testing.T, sampled []core_v1.Pod) { assert.Len(t, sampled, 3) },
+		},
+		"per node": {
+			sampling:   types.Sampling{Strategy: types.SamplingPerNode},
+			podAffPerc: 100,
+			verify: func(t *testing.T, sampled []core_v1.Pod) {
+				assert.Len(t, sampled, 3)
+				nodes := map[string]bool{}
+				for _, pod := range sampled {
+					nodes[pod.Spec.NodeName] = true
+				}
+				assert.Len(t, nodes, 3)
+			},
+		},
+		"per workload": {
+			sampling:   types.Sampling{Strategy: types.SamplingPerWorkload, MaxPerWorkload: 2},
+			podAffPerc: 100,
+			verify:     func(t *testing.T, sampled []core_v1.Pod) { assert.Len(t, sampled, 4) },
+		},
+		"spread zones": {
+			sampling:   types.Sampling{Strategy: types.SamplingSpreadZones},
+			podAffPerc: 34,
+			verify: func(t *testing.T, sampled []core_v1.Pod) {
+				require.Len(t, sampled, 2)
+				assert.NotEqual(t, zoneOf[sampled[0].Spec.NodeName], zoneOf[sampled[1].Spec.NodeName])
+			},
+		},
+		"single zone": {
+			sampling:   types.Sampling{Strategy: types.SamplingSingleZone},
+			podAffPerc: 10,
+			verify: func(t *testing.T, sampled []core_v1.Pod) {
+				require.NotEmpty(t, sampled)
+				zone := zoneOf[sampled[0].Spec.NodeName]
+				for _, pod := range sampled {
+					assert.Equal(t, zone, zoneOf[pod.Spec.NodeName])
+				}
+				assert.Len(t, sampled, map[string]int{"zone-a": 4, "zone-b": 2}[zone])
+			},
+		},
+		"leader": {
+			sampling:   types.Sampling{Strategy: types.SamplingLeader, LeaderSelector: "role=leader"},
+			podAffPerc: 100,
+			verify: func(t *testing.T, sampled []core_v1.Pod) {
+				require.Len(t, sampled, 1)
+				assert.Equal(t, "pod-0", sampled[0].Name)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sampled, err := samplePods(pods, tc.podAffPerc, clientSets, &types.ChaosDetails{Sampling: tc.sampling})
+			require.NoError(t, err)
+			tc.verify(t, sampled.Items)
+		})
+	}
+}
+
+func TestSamplePodsErrors(t *testing.T) {
+	pods, clientSets := testTopology()
+
+	_, err := samplePods(pods, 100, clientSets, &types.ChaosDetails{Sampling: types.Sampling{Strategy: "round-robin"}})
+	assert.ErrorContains(t, err, "unsupported sampling strategy")
+
+	_, err = samplePods(pods, 100, clientSets, &types.ChaosDetails{Sampling: types.Sampling{Strategy: types.SamplingLeader}})
+	assert.ErrorContains(t, err, "leader selector is required")
+
+	_, err = samplePods(pods, 100, clientSets, &types.ChaosDetails{Sampling: types.Sampling{Strategy: types.SamplingLeader, LeaderSelector: "role=primary"}})
+	assert.ErrorContains(t, err, "no target pods found")
+}
+
+func TestSamplingIsReproducibleWithSeed(t *testing.T) {
+	pods, clientSets := testTopology()
+
+	run := func(seed int64) ([]string, string, []string) {
+		chaosDetails := &types.ChaosDetails{Sampling: types.Sampling{Strategy: types.SamplingRandom}}
+		types.SetRandomSeed(chaosDetails, seed)
+		sampled, err := samplePods(pods, 50, clientSets, chaosDetails)
+		require.NoError(t, err)
+		var names []string
+		for _, pod := range sampled.Items {
+			names = append(names, pod.Name)
+		}
+		var order []string
+		err = InjectInSequence(context.Background(), types.SequenceShuffled, sampled.Items, clientSets, chaosDetails, func(_ context.Context, pods []core_v1.Pod) error {
+			for _, pod := range pods {
+				order = append(order, pod.Name)
+			}
+			return nil
+		}, nil)
+		require.NoError(t, err)
+		return names, ValidateRange("1-100", chaosDetails), order
+	}
+
+	names, value, sequence := run(42)
+	replayedNames, replayedValue, replayedSequence := run(42)
+	assert.Equal(t, names, replayedNames)
+	assert.Equal(t, value, replayedValue)
+	assert.Equal(t, sequence, replayedSequence)
+}
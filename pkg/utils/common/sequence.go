@@ -0,0 +1,172 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/status"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/palantir/stacktrace"
+)
+
+// InjectInSequence injects the chaos in the targets as per the sequence
+// serial and parallel are the injection modes of the chaoslib, the other sequences are built on top of them:
+// random picks serial or parallel at random, shuffled runs serial in a shuffled order, rolling runs parallel
+// for every batch of the targets and staggered runs parallel with random start offsets, which are honoured by WaitForStaggeredStart.
+// The random sequence is kept as is for the backward compatibility, as the chaoslibs resolve it through GetRandomSequence,
+// so the serial injection in a random order is requested with shuffled instead
+func InjectInSequence[T any](ctx context.Context, sequence string, targets []T, clients clients.ClientSets, chaosDetails *types.ChaosDetails, serial, parallel func(ctx context.Context, targets []T) error) error {
+	switch strings.ToLower(sequence) {
+	case types.SequenceSerial:
+		if err := serial(ctx, targets); err != nil {
+			return stacktrace.Propagate(err, "could not run chaos in serial mode")
+		}
+	case types.SequenceParallel:
+		if err := parallel(ctx, targets); err != nil {
+			return stacktrace.Propagate(err, "could not run chaos in parallel mode")
+		}
+	case types.SequenceRandom:
+		return InjectInSequence(ctx, GetRandomSequence(sequence, chaosDetails), targets, clients, chaosDetails, serial, parallel)
+	case types.SequenceShuffled:
+		if err := serial(ctx, sampleRandom(targets, len(targets), chaosDetails.GetRandom())); err != nil {
+			return stacktrace.Propagate(err, "could not run chaos in shuffled mode")
+		}
+	case types.SequenceRolling:
+		if err := injectInRollingBatches(ctx, targets, clients, chaosDetails, parallel); err != nil {
+			return stacktrace.Propagate(err, "could not run chaos in rolling mode")
+		}
+	case types.SequenceStaggered:
+		targets = sampleRandom(targets, len(targets), chaosDetails.GetRandom())
+		ctx = withStaggeredStart(ctx, len(targets), chaosDetails)
+		if err := parallel(ctx, targets); err != nil {
+			return stacktrace.Propagate(err, "could not run chaos in staggered mode")
+		}
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("'%s' sequence is not supported", sequence)}
+	}
+	return nil
+}
+
+// injectInRollingBatches injects the chaos in the batches of the targets, one batch at a time
+// it waits for the batch interval and checks the health of the application before moving to the next batch
+// the parallel mode runs the probes during chaos for every batch, they are started only for the first batch,
+// as the probes are marked as started in the probe context of the chaos details
+func injectInRollingBatches[T any](ctx context.Context, targets []T, clients clients.ClientSets, chaosDetails *types.ChaosDetails, parallel func(ctx context.Context, targets []T) error) error {
+	batchSize := chaosDetails.Sequencing.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	for start := 0; start < len(targets); start += batchSize {
+		end := start + batchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		log.Infof("[Chaos]: Injecting chaos in the batch %d of %d, with %d targets", start/batchSize+1, (len(targets)+batchSize-1)/batchSize, end-start)
+		if err := parallel(ctx, targets[start:end]); err != nil {
+			return err
+		}
+		if end == len(targets) {
+			break
+		}
+
+		if chaosDetails.Sequencing.BatchInterval > 0 {
+			log.Infof("[Wait]: Wait for the batch interval %vs", chaosDetails.Sequencing.BatchInterval)
+			if err := WaitForDurationWithContext(ctx, chaosDetails.Sequencing.BatchInterval); err != nil {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: fmt.Sprintf("experiment aborted between the batches, err: %v", err)}
+			}
+		}
+		if chaosDetails.DefaultHealthCheck {
+			log.Info("[Status]: Verify that the AUT (Application Under Test) is running, before the next batch")
+			if err := status.AUTStatusCheck(ctx, clients, chaosDetails); err != nil {
+				return stacktrace.Propagate(err, "application isn't healthy after the batch")
+			}
+		}
+	}
+	return nil
+}
+
+type staggeredStartKey struct{}
+
+// staggeredStart contains the start offsets of the targets, in the ascending order
+type staggeredStart struct {
+	mu      sync.Mutex
+	start   time.Time
+	offsets []time.Duration
+	next    int
+}
+
+// withStaggeredStart returns the context containing the random start offsets of the given number of targets
+// the offsets lie between zero and the max start offset, which defaults to half of the chaos duration
+func withStaggeredStart(ctx context.Context, count int, chaosDetails *types.ChaosDetails) context.Context {
+	maxOffset := chaosDetails.Sequencing.MaxStartOffset
+	if maxOffset <= 0 {
+		maxOffset = chaosDetails.ChaosDuration / 2
+	}
+
+	random := chaosDetails.GetRandom()
+	offsets := make([]time.Duration, count)
+	for i := range offsets {
+		offsets[i] = time.Duration(random.Int63n(int64(maxOffset)*1000+1)) * time.Millisecond
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	return context.WithValue(ctx, staggeredStartKey{}, &staggeredStart{start: time.Now(), offsets: offsets})
+}
+
+// WaitForStaggeredStart waits till the start offset of the next target, in the staggered sequence
+// the parallel modes should call it before injecting the chaos in every target, it returns immediately for the other sequences
+func WaitForStaggeredStart(ctx context.Context) error {
+	schedule, ok := ctx.Value(staggeredStartKey{}).(*staggeredStart)
+	if !ok {
+		return nil
+	}
+
+	schedule.mu.Lock()
+	if schedule.next >= len(schedule.offsets) {
+		schedule.mu.Unlock()
+		return nil
+	}
+	offset := schedule.offsets[schedule.next]
+	schedule.next++
+	schedule.mu.Unlock()
+
+	wait := time.Until(schedule.start.Add(offset))
+	if wait <= 0 {
+		return nil
+	}
+	log.Infof("[Wait]: Wait for the start offset %v of the next target", offset.Round(time.Second))
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: fmt.Sprintf("experiment aborted before the start offset, err: %v", context.Cause(ctx))}
+	}
+}
+
+// Indices returns the indices of a list of the given length
+// they're used as the targets of the sequence, if the details of the targets are spread across multiple lists
+func Indices(count int) []int {
+	indices := make([]int, count)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// SelectIndices returns the items at the given indices
+func SelectIndices[T any](items []T, indices []int) []T {
+	selected := make([]T, 0, len(indices))
+	for _, i := range indices {
+		selected = append(selected, items[i])
+	}
+	return selected
+}
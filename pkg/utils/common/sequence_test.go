@@ -0,0 +1,132 @@
+package common
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectInSequence(t *testing.T) {
+	targets := []int{1, 2, 3, 4, 5}
+
+	testCases := map[string]struct {
+		sequence   string
+		sequencing types.Sequencing
+		serial     [][]int
+		parallel   [][]int
+	}{
+		"serial":   {sequence: "Serial", serial: [][]int{targets}},
+		"parallel": {sequence: "parallel", parallel: [][]int{targets}},
+		"rolling": {
+			sequence:   "rolling",
+			sequencing: types.Sequencing{BatchSize: 2},
+			parallel:   [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		"rolling with invalid batch size": {
+			sequence: "rolling",
+			parallel: [][]int{{1}, {2}, {3}, {4}, {5}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			chaosDetails := &types.ChaosDetails{Sequencing: tc.sequencing}
+			var serial, parallel [][]int
+			err := InjectInSequence(context.Background(), tc.sequence, targets, clients.ClientSets{}, chaosDetails, func(_ context.Context, batch []int) error {
+				serial = append(serial, batch)
+				return nil
+			}, func(_ context.Context, batch []int) error {
+				parallel = append(parallel, batch)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.serial, serial)
+			assert.Equal(t, tc.parallel, parallel)
+		})
+	}
+}
+
+func TestInjectInRandomSequence(t *testing.T) {
+	chaosDetails := &types.ChaosDetails{}
+	types.SetRandomSeed(chaosDetails, 7)
+
+	modes := map[string]int{}
+	for i := 0; i < 20; i++ {
+		err := InjectInSequence(context.Background(), types.SequenceRandom, Indices(3), clients.ClientSets{}, chaosDetails, func(_ context.Context, batch []int) error {
+			modes["serial"]++
+			assert.Equal(t, Indices(3), batch)
+			return nil
+		}, func(_ context.Context, batch []int) error {
+			modes["parallel"]++
+			assert.Equal(t, Indices(3), batch)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	assert.Len(t, modes, 2, "both the modes should be picked")
+}
+
+func TestInjectInShuffledSequence(t *testing.T) {
+	targets := Indices(10)
+	chaosDetails := &types.ChaosDetails{}
+	types.SetRandomSeed(chaosDetails, 7)
+
+	var order []int
+	err := InjectInSequence(context.Background(), types.SequenceShuffled, targets, clients.ClientSets{}, chaosDetails, func(_ context.Context, batch []int) error {
+		order = batch
+		return nil
+	}, func(context.Context, []int) error {
+		t.Fatal("shuffled sequence shouldn't run in parallel mode")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, targets, order)
+	assert.False(t, sort.IntsAreSorted(order), "targets should be shuffled")
+}
+
+func TestInjectInUnsupportedSequence(t *testing.T) {
+	noop := func(context.Context, []int) error { return nil }
+	err := InjectInSequence(context.Background(), "reverse", []int{1}, clients.ClientSets{}, &types.ChaosDetails{}, noop, noop)
+	assert.ErrorContains(t, err, "'reverse' sequence is not supported")
+}
+
+func TestInjectInStaggeredSequence(t *testing.T) {
+	chaosDetails := &types.ChaosDetails{Sequencing: types.Sequencing{MaxStartOffset: 1}}
+	types.SetRandomSeed(chaosDetails, 7)
+
+	start := time.Now()
+	var waits []time.Duration
+	err := InjectInSequence(context.Background(), types.SequenceStaggered, Indices(3), clients.ClientSets{}, chaosDetails, func(context.Context, []int) error {
+		t.Fatal("staggered sequence shouldn't run in serial mode")
+		return nil
+	}, func(ctx context.Context, batch []int) error {
+		for range batch {
+			if err := WaitForStaggeredStart(ctx); err != nil {
+				return err
+			}
+			waits = append(waits, time.Since(start))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, waits, 3)
+	assert.True(t, sort.SliceIsSorted(waits, func(i, j int) bool { return waits[i] < waits[j] }))
+	assert.Less(t, waits[2], 2*time.Second)
+
+	// the other sequences don't wait
+	assert.NoError(t, WaitForStaggeredStart(context.Background()))
+}
+
+func TestWaitForStaggeredStartIsAborted(t *testing.T) {
+	schedule := &staggeredStart{start: time.Now(), offsets: []time.Duration{time.Minute}}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), staggeredStartKey{}, schedule))
+	cancel()
+
+	assert.ErrorContains(t, WaitForStaggeredStart(ctx), "aborted")
+}
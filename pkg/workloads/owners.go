@@ -0,0 +1,159 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmus-go/pkg/cerrors"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	kcorev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// Owner is a controller in the owner chain of a pod
+type Owner struct {
+	Resource   schema.GroupVersionResource
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	UID        k8stypes.UID
+}
+
+// OwnerResolver follows the controller references of the pods up to their top level controllers
+// the resources of the owner kinds are resolved through the discovery api, so any kind (including the custom resources) is supported
+// the controllers of the visited owners are cached, so every owner is fetched only once
+type OwnerResolver struct {
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+
+	mu          sync.Mutex
+	controllers map[string]*v1.OwnerReference
+}
+
+var (
+	resolversMu sync.Mutex
+	// resolvers contains the owner resolver of every dynamic client, the cache lives as long as the experiment run
+	resolvers = map[dynamic.Interface]*OwnerResolver{}
+)
+
+// NewOwnerResolver returns the owner resolver with an empty cache
+func NewOwnerResolver(clients clients.ClientSets) *OwnerResolver {
+	return &OwnerResolver{
+		dynamicClient: clients.DynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clients.KubeClient.Discovery())),
+		controllers:   map[string]*v1.OwnerReference{},
+	}
+}
+
+// GetOwnerResolver returns the shared owner resolver of the run, it is created on the first use
+func GetOwnerResolver(clients clients.ClientSets) *OwnerResolver {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	if resolver, ok := resolvers[clients.DynamicClient]; ok {
+		return resolver
+	}
+	resolver := NewOwnerResolver(clients)
+	resolvers[clients.DynamicClient] = resolver
+	return resolver
+}
+
+// GetOwnerChain returns the controllers of the pod, starting from its direct controller up to the top level controller
+// the walk stops at the owners, which can't be resolved (unknown kind, not found or forbidden)
+func (r *OwnerResolver) GetOwnerChain(ctx context.Context, pod *kcorev1.Pod) ([]Owner, error) {
+	var chain []Owner
+	controller := getController(pod)
+	for controller != nil {
+		gv, err := schema.ParseGroupVersion(controller.APIVersion)
+		if err != nil {
+			log.Warnf("[Owners]: Skipping the owner %v/%v with invalid apiVersion: %v", controller.Kind, controller.Name, controller.APIVersion)
+			break
+		}
+		mapping, err := r.mapper.RESTMapping(gv.WithKind(controller.Kind).GroupKind(), gv.Version)
+		if err != nil {
+			log.Warnf("[Owners]: Unable to resolve the resource of %v kind, err: %v", controller.Kind, err)
+			break
+		}
+
+		owner := Owner{Resource: mapping.Resource, APIVersion: controller.APIVersion, Kind: controller.Kind, Name: controller.Name, UID: controller.UID}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			owner.Namespace = pod.Namespace
+		}
+		chain = append(chain, owner)
+
+		controller, err = r.getControllerOf(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return chain, nil
+}
+
+// GetTopLevelOwner returns the top level controller of the pod, it returns nil for the pods without any controller
+func (r *OwnerResolver) GetTopLevelOwner(ctx context.Context, pod *kcorev1.Pod) (*Owner, error) {
+	chain, err := r.GetOwnerChain(ctx, pod)
+	if err != nil || len(chain) == 0 {
+		return nil, err
+	}
+	return &chain[len(chain)-1], nil
+}
+
+// getControllerOf returns the controller of the owner, the result is cached for the subsequent lookups
+func (r *OwnerResolver) getControllerOf(ctx context.Context, owner Owner) (*v1.OwnerReference, error) {
+	key := fmt.Sprintf("%s/%s/%s/%s", owner.Resource.String(), owner.Namespace, owner.Name, owner.UID)
+
+	r.mu.Lock()
+	controller, ok := r.controllers[key]
+	r.mu.Unlock()
+	if ok {
+		return controller, nil
+	}
+
+	obj, err := r.dynamicClient.Resource(owner.Resource).Namespace(owner.Namespace).Get(ctx, owner.Name, v1.GetOptions{})
+	switch {
+	case err == nil:
+		controller = getController(obj)
+	case k8serrors.IsNotFound(err), k8serrors.IsForbidden(err):
+		// the owner is treated as the top level controller, if it can't be read
+		log.Warnf("[Owners]: Unable to get the %v %v owner, err: %v", owner.Kind, owner.Name, err)
+	default:
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s, name: %s}", owner.Namespace, owner.Kind, owner.Name), Reason: err.Error()}
+	}
+
+	r.mu.Lock()
+	r.controllers[key] = controller
+	r.mu.Unlock()
+	return controller, nil
+}
+
+// getController returns the controller reference of the object
+// the first owner is used as the controller, if none of the owners is marked as the controller
+func getController(obj v1.Object) *v1.OwnerReference {
+	if controller := v1.GetControllerOf(obj); controller != nil {
+		return controller
+	}
+	if owners := obj.GetOwnerReferences(); len(owners) != 0 {
+		return &owners[0]
+	}
+	return nil
+}
+
+// GetPodOwnerTypeAndName returns the lowercase kind and name of the top level controller of the pod
+// it returns empty values for the pods without any controller
+func GetPodOwnerTypeAndName(pod *kcorev1.Pod, clients clients.ClientSets) (parentType, parentName string, err error) {
+	owner, err := GetOwnerResolver(clients).GetTopLevelOwner(context.Background(), pod)
+	if err != nil || owner == nil {
+		return "", "", err
+	}
+	return strings.ToLower(owner.Kind), owner.Name, nil
+}
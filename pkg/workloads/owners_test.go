@@ -0,0 +1,125 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testOwned(obj v1.Object, apiVersion, kind, name string) {
+	controller := true
+	obj.SetOwnerReferences([]v1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, Controller: &controller}})
+}
+
+func testObject(apiVersion, kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	return obj
+}
+
+// testClients returns a deployment with 3 pods and an argo rollout with 2 pods, along with an unmanaged pod
+func testClients() (*kcorev1.PodList, clients.ClientSets, *dynamicfake.FakeDynamicClient) {
+	rs := testObject("apps/v1", "ReplicaSet", "nginx-5d4f")
+	testOwned(rs, "apps/v1", "Deployment", "nginx")
+	rolloutRS := testObject("apps/v1", "ReplicaSet", "checkout-7c9b")
+	testOwned(rolloutRS, "argoproj.io/v1alpha1", "Rollout", "checkout")
+
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "apps", Version: "v1", Resource: "replicasets"}:           "ReplicaSetList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}:           "DeploymentList",
+		{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}: "RolloutList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+		rs, testObject("apps/v1", "Deployment", "nginx"), rolloutRS, testObject("argoproj.io/v1alpha1", "Rollout", "checkout"))
+
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*v1.APIResourceList{
+		{GroupVersion: "apps/v1", APIResources: []v1.APIResource{
+			{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true},
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		}},
+		{GroupVersion: "argoproj.io/v1alpha1", APIResources: []v1.APIResource{{Name: "rollouts", Kind: "Rollout", Namespaced: true}}},
+	}
+
+	pods := &kcorev1.PodList{}
+	for i := 0; i < 5; i++ {
+		pod := kcorev1.Pod{ObjectMeta: v1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: "default"}}
+		if i < 3 {
+			testOwned(&pod, "apps/v1", "ReplicaSet", "nginx-5d4f")
+		} else {
+			testOwned(&pod, "apps/v1", "ReplicaSet", "checkout-7c9b")
+		}
+		pods.Items = append(pods.Items, pod)
+	}
+	pods.Items = append(pods.Items, kcorev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "standalone", Namespace: "default"}})
+	return pods, clients.ClientSets{KubeClient: kubeClient, DynamicClient: dynamicClient}, dynamicClient
+}
+
+func TestGetOwnerChain(t *testing.T) {
+	pods, clientSets, dynamicClient := testClients()
+	resolver := NewOwnerResolver(clientSets)
+
+	chain, err := resolver.GetOwnerChain(context.Background(), &pods.Items[0])
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	assert.Equal(t, "ReplicaSet", chain[0].Kind)
+	assert.Equal(t, schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, chain[1].Resource)
+	assert.Equal(t, "default", chain[1].Namespace)
+
+	owner, err := resolver.GetTopLevelOwner(context.Background(), &pods.Items[3])
+	require.NoError(t, err)
+	assert.Equal(t, "Rollout", owner.Kind)
+	assert.Equal(t, "checkout", owner.Name)
+
+	owner, err = resolver.GetTopLevelOwner(context.Background(), &pods.Items[5])
+	require.NoError(t, err)
+	assert.Nil(t, owner)
+
+	// every owner is fetched only once, irrespective of the number of pods
+	for i := range pods.Items {
+		_, err := resolver.GetOwnerChain(context.Background(), &pods.Items[i])
+		require.NoError(t, err)
+	}
+	assert.Len(t, dynamicClient.Actions(), 4)
+}
+
+func TestGetOwnerChainStopsAtUnknownOwners(t *testing.T) {
+	pods, clientSets, _ := testClients()
+	pod := pods.Items[0]
+	testOwned(&pod, "kubevirt.io/v1", "VirtualMachineInstance", "vm")
+
+	chain, err := NewOwnerResolver(clientSets).GetOwnerChain(context.Background(), &pod)
+	require.NoError(t, err)
+	assert.Empty(t, chain)
+}
+
+func TestGetPodsFromWorkload(t *testing.T) {
+	pods, clientSets, _ := testClients()
+
+	found, err := getPodsFromWorkload(types.AppDetails{Kind: "rollout", Namespace: "default", Names: []string{"checkout"}}, pods, clientSets)
+	require.NoError(t, err)
+	assert.Len(t, found.Items, 2)
+
+	found, err = getPodsFromWorkload(types.AppDetails{Kind: "deployment", Namespace: "default", Names: []string{"nginx"}}, pods, clientSets)
+	require.NoError(t, err)
+	assert.Len(t, found.Items, 3)
+
+	_, err = getPodsFromWorkload(types.AppDetails{Kind: "deployment", Namespace: "default", Names: []string{"redis"}}, pods, clientSets)
+	assert.ErrorContains(t, err, "no pod found for specified target")
+}
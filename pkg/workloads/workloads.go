@@ -8,12 +8,10 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/palantir/stacktrace"
-	"strings"
 
 	kcorev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
 )
 
 type Workload struct {
@@ -22,20 +20,6 @@ type Workload struct {
 	Namespace string `json:"namespace"`
 }
 
-var (
-	gvrrc = schema.GroupVersionResource{
-		Group:    "",
-		Version:  "v1",
-		Resource: "replicacontrollers",
-	}
-
-	gvrrs = schema.GroupVersionResource{
-		Group:    "apps",
-		Version:  "v1",
-		Resource: "replicasets",
-	}
-)
-
 // GetPodsFromWorkloads derives the pods from the parent workloads
 func GetPodsFromWorkloads(target types.AppDetails, client clients.ClientSets) (kcorev1.PodList, error) {
 
@@ -43,65 +27,65 @@ func GetPodsFromWorkloads(target types.AppDetails, client clients.ClientSets) (k
 	if err != nil {
 		return kcorev1.PodList{}, stacktrace.Propagate(err, "could not get all pods")
 	}
-	return getPodsFromWorkload(target, allPods, client.DynamicClient)
+	return getPodsFromWorkload(target, allPods, client)
 }
 
-func getPodsFromWorkload(target types.AppDetails, allPods *kcorev1.PodList, dynamicClient dynamic.Interface) (kcorev1.PodList, error) {
-	var pods kcorev1.PodList
-	for _, wld := range target.Names {
-		found := false
-		for _, r := range allPods.Items {
-			ownerType, ownerName, err := GetPodOwnerTypeAndName(&r, dynamicClient)
-			if err != nil {
-				return pods, err
-			}
-			if ownerName == "" || ownerType == "" {
-				continue
-			}
-			if target.Kind == ownerType && wld == ownerName {
-				found = true
-				pods.Items = append(pods.Items, r)
-			}
-		}
-		if !found {
-			return pods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s, name: %s}", target.Namespace, target.Kind, wld), Reason: "no pod found for specified target"}
-		}
+// GetTargetNamespaces returns the namespaces of the target
+// the namespace pattern and the namespace labels of the target are resolved against all the namespaces
+func GetTargetNamespaces(target types.AppDetails, client clients.ClientSets) ([]string, error) {
+	if !target.IsNamespacePattern() {
+		return []string{target.Namespace}, nil
 	}
-	return pods, nil
-}
 
-func GetPodOwnerTypeAndName(pod *kcorev1.Pod, dynamicClient dynamic.Interface) (parentType, parentName string, err error) {
-	for _, owner := range pod.GetOwnerReferences() {
-		parentName = owner.Name
-		parentType = strings.ToLower(owner.Kind)
-		if owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet" {
-			return strings.ToLower(owner.Kind), parentName, nil
-		}
-
-		if owner.Kind == "ReplicaSet" && strings.HasSuffix(owner.Name, pod.Labels["pod-template-hash"]) {
-			return getParent(owner.Name, pod.Namespace, gvrrs, dynamicClient)
+	nsList, err := client.KubeClient.CoreV1().Namespaces().List(context.Background(), v1.ListOptions{LabelSelector: target.NamespaceLabels.String()})
+	if err != nil {
+		reason := err.Error()
+		if k8serrors.IsForbidden(err) {
+			// the namespaced roles of the experiments don't grant the access of the other namespaces
+			reason = fmt.Sprintf("the namespace patterns and labels need the list access of the namespaces, through a clusterrole, err: %v", err)
 		}
-
-		if owner.Kind == "ReplicaController" {
-			return getParent(owner.Name, pod.Namespace, gvrrc, dynamicClient)
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, namespaceLabels: %s}", target.Namespace, target.NamespaceLabels.String()), Reason: reason}
+	}
+	var namespaces []string
+	for _, ns := range nsList.Items {
+		if target.MatchesNamespace(ns.Name, ns.Labels) {
+			namespaces = append(namespaces, ns.Name)
 		}
 	}
-	return parentType, parentName, nil
+	if len(namespaces) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, namespaceLabels: %s}", target.Namespace, target.NamespaceLabels.String()), Reason: "no namespace found with matching pattern and labels"}
+	}
+	return namespaces, nil
 }
 
-func getParent(name, namespace string, gvr schema.GroupVersionResource, dynamicClient dynamic.Interface) (string, string, error) {
-	res, err := dynamicClient.Resource(gvr).Namespace(namespace).Get(context.Background(), name, v1.GetOptions{})
-	if err != nil {
-		return "", "", cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s, name: %s}", namespace, gvr.Resource, name), Reason: err.Error()}
+// getPodsFromWorkload returns the pods of the target workloads
+// the owner of every pod is resolved once and matched against all the workload names
+func getPodsFromWorkload(target types.AppDetails, allPods *kcorev1.PodList, client clients.ClientSets) (kcorev1.PodList, error) {
+	var pods kcorev1.PodList
+	workloadPods := map[string][]kcorev1.Pod{}
+	for _, r := range allPods.Items {
+		ownerType, ownerName, err := GetPodOwnerTypeAndName(&r, client)
+		if err != nil {
+			return pods, err
+		}
+		if ownerName == "" || ownerType == "" || target.Kind != ownerType {
+			continue
+		}
+		workloadPods[ownerName] = append(workloadPods[ownerName], r)
 	}
 
-	for _, v := range res.GetOwnerReferences() {
-		kind := strings.ToLower(v.Kind)
-		if kind == "deployment" || kind == "rollout" || kind == "deploymentconfig" {
-			return kind, v.Name, nil
+	for _, wld := range target.Names {
+		found, ok := workloadPods[wld]
+		if !ok {
+			return pods, cerrors.Error{ErrorCode: cerrors.ErrorTypeTargetSelection, Target: fmt.Sprintf("{namespace: %s, kind: %s, name: %s}", target.Namespace, target.Kind, wld), Reason: "no pod found for specified target"}
+		}
+		for i := range found {
+			if target.MatchesPod(&found[i]) {
+				pods.Items = append(pods.Items, found[i])
+			}
 		}
 	}
-	return "", "", nil
+	return pods, nil
 }
 
 func getAllPods(namespace string, client clients.ClientSets) (*kcorev1.PodList, error) {
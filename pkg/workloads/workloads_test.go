@@ -0,0 +1,51 @@
+package workloads
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetTargetNamespaces(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&kcorev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "team-a", Labels: map[string]string{"env": "prod"}}},
+		&kcorev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "team-b", Labels: map[string]string{"env": "dev"}}},
+		&kcorev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "default"}},
+	)
+	clientSets := clients.ClientSets{KubeClient: kubeClient}
+
+	targets, err := types.ParseTargets("deployment:team-*:[app=nginx]")
+	require.NoError(t, err)
+	namespaces, err := GetTargetNamespaces(targets[0], clientSets)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"team-a", "team-b"}, namespaces)
+
+	targets, err = types.ParseTargets("deployment:default:[app=nginx]")
+	require.NoError(t, err)
+	namespaces, err = GetTargetNamespaces(targets[0], clientSets)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, namespaces)
+}
+
+func TestGetTargetNamespacesIsForbidden(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", nil)
+	})
+
+	targets, err := types.ParseTargets("deployment:team-*:[app=nginx]")
+	require.NoError(t, err)
+	_, err = GetTargetNamespaces(targets[0], clients.ClientSets{KubeClient: kubeClient})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "through a clusterrole")
+}